@@ -3,10 +3,33 @@ package ast
 // Assert is used in tests.
 type Assert struct {
 	Expr *Binary
-	Pos  string
+
+	// Message is optional. It is shown when the assertion fails.
+	Message Node
+
+	Pos string
 }
 
 // Position returns the position.
 func (node *Assert) Position() string {
 	return node.Pos
 }
+
+// AssertRaise is used in tests to check that evaluating Expr raises an error
+// of Type, like:
+//
+//	assert(divide(1, 0) raise DivideByZero)
+type AssertRaise struct {
+	Expr Node
+	Type string
+
+	// Message is optional. It is shown when the assertion fails.
+	Message Node
+
+	Pos string
+}
+
+// Position returns the position.
+func (node *AssertRaise) Position() string {
+	return node.Pos
+}
@@ -0,0 +1,14 @@
+package ast
+
+// Bench is a named benchmark. It is run many times to measure how long the
+// statements take.
+type Bench struct {
+	Name       string
+	Statements []Node
+	Pos        string
+}
+
+// Position returns the position.
+func (node *Bench) Position() string {
+	return node.Pos
+}
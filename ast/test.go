@@ -2,7 +2,27 @@ package ast
 
 // Test is a named test.
 type Test struct {
-	Name       string
+	Name string
+
+	// Cases is optional. When it is provided the statements are run once for
+	// each element of the array or map, like:
+	//
+	//	test "add" for c in [[1, 2, 3], [2, 2, 4]] {
+	//	    assert(add(c[0], c[1]) == c[2])
+	//	}
+	//
+	// Each case is reported as its own test, named after the index or key.
+	Cases *In
+
+	// Arguments is only used by a fuzz test, like:
+	//
+	//	fuzz "split" (s, delimiter string) {
+	//	    assert(len(strings.Split(s, delimiter)) > 0)
+	//	}
+	//
+	// The statements are run many times with random values for the arguments.
+	Arguments []*Argument
+
 	Statements []Node
 	Pos        string
 }
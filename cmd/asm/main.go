@@ -65,5 +65,10 @@ func (*Command) Run(args []string) {
 			// "-22" is chosen here because is is the longer instruction name.
 			fmt.Printf("  %3d %-22s # %s\n", i+1, ty, ins)
 		}
+
+		// The handlers for each try block are not instructions.
+		for _, scope := range pkg.Funcs[fn.Name].ErrorScopes {
+			fmt.Printf("  %s\n", scope)
+		}
 	}
 }
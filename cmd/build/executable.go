@@ -0,0 +1,123 @@
+package build
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elliotchance/ok/vm"
+)
+
+// trailer is written after the program. It allows the program to be found at
+// the end of the executable:
+//
+//	[ok executable][program][length of program (8 bytes)][trailer]
+const trailer = "\x00ok-program"
+
+// RunEmbedded runs the program appended to the current executable by
+// "ok build". It returns false, without doing anything, if there is no program.
+func RunEmbedded() bool {
+	executable, err := os.Executable()
+	if err != nil {
+		return false
+	}
+
+	program, err := readExecutable(executable)
+	check(err)
+
+	if program == nil {
+		return false
+	}
+
+	m := vm.NewVM(program.Funcs, nil, program.Interfaces, program.Package)
+	m.Args = os.Args[1:]
+	check(m.Run())
+
+	return true
+}
+
+// readExecutable returns the program at the end of the executable. It will
+// return nil, with no error, if there is no program.
+func readExecutable(executable string) (*vm.Program, error) {
+	f, err := os.Open(executable)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	footerLen := int64(8 + len(trailer))
+	if info.Size() < footerLen {
+		return nil, nil
+	}
+
+	footer := make([]byte, footerLen)
+	if _, err := f.ReadAt(footer, info.Size()-footerLen); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(footer[8:], []byte(trailer)) {
+		return nil, nil
+	}
+
+	programLen := int64(binary.LittleEndian.Uint64(footer))
+	if programLen > info.Size()-footerLen {
+		return nil, errors.New("corrupt program in executable")
+	}
+
+	return vm.DecodeProgram(io.NewSectionReader(f,
+		info.Size()-footerLen-programLen, programLen))
+}
+
+// writeExecutable creates an executable from a copy of the runtime with the
+// program appended.
+func writeExecutable(runtime, output string, program *vm.Program) error {
+	existing, err := readExecutable(runtime)
+	if err != nil {
+		return err
+	}
+
+	// Otherwise the new executable would run the existing program.
+	if existing != nil {
+		return fmt.Errorf("%s already contains a program", runtime)
+	}
+
+	var encoded bytes.Buffer
+	if err := program.Encode(&encoded); err != nil {
+		return err
+	}
+
+	in, err := os.Open(runtime)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	footer := make([]byte, 8, 8+len(trailer))
+	binary.LittleEndian.PutUint64(footer, uint64(encoded.Len()))
+	footer = append(footer, trailer...)
+
+	if _, err := out.Write(append(encoded.Bytes(), footer...)); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -1,11 +1,12 @@
 package build
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
-	"path"
+	"path/filepath"
+	"runtime"
 
 	"github.com/elliotchance/ok/compiler"
 	"github.com/elliotchance/ok/util"
@@ -13,6 +14,17 @@ import (
 )
 
 func check(err error) {
+	// Errors from the program are shown with where they happened.
+	if err, ok := err.(*vm.RuntimeError); ok {
+		fmt.Fprint(os.Stderr, err.StackTrace())
+		os.Exit(1)
+	}
+
+	// The program asked to stop with os.Exit.
+	if err, ok := err.(*vm.ExitError); ok {
+		os.Exit(err.Code)
+	}
+
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -25,50 +37,83 @@ func (*Command) Description() string {
 	return "compile a program"
 }
 
-// Run is the entry point for the "ok run" command.
+// Run is the entry point for the "ok build" command.
 func (*Command) Run(args []string) {
+	var output string
+	var jsonErrors bool
+
+	flagSet := flag.NewFlagSet("build", flag.ExitOnError)
+	flagSet.StringVar(&output, "o", "",
+		"Output file. The default is the name of the package directory.")
+	flagSet.BoolVar(&jsonErrors, "json", false,
+		"Print compile errors as JSON, one object per line.")
+	check(flagSet.Parse(args))
+
+	args = flagSet.Args()
 	if len(args) == 0 {
 		args = []string{"."}
 	}
 
-	for _, arg := range args {
-		runArg(arg)
-	}
-}
+	// Packages that are only matched by a pattern, such as "./...", are
+	// skipped when they do not have a main function. A package that is named
+	// directly must have one.
+	named := util.NamedPackages(args)
+	args, err := util.ExpandPackages(args)
+	check(err)
 
-func runArg(arg string) {
-	pkg, errs := compiler.CompilePackage(arg, false)
+	packages, errs := compiler.CompilePackages(args, false)
+	if jsonErrors {
+		util.CheckErrorsWithExitJSON(errs)
+	}
 	util.CheckErrorsWithExit(errs)
 
-	goFile := path.Join(arg, "main.go")
-	f, err := os.Create(goFile)
-	check(err)
+	var mainArgs []string
+	var mainPackages []*compiler.Compiled
+	for i, arg := range args {
+		if packages[i].Funcs["main"] != nil {
+			mainArgs = append(mainArgs, arg)
+			mainPackages = append(mainPackages, packages[i])
+		} else if named[filepath.Clean(arg)] {
+			log.Fatalf("no main function in package %s",
+				util.PackageNameFromPath("", arg))
+		}
+	}
+
+	if output != "" && len(mainArgs) > 1 {
+		log.Fatalln("-o cannot be used with more than one package")
+	}
+
+	for i, arg := range mainArgs {
+		runArg(arg, mainPackages[i], output)
+	}
+}
 
-	defer os.Remove(goFile)
-
-	fmt.Fprintf(f, "package main\n\n")
-	fmt.Fprintf(f, "import \"github.com/elliotchance/ok/ast\"\n")
-	fmt.Fprintf(f, "import \"github.com/elliotchance/ok/vm\"\n\n")
-	fmt.Fprintf(f, "func main() {\n")
-	fmt.Fprintf(f, "\tfuncs := ")
-	vm.Render(f, pkg.Funcs, "\t", false)
-	fmt.Fprintf(f, "\n")
-	fmt.Fprintf(f, "\tm := vm.NewVM(funcs, nil, \"\")\n")
-	fmt.Fprintf(f, "\tif err := m.Run(); err != nil {\n")
-	fmt.Fprintf(f, "\t\tpanic(err)\n")
-	fmt.Fprintf(f, "\t}\n")
-	fmt.Fprintf(f, "}\n")
-	fmt.Fprintf(f, "\n")
-
-	goExecutable, err := exec.LookPath("go")
+func runArg(arg string, pkg *compiler.Compiled, output string) {
+	dir, err := filepath.Abs(arg)
 	check(err)
 
-	goBuild := &exec.Cmd{
-		Path:   goExecutable,
-		Args:   []string{goExecutable, "build", "./" + arg},
-		Stdout: os.Stdout,
-		Stderr: os.Stdout,
+	if output == "" {
+		output = filepath.Base(dir)
+		if runtime.GOOS == "windows" {
+			output += ".exe"
+		}
+	}
+
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		log.Fatalf("build output %s already exists and is a directory", output)
 	}
 
-	check(goBuild.Run())
+	// The running ok executable is used as the runtime. It will run the
+	// program instead of the commands when it finds the program at the end
+	// of itself. See RunEmbedded.
+	executable, err := os.Executable()
+	check(err)
+
+	check(writeExecutable(executable, output, &vm.Program{
+		Package:    util.PackageNameFromPath("", arg),
+		Funcs:      pkg.Funcs,
+		FuncDefs:   pkg.FuncDefs,
+		Interfaces: pkg.Interfaces,
+		Constants:  pkg.Constants,
+	}))
 }
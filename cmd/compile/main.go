@@ -0,0 +1,67 @@
+package compile
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/util"
+	"github.com/elliotchance/ok/vm"
+)
+
+type Command struct{}
+
+func check(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Description is shown in "ok -help".
+func (*Command) Description() string {
+	return "compile a package to bytecode"
+}
+
+// Run is the entry point for the "ok compile" command.
+func (*Command) Run(args []string) {
+	var output string
+
+	flagSet := flag.NewFlagSet("compile", flag.ExitOnError)
+	flagSet.StringVar(&output, "o", "",
+		"Output file. The default is the name of the package directory with"+
+			" the .okc extension.")
+	check(flagSet.Parse(args))
+
+	arg := "."
+	if flagSet.NArg() > 1 {
+		log.Fatalln("only one package can be compiled at a time")
+	}
+	if flagSet.NArg() == 1 {
+		arg = flagSet.Arg(0)
+	}
+
+	pkg, errs := compiler.CompilePackage(arg, false)
+	util.CheckErrorsWithExit(errs)
+
+	if output == "" {
+		dir, err := filepath.Abs(arg)
+		check(err)
+
+		output = filepath.Base(dir) + ".okc"
+	}
+
+	f, err := os.Create(output)
+	check(err)
+
+	err = (&vm.Program{
+		Package:    util.PackageNameFromPath("", arg),
+		Funcs:      pkg.Funcs,
+		FuncDefs:   pkg.FuncDefs,
+		Interfaces: pkg.Interfaces,
+		Constants:  pkg.Constants,
+	}).Encode(f)
+	check(err)
+	check(f.Close())
+}
@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/debugger"
+	"github.com/elliotchance/ok/util"
+	"github.com/elliotchance/ok/vm"
+)
+
+type Command struct{}
+
+func check(err error) {
+	// Errors from the program are shown with where they happened.
+	if err, ok := err.(*vm.RuntimeError); ok {
+		fmt.Fprint(os.Stderr, err.StackTrace())
+		os.Exit(1)
+	}
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// breakpoints allows "-b" to be provided more than once.
+type breakpoints []string
+
+func (b *breakpoints) String() string {
+	return strings.Join(*b, ",")
+}
+
+func (b *breakpoints) Set(value string) error {
+	*b = append(*b, value)
+
+	return nil
+}
+
+// Description is shown in "ok -help".
+func (*Command) Description() string {
+	return "debug ok program"
+}
+
+// Run is the entry point for the "ok debug" command.
+func (*Command) Run(args []string) {
+	var dap bool
+	var locations breakpoints
+
+	flagSet := flag.NewFlagSet("debug", flag.ExitOnError)
+	flagSet.BoolVar(&dap, "dap", false,
+		"Serve the Debug Adapter Protocol on stdin and stdout.")
+	flagSet.Var(&locations, "b",
+		"Set a breakpoint at file:line or a function name. May be repeated.")
+	check(flagSet.Parse(args))
+
+	if dap {
+		check(debugger.NewDAPServer(os.Stdin, os.Stdout).Serve())
+		return
+	}
+
+	dir := "."
+	if flagSet.NArg() > 0 {
+		dir = flagSet.Arg(0)
+	}
+
+	packageName := util.PackageNameFromPath("", dir)
+	pkg, errs := compiler.CompilePackage(dir, false)
+	util.CheckErrorsWithExit(errs)
+
+	d := debugger.New(debugger.NewCLI(os.Stdin, os.Stdout))
+	d.StopOnEntry = len(locations) == 0
+	for _, location := range locations {
+		check(d.SetBreakpoint(location))
+	}
+
+	m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, packageName)
+	d.Attach(m)
+	check(m.Run())
+}
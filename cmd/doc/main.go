@@ -24,12 +24,15 @@ func (*Command) Description() string {
 	return "view documentation for a package"
 }
 
-// Run is the entry point for the "ok test" command.
+// Run is the entry point for the "ok doc" command.
 func (*Command) Run(args []string) {
 	if len(args) == 0 {
 		args = []string{"."}
 	}
 
+	args, err := util.ExpandPackages(args)
+	check(err)
+
 	for _, arg := range args {
 		packageName := util.PackageNameFromPath("", arg)
 
@@ -0,0 +1,111 @@
+package fmt
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+
+	"github.com/elliotchance/ok/format"
+	"github.com/elliotchance/ok/util"
+)
+
+type Command struct{}
+
+type options struct {
+	write, list, diff bool
+}
+
+func check(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Description is shown in "ok -help".
+func (*Command) Description() string {
+	return "format source code"
+}
+
+// Run is the entry point for the "ok fmt" command.
+func (*Command) Run(args []string) {
+	var opts options
+	flagSet := flag.NewFlagSet("fmt", flag.ExitOnError)
+	flagSet.BoolVar(&opts.write, "w", false,
+		"write result to (source) file instead of stdout")
+	flagSet.BoolVar(&opts.list, "l", false,
+		"list files whose formatting differs, exit 1 if there are any")
+	flagSet.BoolVar(&opts.diff, "d", false,
+		"display diffs instead of rewriting files, exit 1 if there are any")
+	check(flagSet.Parse(args))
+
+	args = flagSet.Args()
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	args, err := util.ExpandPackages(args)
+	check(err)
+
+	unformatted := false
+	for _, arg := range args {
+		fileNames := []string{arg}
+		if isDir(arg) {
+			var err error
+			fileNames, err = util.GetAllOKFilesInPath(arg, true)
+			check(err)
+		}
+
+		for _, fileName := range fileNames {
+			if !formatFile(fileName, opts) {
+				unformatted = true
+			}
+		}
+	}
+
+	if unformatted && (opts.list || opts.diff) {
+		os.Exit(1)
+	}
+}
+
+// formatFile returns false if the file was not already formatted.
+func formatFile(fileName string, opts options) bool {
+	data, err := ioutil.ReadFile(fileName)
+	check(err)
+
+	src := string(data)
+	formatted, errs := format.Source(src, fileName)
+	util.CheckErrorsWithExit(errs)
+
+	if opts.list && formatted != src {
+		fmt.Println(fileName)
+	}
+
+	if opts.diff {
+		fmt.Print(util.Diff(path.Join("a", fileName), path.Join("b", fileName),
+			src, formatted))
+	}
+
+	if opts.write && formatted != src {
+		info, err := os.Stat(fileName)
+		check(err)
+
+		err = ioutil.WriteFile(fileName, []byte(formatted), info.Mode())
+		check(err)
+	}
+
+	if !opts.list && !opts.diff && !opts.write {
+		fmt.Print(formatted)
+	}
+
+	return formatted == src
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	check(err)
+
+	return info.IsDir()
+}
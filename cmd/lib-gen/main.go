@@ -1,11 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/compiler"
@@ -27,9 +29,14 @@ func main() {
 
 	packageNames := map[string]bool{}
 
-	funcs := map[string]*vm.InternalDefinition{}
-	constants := map[string]*ast.Literal{}
-	interfaces := map[string]map[string]string{}
+	program := &vm.Program{
+		Package:    "lib",
+		Funcs:      map[string]*vm.CompiledFunc{},
+		FuncDefs:   map[string]*ast.Func{},
+		Interfaces: map[string]map[string]string{},
+		Constants:  map[string]*ast.Literal{},
+	}
+
 	for _, pathInfo := range packages {
 		if !pathInfo.IsDir() {
 			continue
@@ -42,27 +49,26 @@ func main() {
 			packageNames[pkgName] = true
 		}
 
-		pkg, errs := compiler.CompilePackage("lib/"+pkgName, false)
+		// Other than lang, the functions and types are compiled with the
+		// package name so that they can call each other once they are loaded
+		// into the VM.
+		var pkg *compiler.Compiled
+		var errs []error
+		if pkgName == "lang" {
+			pkg, errs = compiler.CompilePackage("lib/"+pkgName, false)
+		} else {
+			pkg, errs = compiler.CompileLibPackage("lib/" + pkgName)
+		}
 		util.CheckErrorsWithExit(errs)
 
+		// Private functions (including function literals) are needed to run
+		// the public functions, but they cannot be called from outside of the
+		// package.
 		for name, fn := range pkg.Funcs {
-			if !util.IsPublic(name) {
-				continue
-			}
-
-			funcDef := pkg.FuncDefs[name]
-
-			// We don't need to serialize this.
-			funcDef.Statements = nil
+			program.Funcs[name] = fn
 
-			key := pkgName + "." + name
-			if pkgName == "lang" {
-				key = name
-			}
-
-			funcs[key] = &vm.InternalDefinition{
-				CompiledFunc: fn,
-				FuncDef:      funcDef,
+			if isPublic(name) {
+				program.FuncDefs[name] = pkg.FuncDefs[name]
 			}
 		}
 
@@ -76,40 +82,52 @@ func main() {
 				key = name
 			}
 
-			constants[key] = c
+			program.Constants[key] = c
 		}
 
 		for name, c := range pkg.Interfaces {
-			if !util.IsPublic(name) {
-				continue
+			if isPublic(name) {
+				program.Interfaces[name] = c
 			}
-
-			key := pkgName + "." + name
-			if pkgName == "lang" {
-				key = name
-			}
-
-			interfaces[key] = c
 		}
 	}
 
+	var bytecode bytes.Buffer
+	check(program.Encode(&bytecode))
+
 	f, err := os.Create("vm/lib.go")
 	check(err)
 
 	fmt.Fprintf(f, "package vm\n\n")
-	fmt.Fprintf(f, "import \"github.com/elliotchance/ok/ast\"\n\n")
 	fmt.Fprintf(f, "func init() {\n")
 	fmt.Fprintf(f, "\tPackages = ")
 	vm.Render(f, packageNames, "\t", true)
 	fmt.Fprintf(f, "\n")
-	fmt.Fprintf(f, "\tLib = ")
-	vm.Render(f, funcs, "\t", true)
-	fmt.Fprintf(f, "\n")
-	fmt.Fprintf(f, "\tConstants = ")
-	vm.Render(f, constants, "\t", true)
-	fmt.Fprintf(f, "\n")
-	fmt.Fprintf(f, "\tInterfaces = ")
-	vm.Render(f, interfaces, "\t", true)
-	fmt.Fprintf(f, "\n}\n")
+	fmt.Fprintf(f, "\tloadLib(lib)\n")
+	fmt.Fprintf(f, "}\n\n")
+
+	// The bytecode is split over many lines so that changes are easier to
+	// review.
+	fmt.Fprintf(f, "// lib is the bytecode for all of the packages in lib/.\n")
+	fmt.Fprintf(f, "const lib = \"\" +")
+	for data := bytecode.Bytes(); len(data) > 0; {
+		n := 48
+		if n > len(data) {
+			n = len(data)
+		}
+
+		fmt.Fprintf(f, "\n\t%q", data[:n])
+		if n < len(data) {
+			fmt.Fprintf(f, " +")
+		}
+		data = data[n:]
+	}
 	fmt.Fprintf(f, "\n")
 }
+
+// isPublic returns true if the name, ignoring the package name, is public.
+func isPublic(name string) bool {
+	parts := strings.Split(name, ".")
+
+	return util.IsPublic(parts[len(parts)-1])
+}
@@ -0,0 +1,26 @@
+package lsp
+
+import (
+	"log"
+	"os"
+
+	"github.com/elliotchance/ok/lsp"
+)
+
+type Command struct{}
+
+func check(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Description is shown in "ok -help".
+func (*Command) Description() string {
+	return "start the language server (communicates over stdio)"
+}
+
+// Run is the entry point for the "ok lsp" command.
+func (*Command) Run(args []string) {
+	check(lsp.NewServer(os.Stdin, os.Stdout).Serve())
+}
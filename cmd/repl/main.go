@@ -0,0 +1,26 @@
+package repl
+
+import (
+	"log"
+	"os"
+
+	"github.com/elliotchance/ok/repl"
+)
+
+type Command struct{}
+
+func check(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Description is shown in "ok -help".
+func (*Command) Description() string {
+	return "start an interactive session"
+}
+
+// Run is the entry point for the "ok repl" command.
+func (*Command) Run(args []string) {
+	check(repl.New(os.Stdout).Run(os.Stdin))
+}
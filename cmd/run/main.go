@@ -1,7 +1,12 @@
 package run
 
 import (
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/elliotchance/ok/compiler"
 	"github.com/elliotchance/ok/util"
@@ -11,6 +16,17 @@ import (
 type Command struct{}
 
 func check(err error) {
+	// Errors from the program are shown with where they happened.
+	if err, ok := err.(*vm.RuntimeError); ok {
+		fmt.Fprint(os.Stderr, err.StackTrace())
+		os.Exit(1)
+	}
+
+	// The program asked to stop with os.Exit.
+	if err, ok := err.(*vm.ExitError); ok {
+		os.Exit(err.Code)
+	}
+
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -23,18 +39,80 @@ func (*Command) Description() string {
 
 // Run is the entry point for the "ok run" command.
 func (*Command) Run(args []string) {
+	var jsonErrors bool
+
+	// Everything after "--" is passed to the program rather than being treated
+	// as packages to run.
+	var programArgs []string
+	for i, arg := range args {
+		if arg == "--" {
+			programArgs = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+
+	flagSet := flag.NewFlagSet("run", flag.ExitOnError)
+	flagSet.BoolVar(&jsonErrors, "json", false,
+		"Print compile errors as JSON, one object per line.")
+	check(flagSet.Parse(args))
+
+	args = flagSet.Args()
 	if len(args) == 0 {
 		args = []string{"."}
 	}
 
+	// Packages that are only matched by a pattern, such as "./...", are
+	// skipped when they do not have a main function.
+	named := util.NamedPackages(args)
+	args, err := util.ExpandPackages(args)
+	check(err)
+
+	// Bytecode from "ok compile" does not need to be compiled. Everything
+	// else is compiled before anything is run.
+	var dirs []string
 	for _, arg := range args {
+		if !strings.HasSuffix(arg, ".okc") {
+			dirs = append(dirs, arg)
+		}
+	}
+
+	packages, errs := compiler.CompilePackages(dirs, false)
+	if jsonErrors {
+		util.CheckErrorsWithExitJSON(errs)
+	}
+	util.CheckErrorsWithExit(errs)
+
+	for _, arg := range args {
+		if strings.HasSuffix(arg, ".okc") {
+			runBytecode(arg, programArgs)
+			continue
+		}
+
 		packageName := util.PackageNameFromPath("", arg)
+		pkg := packages[0]
+		packages = packages[1:]
 
-		pkg, errs := compiler.CompilePackage(arg, false)
-		util.CheckErrorsWithExit(errs)
+		if pkg.Funcs["main"] == nil && !named[filepath.Clean(arg)] {
+			continue
+		}
 
 		m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, packageName)
+		m.Args = programArgs
 		err := m.Run()
 		check(err)
 	}
 }
+
+func runBytecode(fileName string, programArgs []string) {
+	f, err := os.Open(fileName)
+	check(err)
+	defer f.Close()
+
+	program, err := vm.DecodeProgram(f)
+	check(err)
+
+	m := vm.NewVM(program.Funcs, nil, program.Interfaces, program.Package)
+	m.Args = programArgs
+	check(m.Run())
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/elliotchance/ok/vm"
+)
+
+// event is a line of output for "ok test -json". It uses the same format as
+// "go test -json" so that existing tools can read it.
+type event struct {
+	Time    time.Time
+	Action  string
+	Package string  `json:",omitempty"`
+	Test    string  `json:",omitempty"`
+	Elapsed float64 `json:",omitempty"`
+	Output  string  `json:",omitempty"`
+}
+
+type eventEncoder struct {
+	encoder *json.Encoder
+}
+
+func newEventEncoder(w io.Writer) *eventEncoder {
+	return &eventEncoder{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (e *eventEncoder) encode(ev *event) {
+	ev.Time = time.Now()
+
+	// Encoding a struct of strings and numbers cannot fail.
+	_ = e.encoder.Encode(ev)
+}
+
+// results sends the events for each test that was run in a package.
+func (e *eventEncoder) results(packageName string, results []*vm.TestResult) {
+	for _, result := range results {
+		e.encode(&event{
+			Action:  "run",
+			Package: packageName,
+			Test:    result.Name,
+		})
+
+		if result.Output != "" {
+			e.encode(&event{
+				Action:  "output",
+				Package: packageName,
+				Test:    result.Name,
+				Output:  result.Output,
+			})
+		}
+
+		for _, failure := range result.Failures {
+			e.encode(&event{
+				Action:  "output",
+				Package: packageName,
+				Test:    result.Name,
+				Output:  failure.String() + "\n",
+			})
+		}
+
+		e.encode(&event{
+			Action:  action(result.Passed()),
+			Package: packageName,
+			Test:    result.Name,
+			Elapsed: result.Elapsed.Seconds(),
+		})
+	}
+}
+
+func action(passed bool) string {
+	if passed {
+		return "pass"
+	}
+
+	return "fail"
+}
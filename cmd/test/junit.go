@@ -0,0 +1,94 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elliotchance/ok/vm"
+)
+
+// The JUnit XML format is not formally specified. These contain the elements
+// and attributes that are understood by most CI systems.
+
+type junitTestSuites struct {
+	XMLName xml.Name          `xml:"testsuites"`
+	Suites  []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Time      string           `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func newJUnitTestSuite(packageName string, results []*vm.TestResult, elapsed time.Duration) *junitTestSuite {
+	suite := &junitTestSuite{
+		Name:  packageName,
+		Tests: len(results),
+		Time:  formatSeconds(elapsed),
+	}
+
+	for _, result := range results {
+		testCase := &junitTestCase{
+			Name:      result.Name,
+			ClassName: packageName,
+			Time:      formatSeconds(result.Elapsed),
+		}
+
+		if !result.Passed() {
+			suite.Failures++
+
+			var lines []string
+			for _, failure := range result.Failures {
+				lines = append(lines, failure.String())
+			}
+
+			testCase.Failure = &junitFailure{
+				Message: result.Failures[0].Message,
+				Text:    strings.Join(lines, "\n"),
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	return suite
+}
+
+func writeJUnit(w io.Writer, suites []*junitTestSuite) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	err := encoder.Encode(&junitTestSuites{Suites: suites})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
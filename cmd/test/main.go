@@ -1,12 +1,18 @@
 package test
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/elliotchance/ok/compiler"
+	okcover "github.com/elliotchance/ok/cover"
 	"github.com/elliotchance/ok/util"
 	"github.com/elliotchance/ok/vm"
 )
@@ -14,51 +20,334 @@ import (
 type Command struct{}
 
 func check(err error) {
+	// Errors from the program are shown with where they happened.
+	if err, ok := err.(*vm.RuntimeError); ok {
+		fmt.Fprint(os.Stderr, err.StackTrace())
+		os.Exit(1)
+	}
+
+	// The program asked to stop with os.Exit.
+	if err, ok := err.(*vm.ExitError); ok {
+		os.Exit(err.Code)
+	}
+
 	if err != nil {
 		log.Fatalln(err)
 	}
 }
 
+// errorMessage includes the stack trace of a runtime error.
+func errorMessage(err error) string {
+	if err, ok := err.(*vm.RuntimeError); ok {
+		return strings.TrimSpace(err.StackTrace())
+	}
+
+	return err.Error()
+}
+
 // Description is shown in "ok -help".
 func (*Command) Description() string {
 	return "run tests"
 }
 
+// testPackage is a compiled package waiting for its tests to be run.
+type testPackage struct {
+	dir, name string
+	compiled  *compiler.Compiled
+	vm        *vm.VM
+	elapsed   time.Duration
+	err       error
+}
+
 // Run is the entry point for the "ok test" command.
 func (*Command) Run(args []string) {
+	var jsonOutput, cover, verbose bool
+	var coverProfile, run, junit, bench string
+	var parallel, fuzzCount int
+	var benchTime time.Duration
+	var fuzzSeed int64
+
+	flagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	flagSet.BoolVar(&jsonOutput, "json", false,
+		"Print compile errors and test results as JSON, one object per line.")
+	flagSet.BoolVar(&cover, "cover", false,
+		"Show the percentage of statements run by the tests.")
+	flagSet.StringVar(&coverProfile, "coverprofile", "",
+		"Write a coverage profile to the file. Implies -cover.")
+	flagSet.StringVar(&run, "run", "",
+		"Only run the tests with a name that matches the glob, like \"add*\".")
+	flagSet.BoolVar(&verbose, "v", false,
+		"Show the result and time of each test.")
+	flagSet.StringVar(&junit, "junit", "",
+		"Write the test results to the file as JUnit XML.")
+	flagSet.IntVar(&parallel, "p", 1,
+		"The number of packages, and tests within each package, to run at the same time.")
+	flagSet.IntVar(&fuzzCount, "fuzzcount", vm.DefaultFuzzCount,
+		"The number of random inputs for each fuzz test.")
+	flagSet.Int64Var(&fuzzSeed, "fuzzseed", 0,
+		"The seed for the random inputs of fuzz tests. The default is to use the current time.")
+	flagSet.StringVar(&bench, "bench", "",
+		"Run the benchmarks with a name that matches the glob, like \"split*\". Use \"*\" for all.")
+	flagSet.DurationVar(&benchTime, "benchtime", time.Second,
+		"How long to run each benchmark for.")
+	check(flagSet.Parse(args))
+
+	if coverProfile != "" {
+		cover = true
+	}
+
+	args = flagSet.Args()
 	if len(args) == 0 {
 		args = []string{"."}
 	}
 
-	for _, arg := range args {
-		packageName := util.PackageNameFromPath("", arg)
+	args, err := util.ExpandPackages(args)
+	check(err)
 
-		// Use the relative arg here. This will be used to produce error
-		// messages during the compilation.
-		pkg, errs := compiler.CompilePackage(arg, true)
-		util.CheckErrorsWithExit(errs)
+	// Use the relative args here. They will be used to produce error messages
+	// during the compilation.
+	compiled, errs := compiler.CompilePackages(args, true)
+	if jsonOutput {
+		util.CheckErrorsWithExitJSON(errs)
+	}
+	util.CheckErrorsWithExit(errs)
+
+	var packages []*testPackage
+	for i, arg := range args {
+		packageName := util.PackageNameFromPath("", arg)
+		pkg := compiled[i]
 
 		m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, packageName)
-		startTime := time.Now()
-		err := m.RunTests()
-		elapsed := time.Since(startTime).Milliseconds()
-		check(err)
+		m.TestFilter = run
+		m.Parallel = parallel
+		m.BenchFilter = bench
+		m.FuzzCount = fuzzCount
+		m.FuzzSeed = fuzzSeed
+		m.FuzzCorpus = filepath.Join(arg, "testdata", "fuzz")
+		m.BenchTime = benchTime
+		if cover {
+			m.Coverage = map[string]bool{}
+		}
+
+		packages = append(packages, &testPackage{
+			dir:      arg,
+			name:     packageName,
+			compiled: pkg,
+			vm:       m,
+		})
+	}
+
+	runPackages(packages, parallel)
+
+	// The results are shown in the same order as the packages were provided,
+	// regardless of when they finished.
+	events := newEventEncoder(os.Stdout)
+	var profiles []*okcover.Profile
+	var suites []*junitTestSuite
+	failed := false
+	for _, p := range packages {
+		m := p.vm
+		if jsonOutput {
+			events.results(p.name, m.TestResults)
+		} else {
+			printResults(p.name, m.TestResults, verbose)
+		}
+
+		// The tests of this package could not be run. The other packages are
+		// still reported.
+		if p.err != nil {
+			failed = true
+			message := fmt.Sprintf("%s: %s\n", p.name, errorMessage(p.err))
+			if jsonOutput {
+				events.encode(&event{
+					Action:  "output",
+					Package: p.name,
+					Output:  message,
+				})
+				events.encode(&event{
+					Action:  "fail",
+					Package: p.name,
+					Elapsed: p.elapsed.Seconds(),
+				})
+			} else {
+				fmt.Print(message)
+			}
+
+			continue
+		}
+
+		// Benchmarks are only run once all of the tests in the package have
+		// passed. They are run after all of the packages so that nothing else
+		// is running at the same time.
+		if bench != "" && m.TestsFailed == 0 {
+			check(m.RunBenchmarks(p.compiled.Benches))
+			for _, line := range benchLines(m.BenchResults) {
+				if jsonOutput {
+					events.encode(&event{
+						Action:  "output",
+						Package: p.name,
+						Output:  line,
+					})
+				} else {
+					fmt.Print(line)
+				}
+			}
+		}
+
+		if junit != "" {
+			suites = append(suites,
+				newJUnitTestSuite(p.name, m.TestResults, p.elapsed))
+		}
+
+		coverage := ""
+		if cover {
+			profile := newProfile(p.dir, p.compiled.Funcs, m.Coverage)
+			profiles = append(profiles, profile)
+			coverage = " coverage: [no statements]"
+			if len(profile.Blocks) > 0 {
+				coverage = fmt.Sprintf(" coverage: %.1f%% of statements",
+					profile.Percent())
+			}
+		}
 
 		assertWord := pluralise("assert", m.TotalAssertions)
+		elapsed := p.elapsed.Milliseconds()
+		summary := fmt.Sprintf("%s: %d passed %d %s (%d ms)%s\n",
+			p.name, m.TestsPass,
+			m.TotalAssertions, assertWord, elapsed, coverage)
 		if m.TestsFailed > 0 {
-			fmt.Printf("%s: %d failed %d passed %d %s (%d ms)\n",
-				packageName, m.TestsFailed, m.TestsPass,
-				m.TotalAssertions, assertWord, elapsed)
+			summary = fmt.Sprintf("%s: %d failed %d passed %d %s (%d ms)%s\n",
+				p.name, m.TestsFailed, m.TestsPass,
+				m.TotalAssertions, assertWord, elapsed, coverage)
+			failed = true
+		}
+
+		if jsonOutput {
+			events.encode(&event{
+				Action:  "output",
+				Package: p.name,
+				Output:  summary,
+			})
+			events.encode(&event{
+				Action:  action(m.TestsFailed == 0),
+				Package: p.name,
+				Elapsed: p.elapsed.Seconds(),
+			})
 		} else {
-			fmt.Printf("%s: %d passed %d %s (%d ms)\n",
-				packageName, m.TestsPass,
-				m.TotalAssertions, assertWord, elapsed)
+			fmt.Print(summary)
 		}
+	}
 
-		if m.TestsFailed > 0 {
-			os.Exit(1)
+	if coverProfile != "" {
+		f, err := os.Create(coverProfile)
+		check(err)
+		check(okcover.WriteProfiles(f, profiles))
+		check(f.Close())
+	}
+
+	if junit != "" {
+		f, err := os.Create(junit)
+		check(err)
+		check(writeJUnit(f, suites))
+		check(f.Close())
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// runPackages runs the tests for up to parallel packages at the same time.
+func runPackages(packages []*testPackage, parallel int) {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	running := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for _, p := range packages {
+		wg.Add(1)
+		running <- struct{}{}
+		go func(p *testPackage) {
+			defer wg.Done()
+
+			startTime := time.Now()
+			p.err = p.vm.RunTests()
+			p.elapsed = time.Since(startTime)
+			<-running
+		}(p)
+	}
+	wg.Wait()
+}
+
+// printResults shows the output and failed asserts for each test. When verbose
+// is true every test is shown with its time, even if it passed.
+func printResults(packageName string, results []*vm.TestResult, verbose bool) {
+	for _, result := range results {
+		fmt.Print(result.Output)
+
+		if verbose {
+			status := "PASS"
+			if !result.Passed() {
+				status = "FAIL"
+			}
+
+			fmt.Printf("--- %s: %s (%d ms)\n", status, result.Name,
+				result.Elapsed.Milliseconds())
+
+			for _, failure := range result.Failures {
+				fmt.Println(indent(failure.String()))
+			}
+
+			continue
+		}
+
+		for _, failure := range result.Failures {
+			fmt.Printf("%s: %s: %s: %s\n", packageName, failure.Pos,
+				result.Name, failure.Message)
+			if failure.Details != "" {
+				fmt.Println(indent(failure.Details))
+			}
+		}
+	}
+}
+
+// benchLines formats the result of each benchmark with the columns aligned.
+func benchLines(results []*vm.BenchResult) []string {
+	nameWidth := 0
+	for _, result := range results {
+		if len(result.Name) > nameWidth {
+			nameWidth = len(result.Name)
 		}
 	}
+
+	var lines []string
+	for _, result := range results {
+		lines = append(lines, fmt.Sprintf("%-*s %10d %12d ns/op %10d instructions/op\n",
+			nameWidth, result.Name, result.N, result.NsPerOp(),
+			result.InstructionsPerOp()))
+	}
+
+	return lines
+}
+
+// newProfile returns the coverage of the source files (not including the
+// tests) in the package dir.
+func newProfile(dir string, funcs map[string]*vm.CompiledFunc, covered map[string]bool) *okcover.Profile {
+	// The file names must be the same as the ones used by the compiler so
+	// that the positions match.
+	fileNames, err := util.GetAllOKFilesInPath(path.Clean(dir), false)
+	check(err)
+
+	profile, err := okcover.NewProfile(funcs, fileNames, covered)
+	check(err)
+
+	return profile
+}
+
+// indent adds four spaces to the start of each line.
+func indent(s string) string {
+	return "    " + strings.Replace(s, "\n", "\n    ", -1)
 }
 
 func pluralise(word string, n int) string {
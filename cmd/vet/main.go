@@ -0,0 +1,62 @@
+package vet
+
+import (
+	"flag"
+	"log"
+
+	"github.com/elliotchance/ok/util"
+	"github.com/elliotchance/ok/vet"
+)
+
+type Command struct{}
+
+func check(err error) {
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Description is shown in "ok -help".
+func (*Command) Description() string {
+	return "report likely mistakes in packages"
+}
+
+// Run is the entry point for the "ok vet" command.
+func (*Command) Run(args []string) {
+	var jsonErrors bool
+
+	flagSet := flag.NewFlagSet("vet", flag.ExitOnError)
+	flagSet.BoolVar(&jsonErrors, "json", false,
+		"Print problems as JSON, one object per line.")
+
+	enabled := map[*vet.Check]*bool{}
+	for _, c := range vet.Checks {
+		enabled[c] = flagSet.Bool(c.Name, true, c.Description)
+	}
+	check(flagSet.Parse(args))
+
+	var checks []*vet.Check
+	for _, c := range vet.Checks {
+		if *enabled[c] {
+			checks = append(checks, c)
+		}
+	}
+
+	args = flagSet.Args()
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	args, err := util.ExpandPackages(args)
+	check(err)
+
+	var errs []error
+	for _, arg := range args {
+		errs = append(errs, vet.Package(arg, checks)...)
+	}
+
+	if jsonErrors {
+		util.CheckErrorsWithExitJSON(errs)
+	}
+	util.CheckErrorsWithExit(errs)
+}
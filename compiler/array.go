@@ -14,10 +14,10 @@ func compileArray(
 	file *Compiled,
 ) (vm.Register, string, error) {
 	if len(n.Elements) == 0 && n.Kind == "" {
-		err := fmt.Errorf("%s empty array needs to specify a type",
-			n.Position())
+		err := newDiagnostic(n.Position(), CodeUntypedArray,
+			"empty array needs to specify a type")
 
-		return "", "", err
+		return vm.NoRegister, "", err
 	}
 
 	sizeRegister := compiledFunc.NextRegister()
@@ -30,7 +30,7 @@ func compileArray(
 	arrayAlloc := &vm.ArrayAlloc{
 		Size:   sizeRegister,
 		Result: arrayRegister,
-		Kind:   n.Kind,
+		Kind:   file.qualifiedType(n.Kind),
 	}
 	compiledFunc.Append(arrayAlloc)
 
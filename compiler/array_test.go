@@ -20,7 +20,7 @@ func TestArray(t *testing.T) {
 	}{
 		"unknown-array-empty": {
 			node: &ast.Array{},
-			err:  errors.New(" empty array needs to specify a type"),
+			err:  errors.New("empty array needs to specify a type"),
 		},
 		"number-empty": {
 			node: &ast.Array{
@@ -29,12 +29,12 @@ func TestArray(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 			},
@@ -50,58 +50,58 @@ func TestArray(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 
 				// set 0
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "3",
-					Value: "4",
+					Array: 2,
+					Index: 3,
+					Value: 4,
 				},
 
 				// set 1
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "5",
-					Value: "6",
+					Array: 2,
+					Index: 5,
+					Value: 6,
 				},
 
 				// set 2
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 7,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 8,
 					Value:        asttest.NewLiteralNumber("13"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "7",
-					Value: "8",
+					Array: 2,
+					Index: 7,
+					Value: 8,
 				},
 			},
 		},
@@ -121,34 +121,34 @@ func TestArray(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 
 				// set 0
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "3",
-					Value: "4",
+					Array: 2,
+					Index: 3,
+					Value: 4,
 				},
 
 				// assign a
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "2",
+					VariableName: 5,
+					Register:     2,
 				},
 			},
 		},
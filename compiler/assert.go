@@ -1,9 +1,8 @@
 package compiler
 
 import (
-	"fmt"
-
 	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/format"
 	"github.com/elliotchance/ok/vm"
 )
 
@@ -18,16 +17,118 @@ func compileAssert(
 	}
 
 	if returnKind != "bool" {
-		return fmt.Errorf("assert condition must be a bool but is %s", returnKind)
+		return newDiagnostic(n.Position(), CodeTypeMismatch,
+			"assert condition must be a bool but is %s", returnKind)
+	}
+
+	message, err := compileAssertMessage(compiledFunc, n.Message, file)
+	if err != nil {
+		return err
 	}
 
 	compiledFunc.Append(&vm.Assert{
-		Left:  left,
-		Op:    n.Expr.Op,
-		Right: right,
-		Final: returns,
-		Pos:   n.Position(),
+		Left:    left,
+		Op:      n.Expr.Op,
+		Right:   right,
+		Final:   returns,
+		Pos:     n.Position(),
+		Message: message,
+	})
+
+	return nil
+}
+
+// compileAssertRaise works like a try block around the expression. Every
+// error is handled so that the assert can report what was raised instead.
+func compileAssertRaise(
+	compiledFunc *vm.CompiledFunc,
+	n *ast.AssertRaise,
+	file *Compiled,
+) error {
+	message, err := compileAssertMessage(compiledFunc, n.Message, file)
+	if err != nil {
+		return err
+	}
+
+	// The source of the expression is shown when the assertion fails.
+	expr := format.Expr(n.Expr)
+
+	scope := &vm.ErrorScope{
+		Start: len(compiledFunc.Instructions),
+	}
+
+	_, _, err = compileExpr(compiledFunc, n.Expr, file)
+	if err != nil {
+		return err
+	}
+
+	scope.End = len(compiledFunc.Instructions)
+
+	// Reaching here means that nothing was raised.
+	compiledFunc.Append(&vm.AssertRaise{
+		Err:     vm.NoRegister,
+		Type:    n.Type,
+		Message: message,
+		Expr:    expr,
+		Pos:     n.Position(),
 	})
 
+	done := &vm.Jump{}
+	compiledFunc.Append(done)
+
+	// The expected type is checked first because it may not be an Error.
+	types := []string{n.Type}
+	if n.Type != "Error" {
+		types = append(types, "Error")
+	}
+
+	for _, ty := range types {
+		on := &vm.On{
+			Type: ty,
+			Err:  compiledFunc.NextRegister(),
+			To:   len(compiledFunc.Instructions),
+		}
+		scope.On = append(scope.On, on)
+
+		compiledFunc.Append(&vm.AssertRaise{
+			Err:     on.Err,
+			Type:    n.Type,
+			Message: message,
+			Expr:    expr,
+			Pos:     n.Position(),
+		})
+		compiledFunc.Append(done)
+	}
+
+	// The "-1" is to correct for the "+1" that would happen after every
+	// instruction.
+	done.To = len(compiledFunc.Instructions) - 1
+
+	compiledFunc.ErrorScopes = append(compiledFunc.ErrorScopes, scope)
+
 	return nil
 }
+
+// compileAssertMessage returns vm.NoRegister when there is no message.
+func compileAssertMessage(
+	compiledFunc *vm.CompiledFunc,
+	message ast.Node,
+	file *Compiled,
+) (vm.Register, error) {
+	if message == nil {
+		return vm.NoRegister, nil
+	}
+
+	registers, kinds, err := compileExpr(compiledFunc, message, file)
+	if err != nil {
+		return vm.NoRegister, err
+	}
+
+	if kinds[0] != "string" {
+		return vm.NoRegister, newDiagnostic(message.Position(),
+			CodeTypeMismatch, "assert message must be a string but is %s",
+			kinds[0])
+	}
+
+	return registers[0], nil
+}
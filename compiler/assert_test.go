@@ -29,6 +29,53 @@ func TestAssert(t *testing.T) {
 			},
 			err: errors.New("assert condition must be a bool but is number"),
 		},
+		"message-not-a-string": {
+			node: &ast.Assert{
+				Expr: asttest.NewBinary(
+					asttest.NewLiteralNumber("1"),
+					lexer.TokenEqual,
+					asttest.NewLiteralNumber("2"),
+				),
+				Message: asttest.NewLiteralNumber("3"),
+			},
+			err: errors.New("assert message must be a string but is number"),
+		},
+		"message": {
+			node: &ast.Assert{
+				Expr: asttest.NewBinary(
+					asttest.NewLiteralNumber("1"),
+					lexer.TokenEqual,
+					asttest.NewLiteralNumber("2"),
+				),
+				Message: asttest.NewLiteralString("why"),
+			},
+			expected: []vm.Instruction{
+				&vm.Assign{
+					VariableName: 1,
+					Value:        asttest.NewLiteralNumber("1"),
+				},
+				&vm.Assign{
+					VariableName: 2,
+					Value:        asttest.NewLiteralNumber("2"),
+				},
+				&vm.EqualNumber{
+					Left:   1,
+					Right:  2,
+					Result: 3,
+				},
+				&vm.Assign{
+					VariableName: 4,
+					Value:        asttest.NewLiteralString("why"),
+				},
+				&vm.Assert{
+					Left:    1,
+					Op:      "==",
+					Right:   2,
+					Final:   3,
+					Message: 4,
+				},
+			},
+		},
 		"success": {
 			node: &ast.Assert{
 				Expr: asttest.NewBinary(
@@ -39,23 +86,24 @@ func TestAssert(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.EqualNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 				&vm.Assert{
-					Left:  "1",
-					Op:    "==",
-					Right: "2",
-					Final: "3",
+					Left:    1,
+					Op:      "==",
+					Right:   2,
+					Final:   3,
+					Message: vm.NoRegister,
 				},
 			},
 		},
@@ -75,3 +123,104 @@ func TestAssert(t *testing.T) {
 		})
 	}
 }
+
+func TestAssertRaise(t *testing.T) {
+	for testName, test := range map[string]struct {
+		node        *ast.AssertRaise
+		expected    []vm.Instruction
+		errorScopes []*vm.ErrorScope
+	}{
+		"error": {
+			node: &ast.AssertRaise{
+				Expr: &ast.Call{FunctionName: "foo"},
+				Type: "Error",
+			},
+			expected: []vm.Instruction{
+				&vm.Call{FunctionName: "foo"},
+				&vm.AssertRaise{
+					Err:     vm.NoRegister,
+					Type:    "Error",
+					Message: vm.NoRegister,
+					Expr:    "foo()",
+				},
+				&vm.Jump{To: 4},
+				&vm.AssertRaise{
+					Err:     1,
+					Type:    "Error",
+					Message: vm.NoRegister,
+					Expr:    "foo()",
+				},
+				&vm.Jump{To: 4},
+			},
+			errorScopes: []*vm.ErrorScope{
+				{
+					Start: 0,
+					End:   1,
+					On: []*vm.On{
+						{Type: "Error", Err: 1, To: 3},
+					},
+				},
+			},
+		},
+		"custom-type": {
+			node: &ast.AssertRaise{
+				Expr:    &ast.Call{FunctionName: "foo"},
+				Type:    "MyError",
+				Message: asttest.NewLiteralString("why"),
+			},
+			expected: []vm.Instruction{
+				&vm.Assign{
+					VariableName: 1,
+					Value:        asttest.NewLiteralString("why"),
+				},
+				&vm.Call{FunctionName: "foo"},
+				&vm.AssertRaise{
+					Err:     vm.NoRegister,
+					Type:    "MyError",
+					Message: 1,
+					Expr:    "foo()",
+				},
+				&vm.Jump{To: 7},
+				&vm.AssertRaise{
+					Err:     2,
+					Type:    "MyError",
+					Message: 1,
+					Expr:    "foo()",
+				},
+				&vm.Jump{To: 7},
+				&vm.AssertRaise{
+					Err:     3,
+					Type:    "MyError",
+					Message: 1,
+					Expr:    "foo()",
+				},
+				&vm.Jump{To: 7},
+			},
+			errorScopes: []*vm.ErrorScope{
+				{
+					Start: 1,
+					End:   2,
+					On: []*vm.On{
+						{Type: "MyError", Err: 2, To: 4},
+						{Type: "Error", Err: 3, To: 6},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			compiledFunc, err := compiler.CompileFunc(&ast.Func{
+				Statements: []ast.Node{
+					test.node,
+				},
+			}, &compiler.Compiled{
+				FuncDefs: map[string]*ast.Func{
+					"foo": {Name: "foo"},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, compiledFunc.Instructions)
+			assert.Equal(t, test.errorScopes, compiledFunc.ErrorScopes)
+		})
+	}
+}
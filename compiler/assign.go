@@ -1,10 +1,10 @@
 package compiler
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/compiler/kind"
 	"github.com/elliotchance/ok/vm"
 )
 
@@ -29,7 +29,8 @@ func compileAssign(compiledFunc *vm.CompiledFunc, node *ast.Assign, file *Compil
 	}
 
 	if len(rightResults) != len(node.Lefts) {
-		return fmt.Errorf("cannot assign %d values to %d variables",
+		return newDiagnostic(node.Position(), CodeAssignCount,
+			"cannot assign %d values to %d variables",
 			len(rightResults), len(node.Lefts))
 	}
 
@@ -39,17 +40,22 @@ func compileAssign(compiledFunc *vm.CompiledFunc, node *ast.Assign, file *Compil
 		case *ast.Identifier:
 			variableName := l.Name
 
-			// Make sure we do not assign the wrong type to an existing variable.
-			if v, ok := compiledFunc.Variables[variableName]; ok && rightResults[0].kind != v {
-				return fmt.Errorf(
-					"cannot assign %s to variable %s (expecting %s)",
-					rightResults[0].kind, variableName, v)
-			}
+			// Make sure we do not assign the wrong type to an existing
+			// variable. The variable keeps its declared type, which may be an
+			// interface.
+			ty := rr.kind
+			if v, ok := compiledFunc.Variables[variableName]; ok {
+				if checkType(file, v, rr.kind) != nil {
+					return newDiagnostic(l.Position(), CodeTypeMismatch,
+						"cannot assign %s to variable %s (expecting %s)",
+						rr.kind, variableName, v)
+				}
 
-			compiledFunc.NewVariable(variableName, rr.kind)
+				ty = v
+			}
 
 			compiledFunc.Append(&vm.Assign{
-				VariableName: vm.Register(variableName),
+				VariableName: compiledFunc.NewVariable(variableName, ty),
 				Register:     rr.result,
 			})
 
@@ -66,18 +72,29 @@ func compileAssign(compiledFunc *vm.CompiledFunc, node *ast.Assign, file *Compil
 				return err
 			}
 
-			if strings.HasPrefix(arrayOrMapKind[0], "[]") {
+			// The value must be the same type as the other elements.
+			containerKind := arrayOrMapKind[0]
+			if kind.IsArray(containerKind) || kind.IsMap(containerKind) {
+				elementKind := kind.ElementType(containerKind)
+				if checkType(file, elementKind, rr.kind) != nil {
+					return newDiagnostic(l.Position(), CodeTypeMismatch,
+						"cannot assign %s to element of %s (expecting %s)",
+						rr.kind, containerKind, elementKind)
+				}
+			}
+
+			if strings.HasPrefix(containerKind, "[]") {
 				ins := &vm.ArraySet{
 					Array: arrayOrMapResults[0],
 					Index: keyResults[0],
-					Value: rightResults[0].result,
+					Value: rr.result,
 				}
 				compiledFunc.Append(ins)
 			} else {
 				ins := &vm.MapSet{
 					Map:   arrayOrMapResults[0],
 					Key:   keyResults[0],
-					Value: rightResults[0].result,
+					Value: rr.result,
 				}
 				compiledFunc.Append(ins)
 			}
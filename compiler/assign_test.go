@@ -1,6 +1,7 @@
 package compiler_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/elliotchance/ok/ast"
@@ -30,12 +31,12 @@ func TestAssign(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 			},
 		},
@@ -54,23 +55,79 @@ func TestAssign(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("3.0"),
 				},
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "1",
+					VariableName: 3,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "bar",
-					Register:     "2",
+					VariableName: 4,
+					Register:     2,
 				},
 			},
 		},
+		"assign-array-element-wrong-type": {
+			nodes: []ast.Node{
+				&ast.Assign{
+					Lefts: []ast.Node{
+						&ast.Identifier{Name: "arr"},
+					},
+					Rights: []ast.Node{
+						asttest.NewArrayNumbers([]string{"1"}),
+					},
+				},
+				&ast.Assign{
+					Lefts: []ast.Node{
+						&ast.Key{
+							Expr: &ast.Identifier{Name: "arr"},
+							Key:  asttest.NewLiteralNumber("0"),
+						},
+					},
+					Rights: []ast.Node{
+						asttest.NewLiteralString("s"),
+					},
+				},
+			},
+			err: errors.New("cannot assign string to element of []number (expecting number)"),
+		},
+		"assign-map-element-wrong-type": {
+			nodes: []ast.Node{
+				&ast.Assign{
+					Lefts: []ast.Node{
+						&ast.Identifier{Name: "m"},
+					},
+					Rights: []ast.Node{
+						&ast.Map{
+							Kind: "{}number",
+							Elements: []*ast.KeyValue{
+								{
+									Key:   asttest.NewLiteralString("a"),
+									Value: asttest.NewLiteralNumber("1"),
+								},
+							},
+						},
+					},
+				},
+				&ast.Assign{
+					Lefts: []ast.Node{
+						&ast.Key{
+							Expr: &ast.Identifier{Name: "m"},
+							Key:  asttest.NewLiteralString("a"),
+						},
+					},
+					Rights: []ast.Node{
+						asttest.NewLiteralString("x"),
+					},
+				},
+			},
+			err: errors.New("cannot assign string to element of {}number (expecting number)"),
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			compiledFunc, err := compiler.CompileFunc(newFunc(test.nodes...),
@@ -0,0 +1,23 @@
+package compiler
+
+import (
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/vm"
+)
+
+// CompileBench will compile a benchmark.
+func CompileBench(fn *ast.Bench, file *Compiled) (*vm.CompiledBench, error) {
+	// Benchmarks are compiled in the same way as tests.
+	compiledFunc, err := CompileFunc(&ast.Func{
+		Statements: fn.Statements,
+		Pos:        fn.Pos,
+	}, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vm.CompiledBench{
+		CompiledFunc: compiledFunc,
+		BenchName:    fn.Name,
+	}, nil
+}
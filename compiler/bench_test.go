@@ -0,0 +1,43 @@
+package compiler_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBench(t *testing.T) {
+	for testName, test := range map[string]struct {
+		fn       *ast.Bench
+		expected []vm.Instruction
+	}{
+		"no-statements": {
+			fn: &ast.Bench{},
+		},
+		"one-statement": {
+			fn: &ast.Bench{
+				Name: "print",
+				Statements: []ast.Node{
+					&ast.Call{
+						FunctionName: "print",
+					},
+				},
+			},
+			expected: []vm.Instruction{
+				&vm.Print{},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			compiledBench, err := compiler.CompileBench(test.fn,
+				&compiler.Compiled{})
+			require.NoError(t, err)
+			assert.Equal(t, test.fn.Name, compiledBench.BenchName)
+			assert.Equal(t, test.expected, compiledBench.Instructions)
+		})
+	}
+}
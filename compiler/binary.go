@@ -165,7 +165,7 @@ func compileBinary(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Compil
 
 		right, rightKind, err := compileExpr(compiledFunc, node.Right, file)
 		if err != nil {
-			return "", "", err
+			return vm.NoRegister, "", err
 		}
 
 		// TODO(elliot): Check +=, etc.
@@ -173,13 +173,13 @@ func compileBinary(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Compil
 			arrayOrMapResults, arrayOrMapKind, err := compileExpr(compiledFunc,
 				key.Expr, file)
 			if err != nil {
-				return "", "", err
+				return vm.NoRegister, "", err
 			}
 
 			// TODO(elliot): Check this is a sane operation.
 			keyResults, _, err := compileExpr(compiledFunc, key.Key, file)
 			if err != nil {
-				return "", "", err
+				return vm.NoRegister, "", err
 			}
 
 			if kind.IsArray(arrayOrMapKind[0]) {
@@ -200,19 +200,21 @@ func compileBinary(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Compil
 			}
 
 			// TODO(elliot): Return something more reasonable here.
-			return "", "", nil
+			return vm.NoRegister, "", nil
 		}
 
 		variable, ok := node.Left.(*ast.Identifier)
 		if !ok {
-			return "", "", fmt.Errorf("cannot assign to non-variable")
+			return vm.NoRegister, "", newDiagnostic(node.Position(),
+				CodeInvalidOperation, "cannot assign to non-variable")
 		}
 
 		// Make sure we do not assign the wrong type to an existing variable.
 		if v, ok := compiledFunc.Variables[variable.Name]; ok && rightKind[0] != v {
-			return "", "", fmt.Errorf(
-				"%s cannot assign %s to variable %s (expecting %s)",
-				variable.Position(), rightKind[0], variable.Name, v)
+			return vm.NoRegister, "", newDiagnostic(variable.Position(),
+				CodeTypeMismatch,
+				"cannot assign %s to variable %s (expecting %s)",
+				rightKind[0], variable.Name, v)
 		}
 
 		switch node.Op {
@@ -220,63 +222,63 @@ func compileBinary(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Compil
 			switch {
 			case kind.IsArray(rightKind[0]):
 				compiledFunc.Append(&vm.Append{
-					A:      vm.Register(variable.Name),
+					A:      compiledFunc.VariableRegister(variable.Name),
 					B:      right[0],
-					Result: vm.Register(variable.Name),
+					Result: compiledFunc.VariableRegister(variable.Name),
 				})
 
 			case rightKind[0] == "data":
 				compiledFunc.Append(&vm.Combine{
-					Left:   vm.Register(variable.Name),
+					Left:   compiledFunc.VariableRegister(variable.Name),
 					Right:  right[0],
-					Result: vm.Register(variable.Name),
+					Result: compiledFunc.VariableRegister(variable.Name),
 				})
 
 			case rightKind[0] == "number":
 				compiledFunc.Append(&vm.Add{
-					Left:   vm.Register(variable.Name),
-					Right:  vm.Register(right[0]),
-					Result: vm.Register(variable.Name),
+					Left:   compiledFunc.VariableRegister(variable.Name),
+					Right:  right[0],
+					Result: compiledFunc.VariableRegister(variable.Name),
 				})
 
 			case rightKind[0] == "string":
 				compiledFunc.Append(&vm.Concat{
-					Left:   vm.Register(variable.Name),
+					Left:   compiledFunc.VariableRegister(variable.Name),
 					Right:  right[0],
-					Result: vm.Register(variable.Name),
+					Result: compiledFunc.VariableRegister(variable.Name),
 				})
 			}
 
 		case lexer.TokenMinusAssign:
 			compiledFunc.Append(&vm.Subtract{
-				Left:   vm.Register(variable.Name),
+				Left:   compiledFunc.VariableRegister(variable.Name),
 				Right:  right[0],
-				Result: vm.Register(variable.Name),
+				Result: compiledFunc.VariableRegister(variable.Name),
 			})
 
 		case lexer.TokenTimesAssign:
 			compiledFunc.Append(&vm.Multiply{
-				Left:   vm.Register(variable.Name),
+				Left:   compiledFunc.VariableRegister(variable.Name),
 				Right:  right[0],
-				Result: vm.Register(variable.Name),
+				Result: compiledFunc.VariableRegister(variable.Name),
 			})
 
 		case lexer.TokenDivideAssign:
 			compiledFunc.Append(&vm.Divide{
-				Left:   vm.Register(variable.Name),
+				Left:   compiledFunc.VariableRegister(variable.Name),
 				Right:  right[0],
-				Result: vm.Register(variable.Name),
+				Result: compiledFunc.VariableRegister(variable.Name),
 			})
 
 		case lexer.TokenRemainderAssign:
 			compiledFunc.Append(&vm.Remainder{
-				Left:   vm.Register(variable.Name),
+				Left:   compiledFunc.VariableRegister(variable.Name),
 				Right:  right[0],
-				Result: vm.Register(variable.Name),
+				Result: compiledFunc.VariableRegister(variable.Name),
 			})
 		}
 
-		return vm.Register(variable.Name), rightKind[0], nil
+		return compiledFunc.VariableRegister(variable.Name), rightKind[0], nil
 	}
 
 	_, _, returns, returnKind, err := compileComparison(compiledFunc, node, file)
@@ -287,12 +289,12 @@ func compileBinary(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Compil
 func compileComparison(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Compiled) (vm.Register, vm.Register, vm.Register, string, error) {
 	left, leftKind, err := compileExpr(compiledFunc, node.Left, file)
 	if err != nil {
-		return "", "", "", "", err
+		return vm.NoRegister, vm.NoRegister, vm.NoRegister, "", err
 	}
 
 	right, rightKind, err := compileExpr(compiledFunc, node.Right, file)
 	if err != nil {
-		return "", "", "", "", err
+		return vm.NoRegister, vm.NoRegister, vm.NoRegister, "", err
 	}
 
 	returns := compiledFunc.NextRegister()
@@ -308,5 +310,6 @@ func compileComparison(compiledFunc *vm.CompiledFunc, node *ast.Binary, file *Co
 	}
 
 	return left[0], right[0], returns, "",
-		fmt.Errorf("%s cannot perform %s", node.Position(), op)
+		newDiagnostic(node.Position(), CodeInvalidOperation,
+			"cannot perform %s", op)
 }
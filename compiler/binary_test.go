@@ -37,21 +37,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralData([]byte("foo")),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralData([]byte("bar")),
 				},
 				&vm.Combine{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -73,21 +73,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.Concat{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -109,21 +109,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Add{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -145,21 +145,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Subtract{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -181,21 +181,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Multiply{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -217,21 +217,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Divide{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -253,21 +253,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Remainder{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -290,7 +290,7 @@ func TestBinary(t *testing.T) {
 					},
 				},
 			},
-			err: errors.New(" cannot perform string / number"),
+			err: errors.New("cannot perform string / number"),
 		},
 		"data-plus-data": {
 			nodes: []ast.Node{
@@ -302,17 +302,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralData([]byte("foo")),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralData([]byte("bar")),
 				},
 				&vm.Combine{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -326,17 +326,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.20"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Add{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -350,17 +350,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.Concat{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -374,17 +374,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.20"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Subtract{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -398,17 +398,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.20"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Multiply{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -422,17 +422,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.20"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Divide{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -446,17 +446,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1.20"),
 				},
 				&vm.Remainder{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -489,7 +489,7 @@ func TestBinary(t *testing.T) {
 					asttest.NewLiteralBool(true),
 				),
 			},
-			err: errors.New(" cannot perform bool > bool"),
+			err: errors.New("cannot perform bool > bool"),
 		},
 		"bool-equals-bool": {
 			nodes: []ast.Node{
@@ -501,17 +501,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Equal{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -525,17 +525,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralChar('a'),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralChar('B'),
 				},
 				&vm.Equal{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -549,17 +549,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralData([]byte("a")),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralData([]byte("B")),
 				},
 				&vm.Equal{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -573,17 +573,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.EqualNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -597,17 +597,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.Equal{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -621,17 +621,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.NotEqual{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -645,17 +645,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralChar('a'),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralChar('B'),
 				},
 				&vm.NotEqual{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -669,17 +669,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralData([]byte("a")),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralData([]byte("B")),
 				},
 				&vm.NotEqual{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -693,17 +693,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.NotEqualNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -717,17 +717,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.NotEqual{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -741,17 +741,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.GreaterThanNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -765,17 +765,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.GreaterThanEqualNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -789,17 +789,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.LessThanNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -813,17 +813,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.LessThanEqualNumber{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -837,17 +837,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("1"),
 				},
 				&vm.GreaterThanString{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -861,17 +861,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("1"),
 				},
 				&vm.GreaterThanEqualString{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -885,17 +885,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("1"),
 				},
 				&vm.LessThanString{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -909,17 +909,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("1.0"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("1"),
 				},
 				&vm.LessThanEqualString{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -934,21 +934,21 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("total is"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0.8"),
 				},
 				&vm.Add{
-					Left:   "2",
-					Right:  "3",
-					Result: "4",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 			},
 		},
@@ -962,17 +962,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.And{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -986,17 +986,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralBool(false),
 				},
 				&vm.And{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -1010,17 +1010,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Or{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -1034,17 +1034,17 @@ func TestBinary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralBool(false),
 				},
 				&vm.Or{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -1067,7 +1067,7 @@ func TestBinary(t *testing.T) {
 					},
 				},
 			},
-			err: errors.New(" cannot perform bool + bool"),
+			err: errors.New("cannot perform bool + bool"),
 		},
 		"plus-assign-array": {
 			nodes: []ast.Node{
@@ -1088,35 +1088,35 @@ func TestBinary(t *testing.T) {
 			expected: []vm.Instruction{
 				// first array
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "2",
+					VariableName: 3,
+					Register:     2,
 				},
 
 				// second array
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "3",
-					Result: "4",
+					Size:   4,
+					Result: 5,
 					Kind:   "[]number",
 				},
 
 				// +=
 				&vm.Append{
-					A:      "i",
-					B:      "4",
-					Result: "i",
+					A:      3,
+					B:      5,
+					Result: 3,
 				},
 			},
 		},
@@ -8,12 +8,30 @@ import (
 // compileBlock translates list of statements into a set of instructions. The
 // number of instructions returned may be zero.
 func compileBlock(compiledFunc *vm.CompiledFunc, stmts []ast.Node, breakIns, continueIns vm.Instruction, file *Compiled) error {
+	// An error does not stop the following statements from being compiled so
+	// that all of the errors can be reported at once.
+	var errs []error
 	for _, statement := range stmts {
 		err := compileStatement(compiledFunc, statement, breakIns, continueIns, file)
 		if err != nil {
-			return err
+			errs = appendErrors(errs, err)
 		}
 	}
 
-	return nil
+	switch len(errs) {
+	case 0:
+		return nil
+
+	case 1:
+		return errs[0]
+	}
+
+	return errorList(errs)
+}
+
+// CompileStatements appends the instructions for stmts to an existing
+// function. This allows code to be compiled in the context of variables that
+// were declared previously, such as each input of the REPL.
+func CompileStatements(compiledFunc *vm.CompiledFunc, stmts []ast.Node, file *Compiled) error {
+	return compileBlock(compiledFunc, stmts, nil, nil, file)
 }
@@ -0,0 +1,145 @@
+package compiler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/elliotchance/ok/vm"
+)
+
+var (
+	compilerHash     string
+	compilerHashOnce sync.Once
+)
+
+// compilerFingerprint changes whenever the compiler itself changes so that
+// packages compiled by a different version of ok are not loaded from the
+// cache.
+func compilerFingerprint() string {
+	compilerHashOnce.Do(func() {
+		h := sha256.New()
+		fmt.Fprintf(h, "bytecode %d\n", vm.BytecodeVersion)
+
+		if executable, err := os.Executable(); err == nil {
+			if f, err := os.Open(executable); err == nil {
+				_, _ = io.Copy(h, f)
+				f.Close()
+			}
+		}
+
+		compilerHash = hex.EncodeToString(h.Sum(nil))
+	})
+
+	return compilerHash
+}
+
+// packageHash is the key for a package in the cache. It includes the source
+// files of the package and the hashes of the packages it imports, so a change
+// to any dependency will also produce a new hash.
+func packageHash(pkg *parsedPackage, deps map[string]*importedPackage) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "compiler %s\n", compilerFingerprint())
+	fmt.Fprintf(h, "package %q\n", pkg.name)
+
+	// The file names are used as they are because they are part of the
+	// positions in the compiled package. The same package compiled from another
+	// directory will have different positions.
+	for _, fileName := range pkg.fileNames {
+		fmt.Fprintf(h, "file %q %d\n", fileName, len(pkg.sources[fileName]))
+		h.Write(pkg.sources[fileName])
+	}
+
+	var names []string
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(h, "import %q %s\n", name, deps[name].hash)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheDir is where compiled packages are stored. It will be empty if there is
+// no cache directory for the current user, in which case nothing is cached.
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "ok")
+}
+
+func cacheFileName(hash string) string {
+	if dir := cacheDir(); dir != "" {
+		return filepath.Join(dir, hash+".okc")
+	}
+
+	return ""
+}
+
+// loadCachedPackage returns nil if the package is not in the cache or cannot
+// be read.
+func loadCachedPackage(hash string) *vm.Program {
+	fileName := cacheFileName(hash)
+	if fileName == "" {
+		return nil
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	program, err := vm.DecodeProgram(f)
+	if err != nil {
+		return nil
+	}
+
+	return program
+}
+
+// saveCachedPackage is best effort. The cache is only an optimization so any
+// errors are ignored.
+func saveCachedPackage(hash string, program *vm.Program) {
+	fileName := cacheFileName(hash)
+	if fileName == "" {
+		return
+	}
+
+	dir := filepath.Dir(fileName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	// Writing to a temporary file first means that another process will never
+	// see a partially written package.
+	f, err := ioutil.TempFile(dir, hash+".*.tmp")
+	if err != nil {
+		return
+	}
+
+	err = program.Encode(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), fileName)
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
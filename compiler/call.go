@@ -1,7 +1,7 @@
 package compiler
 
 import (
-	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/elliotchance/ok/ast"
@@ -11,36 +11,133 @@ import (
 
 type builtinFn func(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error)
 
-// TODO(elliot): These needs to check function signatures.
 var builtinFunctions = map[string]builtinFn{
-	"__call":      funcCall,
-	"__get":       funcGet,
-	"__interface": funcInterface,
-	"__len":       funcLen,
-	"__log":       funcLog,
-	"__pow":       funcPow,
-	"__props":     funcProps,
-	"__set":       funcSet,
-	"__type":      funcType,
-	"char":        funcChar,
-	"len":         funcLen,
-	"number":      funcNumber,
-	"print":       funcPrint,
-	"string":      funcString,
+	"__appendfile": funcAppendFile,
+	"__args":       funcArgs,
+	"__call":       funcCall,
+	"__environ":    funcEnviron,
+	"__eof":        funcEOF,
+	"__exit":       funcExit,
+	"__fclose":     funcFileClose,
+	"__feof":       funcFileEOF,
+	"__fopen":      funcFileOpen,
+	"__fread":      funcFileRead,
+	"__freadline":  funcFileReadLine,
+	"__fwrite":     funcFileWrite,
+	"__get":        funcGet,
+	"__getenv":     funcGetenv,
+	"__getwd":      funcGetwd,
+	"__glob":       funcGlob,
+	"__hostname":   funcHostname,
+	"__interface":  funcInterface,
+	"__len":        funcLen,
+	"__log":        funcLog,
+	"__mkdirall":   funcMkdirAll,
+	"__pid":        funcPid,
+	"__pow":        funcPow,
+	"__props":      funcProps,
+	"__readall":    funcReadAll,
+	"__readdir":    funcReadDir,
+	"__readfile":   funcReadFile,
+	"__readline":   funcReadLine,
+	"__remove":     funcRemove,
+	"__removeall":  funcRemoveAll,
+	"__rename":     funcRename,
+	"__set":        funcSet,
+	"__setenv":     funcSetenv,
+	"__stat":       funcStat,
+	"__tempdir":    funcTempDir,
+	"__tempfile":   funcTempFile,
+	"__type":       funcType,
+	"__write":      funcWrite,
+	"__writeerror": funcWriteError,
+	"__writefile":  funcWriteFile,
+	"char":         funcChar,
+	"data":         funcData,
+	"len":          funcLen,
+	"number":       funcNumber,
+	"print":        funcPrint,
+	"string":       funcString,
+}
+
+// builtinArguments are the argument types for each of the builtinFunctions.
+// print is not included because it accepts any number of arguments.
+var builtinArguments = map[string][]string{
+	"__appendfile": {"string", "data"},
+	"__args":       {},
+	"__call":       {"any", "[]any"},
+	"__environ":    {},
+	"__eof":        {},
+	"__exit":       {"number"},
+	"__fclose":     {"number"},
+	"__feof":       {"number"},
+	"__fopen":      {"string", "string"},
+	"__fread":      {"number", "number"},
+	"__freadline":  {"number"},
+	"__fwrite":     {"number", "data"},
+	"__get":        {"any", "string"},
+	"__getenv":     {"string"},
+	"__getwd":      {},
+	"__glob":       {"string"},
+	"__hostname":   {},
+	"__interface":  {"any"},
+	"__len":        {"any"},
+	"__log":        {"number"},
+	"__mkdirall":   {"string"},
+	"__pid":        {},
+	"__pow":        {"number", "number"},
+	"__props":      {"any"},
+	"__readall":    {},
+	"__readdir":    {"string"},
+	"__readfile":   {"string"},
+	"__readline":   {},
+	"__remove":     {"string"},
+	"__removeall":  {"string"},
+	"__rename":     {"string", "string"},
+	"__set":        {"any", "string", "any"},
+	"__setenv":     {"string", "string"},
+	"__stat":       {"string"},
+	"__tempdir":    {},
+	"__tempfile":   {},
+	"__type":       {"any"},
+	"__write":      {"string"},
+	"__writeerror": {"string"},
+	"__writefile":  {"string", "data"},
+	"char":         {"number"},
+	"data":         {"any"},
+	"len":          {"any"},
+	"number":       {"char"},
+	"string":       {"any"},
 }
 
 func compileCall(compiledFunc *vm.CompiledFunc, call *ast.Call, file *Compiled) ([]vm.Register, []string, error) {
 	var argResults []vm.Register
+	var argKinds []string
 	for _, arg := range call.Arguments {
-		argResult, _, err := compileExpr(compiledFunc, arg, file)
+		argResult, argKind, err := compileExpr(compiledFunc, arg, file)
 		if err != nil {
 			return nil, nil, err
 		}
 
 		argResults = append(argResults, argResult...)
+		argKinds = append(argKinds, argKind...)
 	}
 
 	if fn, ok := builtinFunctions[call.FunctionName]; ok {
+		if args, ok := builtinArguments[call.FunctionName]; ok {
+			builtin := &ast.Func{}
+			for _, arg := range args {
+				builtin.Arguments = append(builtin.Arguments,
+					&ast.Argument{Type: arg})
+			}
+
+			err := checkArguments(file, call.Position(), call.FunctionName,
+				builtin, argKinds)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
 		ins, result, returnType, err := fn(compiledFunc, argResults)
 		if err != nil {
 			return nil, nil, err
@@ -48,14 +145,25 @@ func compileCall(compiledFunc *vm.CompiledFunc, call *ast.Call, file *Compiled)
 
 		compiledFunc.Append(ins)
 
+		// Some builtins, like __stat, return a type that is declared by the
+		// package that uses them.
+		returnType = file.qualifiedType(returnType)
+
 		return []vm.Register{result}, []string{returnType}, nil
 	}
 
+	// findFunc may replace the function name.
+	name := call.FunctionName
+
 	toCall, err := findFunc(compiledFunc, call, file)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if err := checkArguments(file, call.Position(), name, toCall, argKinds); err != nil {
+		return nil, nil, err
+	}
+
 	// Prepare enough return registers.
 	var returnRegisters []vm.Register
 	for range toCall.Returns {
@@ -86,40 +194,42 @@ func findFunc(compiledFunc *vm.CompiledFunc, call *ast.Call, file *Compiled) (*a
 
 		// TODO(elliot): This is a pretty nasty hack for now. This will tell the
 		//  VM at runtime to resolve this variable to the real function name.
-		call.FunctionName = "*" + call.FunctionName
+		call.FunctionName = "*" + strconv.Itoa(int(
+			compiledFunc.VariableRegister(call.FunctionName)))
 
 		return toCall, nil
 	}
 
-	toCall := file.FuncDefs[call.FunctionName]
-	if toCall != nil {
+	if toCall, name := file.funcDef(call.FunctionName); toCall != nil {
+		call.FunctionName = name
+
 		return toCall, nil
 	}
 
 	// It might be a built in function.
 	//
 	// TODO(elliot): This needs to only allow this usage if its imported.
-	if internal := vm.Lib[call.FunctionName]; internal != nil {
+	if internal := vm.Lib[call.FunctionName]; internal != nil && internal.FuncDef != nil {
 		return internal.FuncDef, nil
 	}
 
 	// Is it a method being called on a variable?
 	parts := strings.Split(call.FunctionName, ".")
 	if len(parts) != 2 {
-		return nil, fmt.Errorf("%s no such function: %s",
-			call.Position(), call.FunctionName)
+		return nil, newDiagnostic(call.Position(), CodeUndefinedFunction,
+			"no such function: %s", call.FunctionName)
 	}
 
 	ty, ok := compiledFunc.Variables[parts[0]]
 	if !ok {
-		return nil, fmt.Errorf("%s no such function %s on variable %s",
-			call.Position(), call.FunctionName, parts[0])
+		return nil, newDiagnostic(call.Position(), CodeUndefinedFunction,
+			"no such function %s on variable %s", call.FunctionName, parts[0])
 	}
 
-	methodType, ok := file.Interfaces[ty][parts[1]]
+	methodType, ok := interfaceOf(file, ty)[parts[1]]
 	if !ok {
-		return nil, fmt.Errorf("%s no such function %s on %s",
-			call.Position(), parts[1], ty)
+		return nil, newDiagnostic(call.Position(), CodeUndefinedFunction,
+			"no such function %s on %s", parts[1], ty)
 	}
 
 	keyRegister := compiledFunc.NextRegister()
@@ -130,13 +240,13 @@ func findFunc(compiledFunc *vm.CompiledFunc, call *ast.Call, file *Compiled) (*a
 
 	callRegister := compiledFunc.NextRegister()
 	compiledFunc.Append(&vm.MapGet{
-		Map:    vm.Register(parts[0]),
+		Map:    compiledFunc.VariableRegister(parts[0]),
 		Key:    keyRegister,
 		Result: callRegister,
 	})
 
-	toCall = ast.NewFuncFromPrototype(methodType)
-	call.FunctionName = "*" + string(callRegister)
+	toCall := ast.NewFuncFromPrototype(methodType)
+	call.FunctionName = "*" + strconv.Itoa(int(callRegister))
 
 	return toCall, nil
 }
@@ -275,5 +385,302 @@ func funcPrint(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Regis
 		Arguments: args,
 	}
 
-	return ins, "", "", nil
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcArgs(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Args{
+		Result: result,
+	}
+
+	return ins, result, "[]string", nil
+}
+
+func funcEnviron(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Environ{
+		Result: result,
+	}
+
+	return ins, result, "{}string", nil
+}
+
+func funcExit(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Exit{
+		Code: args[0],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcGetenv(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Getenv{
+		Name:   args[0],
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcGetwd(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Getwd{
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcHostname(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Hostname{
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcPid(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Pid{
+		Result: result,
+	}
+
+	return ins, result, "number", nil
+}
+
+func funcSetenv(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Setenv{
+		Name:  args[0],
+		Value: args[1],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcAppendFile(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.WriteFile{
+		Path:   args[0],
+		Data:   args[1],
+		Append: true,
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcData(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.CastData{
+		X:      args[0],
+		Result: result,
+	}
+
+	return ins, result, "data", nil
+}
+
+func funcFileClose(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.FileClose{
+		File: args[0],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcFileEOF(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.FileEOF{
+		File:   args[0],
+		Result: result,
+	}
+
+	return ins, result, "bool", nil
+}
+
+func funcFileOpen(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.FileOpen{
+		Path:   args[0],
+		Mode:   args[1],
+		Result: result,
+	}
+
+	return ins, result, "number", nil
+}
+
+func funcFileRead(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.FileRead{
+		File:   args[0],
+		Size:   args[1],
+		Result: result,
+	}
+
+	return ins, result, "data", nil
+}
+
+func funcFileReadLine(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.FileReadLine{
+		File:   args[0],
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcFileWrite(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.FileWrite{
+		File: args[0],
+		Data: args[1],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcGlob(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Glob{
+		Pattern: args[0],
+		Result:  result,
+	}
+
+	return ins, result, "[]string", nil
+}
+
+func funcMkdirAll(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.MkdirAll{
+		Path: args[0],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcReadDir(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.ReadDir{
+		Path:   args[0],
+		Result: result,
+	}
+
+	return ins, result, "[]FileInfo", nil
+}
+
+func funcReadFile(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.ReadFile{
+		Path:   args[0],
+		Result: result,
+	}
+
+	return ins, result, "data", nil
+}
+
+func funcRemove(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Remove{
+		Path: args[0],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcRemoveAll(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Remove{
+		Path: args[0],
+		All:  true,
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcRename(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Rename{
+		From: args[0],
+		To:   args[1],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcStat(compiledFunc *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.Stat{
+		Path:   args[0],
+		Result: result,
+	}
+
+	return ins, result, "FileInfo", nil
+}
+
+func funcTempDir(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.TempFile{
+		Dir:    true,
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcTempFile(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.TempFile{
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcWriteFile(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.WriteFile{
+		Path: args[0],
+		Data: args[1],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcReadLine(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.ReadLine{
+		Result: result,
+	}
+
+	return ins, result, "string", nil
+}
+
+func funcReadAll(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.ReadAll{
+		Result: result,
+	}
+
+	return ins, result, "data", nil
+}
+
+func funcEOF(compiledFunc *vm.CompiledFunc, _ []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	result := compiledFunc.NextRegister()
+	ins := &vm.EOF{
+		Result: result,
+	}
+
+	return ins, result, "bool", nil
+}
+
+func funcWrite(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Write{
+		Text: args[0],
+	}
+
+	return ins, vm.NoRegister, "", nil
+}
+
+func funcWriteError(_ *vm.CompiledFunc, args []vm.Register) (vm.Instruction, vm.Register, string, error) {
+	ins := &vm.Write{
+		Text:   args[0],
+		Stderr: true,
+	}
+
+	return ins, vm.NoRegister, "", nil
 }
@@ -1,6 +1,7 @@
 package compiler_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/elliotchance/ok/ast"
@@ -39,17 +40,17 @@ func TestCall(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 				&vm.Len{
-					Argument: "2",
-					Result:   "3",
+					Argument: 2,
+					Result:   3,
 				},
 			},
 		},
@@ -72,15 +73,15 @@ func TestCall(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"foo"},
+					Arguments: []vm.Register{2},
 				},
 			},
 		},
@@ -112,33 +113,115 @@ func TestCall(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Add{
-					Left:   "foo",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("10"),
 				},
 				&vm.Multiply{
-					Left:   "4",
-					Right:  "foo",
-					Result: "5",
+					Left:   5,
+					Right:  2,
+					Result: 6,
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"3", "5"},
+					Arguments: []vm.Register{4, 6},
+				},
+			},
+		},
+		"getenv": {
+			nodes: []ast.Node{
+				&ast.Call{
+					FunctionName: "__getenv",
+					Arguments: []ast.Node{
+						asttest.NewLiteralString("HOME"),
+					},
+				},
+			},
+			expected: []vm.Instruction{
+				&vm.Assign{
+					VariableName: 1,
+					Value:        asttest.NewLiteralString("HOME"),
+				},
+				&vm.Getenv{
+					Name:   1,
+					Result: 2,
+				},
+			},
+		},
+		"getenv-wrong-type": {
+			nodes: []ast.Node{
+				&ast.Call{
+					FunctionName: "__getenv",
+					Arguments: []ast.Node{
+						asttest.NewLiteralNumber("1"),
+					},
+				},
+			},
+			err: errors.New("cannot use number as string for argument 1 of __getenv"),
+		},
+		"exit": {
+			nodes: []ast.Node{
+				&ast.Call{
+					FunctionName: "__exit",
+					Arguments: []ast.Node{
+						asttest.NewLiteralNumber("3"),
+					},
+				},
+			},
+			expected: []vm.Instruction{
+				&vm.Assign{
+					VariableName: 1,
+					Value:        asttest.NewLiteralNumber("3"),
+				},
+				&vm.Exit{
+					Code: 1,
+				},
+			},
+		},
+		"readline": {
+			nodes: []ast.Node{
+				&ast.Call{
+					FunctionName: "__readline",
+				},
+			},
+			expected: []vm.Instruction{
+				&vm.ReadLine{
+					Result: 1,
+				},
+			},
+		},
+		"writeerror": {
+			nodes: []ast.Node{
+				&ast.Call{
+					FunctionName: "__writeerror",
+					Arguments: []ast.Node{
+						asttest.NewLiteralString("oops"),
+					},
+				},
+			},
+			expected: []vm.Instruction{
+				&vm.Assign{
+					VariableName: 1,
+					Value:        asttest.NewLiteralString("oops"),
+				},
+				&vm.Write{
+					Text:   1,
+					Stderr: true,
 				},
 			},
 		},
@@ -0,0 +1,61 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/util"
+)
+
+// Codes for the diagnostics produced by the compiler.
+const (
+	CodeArgumentCount     = "argument-count"
+	CodeAssignCount       = "assign-count"
+	CodeImportCycle       = "import-cycle"
+	CodeInvalidOperation  = "invalid-operation"
+	CodeReturnCount       = "return-count"
+	CodeTypeMismatch      = "type-mismatch"
+	CodeUndefinedFunction = "undefined-function"
+	CodeUndefinedVariable = "undefined-variable"
+	CodeUntypedArray      = "untyped-array"
+)
+
+// newDiagnostic creates an error at pos, which is in the form of
+// "file:line:column". The pos may be empty if the position is not known.
+func newDiagnostic(pos, code, format string, args ...interface{}) *util.Diagnostic {
+	p := lexer.ParsePos(pos)
+
+	return &util.Diagnostic{
+		File:     p.FileName,
+		Line:     p.LineNumber,
+		Column:   p.CharacterNumber,
+		Severity: util.SeverityError,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// errorList is returned when there is more than one error, such as when
+// several statements in the same function cannot be compiled.
+type errorList []error
+
+// Error implements the error interface. Each error is on its own line.
+func (errs errorList) Error() string {
+	var lines []string
+	for _, err := range errs {
+		lines = append(lines, err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// appendErrors adds err to errs. If err is an errorList each of its errors are
+// added separately.
+func appendErrors(errs []error, err error) []error {
+	if list, ok := err.(errorList); ok {
+		return append(errs, list...)
+	}
+
+	return append(errs, err)
+}
@@ -0,0 +1,120 @@
+package compiler_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src      string
+		expected []error
+	}{
+		"no-errors": {
+			src: `func main() {
+    print("hi")
+}`,
+		},
+		"one-error": {
+			src: `func main() {
+    print(a)
+}`,
+			expected: []error{
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     2,
+					Column:   11,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeUndefinedVariable,
+					Message:  "undefined variable: a",
+				},
+			},
+		},
+		"errors-in-one-function": {
+			src: `func main() {
+    print(a)
+    if true {
+        foo()
+    }
+    b = 1
+    b = "c"
+}`,
+			expected: []error{
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     2,
+					Column:   11,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeUndefinedVariable,
+					Message:  "undefined variable: a",
+				},
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     4,
+					Column:   9,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeUndefinedFunction,
+					Message:  "no such function: foo",
+				},
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     7,
+					Column:   5,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeTypeMismatch,
+					Message:  "cannot assign string to variable b (expecting number)",
+				},
+			},
+		},
+		"errors-in-all-functions-and-tests": {
+			src: `func foo() number {
+    return "a"
+}
+func main() {
+    bar()
+}
+test "baz" {
+    assert(1 == b)
+}`,
+			expected: []error{
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     2,
+					Column:   5,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeTypeMismatch,
+					Message:  "cannot use string as number for return value 1 of foo",
+				},
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     5,
+					Column:   5,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeUndefinedFunction,
+					Message:  "no such function: bar",
+				},
+				&util.Diagnostic{
+					File:     "a.ok",
+					Line:     8,
+					Column:   17,
+					Severity: util.SeverityError,
+					Code:     compiler.CodeUndefinedVariable,
+					Message:  "undefined variable: b",
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			p := parser.ParseString(test.src, "a.ok")
+			require.Nil(t, p.Errors())
+
+			_, errs := compiler.CompileFile(p.File, p.Interfaces, nil)
+			assert.Equal(t, test.expected, errs)
+		})
+	}
+}
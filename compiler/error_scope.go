@@ -15,11 +15,17 @@ func compileErrorScope(compiledFunc *vm.CompiledFunc, n *ast.ErrorScope, file *C
 	}
 
 	// Try section.
+	scope := &vm.ErrorScope{
+		Start: len(compiledFunc.Instructions),
+	}
+
 	err := compileBlock(compiledFunc, n.Statements, nil, nil, file)
 	if err != nil {
 		return err
 	}
 
+	scope.End = len(compiledFunc.Instructions)
+
 	// The done jump will be correct later. It is called after all the try
 	// statements (ie. there was no error raised) and will jump to after all the
 	// error handlers so the problem can continue.
@@ -32,14 +38,16 @@ func compileErrorScope(compiledFunc *vm.CompiledFunc, n *ast.ErrorScope, file *C
 
 	// Each of the On clauses.
 	for _, on := range n.On {
-		compiledFunc.Append(&vm.On{
-			Type: on.Type,
+		ty := file.qualifiedType(on.Type)
+
+		// Provide the err variable. The runtime value will be set by the VM
+		// before jumping to the handler.
+		scope.On = append(scope.On, &vm.On{
+			Type: ty,
+			Err:  compiledFunc.NewVariable("err", ty),
+			To:   len(compiledFunc.Instructions),
 		})
 
-		// Provide the err variable. The runtime value will be provided by the
-		// On instruction above.
-		compiledFunc.NewVariable("err", on.Type)
-
 		err := compileBlock(compiledFunc, on.Statements, nil, nil, file)
 		if err != nil {
 			return err
@@ -48,14 +56,14 @@ func compileErrorScope(compiledFunc *vm.CompiledFunc, n *ast.ErrorScope, file *C
 		compiledFunc.Append(done)
 	}
 
-	// An On with an empty Type signals to a VM trying to recover from an error
-	// that there is no handler. It will pass the error up to the caller.
-	compiledFunc.Append(&vm.On{Type: ""})
-
 	// Correct the jump after the error has been handled. The "-1" is to
 	// correct for the "+1" that would happen after every instruction.
 	done.To = len(compiledFunc.Instructions) - 1
 
+	// Any try blocks nested in this one have already been added so they will
+	// be checked first.
+	compiledFunc.ErrorScopes = append(compiledFunc.ErrorScopes, scope)
+
 	// The optional finally clause exists as unrelated code after all the
 	// clauses. On success the finally will run here, otherwise the block was
 	// activated as soon as the try was entered and the VM will ensure it is run
@@ -76,6 +84,11 @@ func compileErrorScope(compiledFunc *vm.CompiledFunc, n *ast.ErrorScope, file *C
 
 		finallyInstructions := compiledFunc.Instructions[beforeLen:]
 		compiledFunc.Finally = append(compiledFunc.Finally, finallyInstructions)
+
+		finallyPositions := append([]string(nil),
+			compiledFunc.Positions[beforeLen:]...)
+		compiledFunc.FinallyPositions = append(compiledFunc.FinallyPositions,
+			finallyPositions)
 	}
 
 	return nil
@@ -14,6 +14,7 @@ func TestErrorScope(t *testing.T) {
 	for testName, test := range map[string]struct {
 		nodes    []ast.Node
 		expected []vm.Instruction
+		scopes   []*vm.ErrorScope
 		err      error
 	}{
 		"only-empty-try": {
@@ -22,13 +23,12 @@ func TestErrorScope(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Jump{
-					To: 1,
-				},
-
-				&vm.On{
-					Type: "",
+					To: 0,
 				},
 			},
+			scopes: []*vm.ErrorScope{
+				{Start: 0, End: 0},
+			},
 		},
 		"only-try": {
 			nodes: []ast.Node{
@@ -43,13 +43,12 @@ func TestErrorScope(t *testing.T) {
 			expected: []vm.Instruction{
 				&vm.Print{},
 				&vm.Jump{
-					To: 2,
-				},
-
-				&vm.On{
-					Type: "",
+					To: 1,
 				},
 			},
+			scopes: []*vm.ErrorScope{
+				{Start: 0, End: 1},
+			},
 		},
 		"try-on-1": {
 			nodes: []ast.Node{
@@ -69,18 +68,21 @@ func TestErrorScope(t *testing.T) {
 			expected: []vm.Instruction{
 				&vm.Print{},
 				&vm.Jump{
-					To: 4,
+					To: 2,
 				},
 
-				&vm.On{
-					Type: "SomeError",
-				},
+				// on SomeError
 				&vm.Jump{
-					To: 4,
+					To: 2,
 				},
-
-				&vm.On{
-					Type: "",
+			},
+			scopes: []*vm.ErrorScope{
+				{
+					Start: 0,
+					End:   1,
+					On: []*vm.On{
+						{Type: "SomeError", Err: 1, To: 2},
+					},
 				},
 			},
 		},
@@ -110,26 +112,89 @@ func TestErrorScope(t *testing.T) {
 			expected: []vm.Instruction{
 				&vm.Print{},
 				&vm.Jump{
-					To: 7,
+					To: 4,
 				},
 
-				&vm.On{
-					Type: "SomeError",
-				},
+				// on SomeError
 				&vm.Jump{
-					To: 7,
+					To: 4,
 				},
 
-				&vm.On{
-					Type: "SomethingElse",
+				// on SomethingElse
+				&vm.Print{},
+				&vm.Jump{
+					To: 4,
+				},
+			},
+			scopes: []*vm.ErrorScope{
+				{
+					Start: 0,
+					End:   1,
+					On: []*vm.On{
+						{Type: "SomeError", Err: 1, To: 2},
+						{Type: "SomethingElse", Err: 1, To: 3},
+					},
+				},
+			},
+		},
+		"nested-try": {
+			nodes: []ast.Node{
+				&ast.ErrorScope{
+					Statements: []ast.Node{
+						&ast.ErrorScope{
+							Statements: []ast.Node{
+								&ast.Call{
+									FunctionName: "print",
+								},
+							},
+							On: []*ast.On{
+								{
+									Type: "SomeError",
+								},
+							},
+						},
+					},
+					On: []*ast.On{
+						{
+							Type: "SomethingElse",
+						},
+					},
 				},
+			},
+			expected: []vm.Instruction{
 				&vm.Print{},
 				&vm.Jump{
-					To: 7,
+					To: 2,
+				},
+
+				// inner on SomeError
+				&vm.Jump{
+					To: 2,
 				},
 
-				&vm.On{
-					Type: "",
+				&vm.Jump{
+					To: 4,
+				},
+
+				// outer on SomethingElse
+				&vm.Jump{
+					To: 4,
+				},
+			},
+			scopes: []*vm.ErrorScope{
+				{
+					Start: 0,
+					End:   1,
+					On: []*vm.On{
+						{Type: "SomeError", Err: 1, To: 2},
+					},
+				},
+				{
+					Start: 0,
+					End:   3,
+					On: []*vm.On{
+						{Type: "SomethingElse", Err: 1, To: 4},
+					},
 				},
 			},
 		},
@@ -159,11 +224,7 @@ func TestErrorScope(t *testing.T) {
 
 				&vm.Print{},
 				&vm.Jump{
-					To: 3,
-				},
-
-				&vm.On{
-					Type: "",
+					To: 2,
 				},
 
 				// If we enter the finally block we need to disable it, this
@@ -174,6 +235,9 @@ func TestErrorScope(t *testing.T) {
 				},
 				&vm.Print{},
 			},
+			scopes: []*vm.ErrorScope{
+				{Start: 1, End: 2},
+			},
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
@@ -184,6 +248,7 @@ func TestErrorScope(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.Equal(t, test.expected, compiledFunc.Instructions)
+				assert.Equal(t, test.scopes, compiledFunc.ErrorScopes)
 			}
 		})
 	}
@@ -1,12 +1,19 @@
 package compiler
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/vm"
 )
 
+// CompileExpr appends the instructions for a single expression to an existing
+// function. It returns the registers that will contain the results and their
+// types.
+func CompileExpr(compiledFunc *vm.CompiledFunc, expr ast.Node, file *Compiled) ([]vm.Register, []string, error) {
+	return compileExpr(compiledFunc, expr, file)
+}
+
 // compileExpr return the (result register, result type, error)
 func compileExpr(compiledFunc *vm.CompiledFunc, expr ast.Node, file *Compiled) ([]vm.Register, []string, error) {
 	switch e := expr.(type) {
@@ -25,6 +32,12 @@ func compileExpr(compiledFunc *vm.CompiledFunc, expr ast.Node, file *Compiled) (
 		return []vm.Register{returns}, []string{e.Kind}, nil
 
 	case *ast.Func:
+		// Function literals in an imported package must not clash with those
+		// in other packages.
+		if !strings.HasPrefix(e.Name, file.prefix) {
+			e.Name = file.prefix + e.Name
+		}
+
 		cf, err := CompileFunc(e, file)
 		if err != nil {
 			return nil, nil, err
@@ -75,14 +88,15 @@ func compileExpr(compiledFunc *vm.CompiledFunc, expr ast.Node, file *Compiled) (
 		return results, resultKinds, nil
 
 	case *ast.Identifier:
-		// TODO(elliot): Doesn't check that the upper scope variable exists or
-		//  fetches the correct type.
+		// TODO(elliot): Doesn't check that the upper scope variable exists.
 		if e.Name[0] == '^' {
-			return []vm.Register{vm.Register(e.Name)}, []string{"number"}, nil
+			return []vm.Register{compiledFunc.VariableRegister(e.Name)},
+				[]string{parentVariableType(file, e.Name[1:])}, nil
 		}
 
-		if v, ok := compiledFunc.Variables[e.Name]; ok || e.Name[0] == '^' {
-			return []vm.Register{vm.Register(e.Name)}, []string{v}, nil
+		if v, ok := compiledFunc.Variables[e.Name]; ok {
+			return []vm.Register{compiledFunc.VariableRegister(e.Name)},
+				[]string{v}, nil
 		}
 
 		// It could be a package-level constant.
@@ -106,7 +120,7 @@ func compileExpr(compiledFunc *vm.CompiledFunc, expr ast.Node, file *Compiled) (
 		}
 
 		// It could also reference a package-level function.
-		if fn, ok := file.FuncDefs[e.Name]; ok {
+		if fn, _ := file.funcDef(e.Name); fn != nil {
 			literalRegister := compiledFunc.NextRegister()
 			compiledFunc.Append(&vm.Assign{
 				VariableName: literalRegister,
@@ -119,8 +133,8 @@ func compileExpr(compiledFunc *vm.CompiledFunc, expr ast.Node, file *Compiled) (
 			return []vm.Register{literalRegister}, []string{fn.Type()}, nil
 		}
 
-		return nil, nil, fmt.Errorf("%s undefined variable: %s",
-			e.Pos, e.Name)
+		return nil, nil, newDiagnostic(e.Pos, CodeUndefinedVariable,
+			"undefined variable: %s", e.Name)
 
 	case *ast.Binary:
 		result, ty, err := compileBinary(compiledFunc, e, file)
@@ -35,26 +35,26 @@ func TestExpr(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("4"),
 				},
 				&vm.Add{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Multiply{
-					Left:   "3",
-					Right:  "4",
-					Result: "5",
+					Left:   3,
+					Right:  4,
+					Result: 5,
 				},
 			},
 		},
@@ -74,26 +74,26 @@ func TestExpr(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("4"),
 				},
 				&vm.Add{
-					Left:   "2",
-					Right:  "3",
-					Result: "4",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.Subtract{
-					Left:   "1",
-					Right:  "4",
-					Result: "5",
+					Left:   1,
+					Right:  4,
+					Result: 5,
 				},
 			},
 		},
@@ -113,17 +113,17 @@ func TestExpr(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Add{
-					Left:   "1",
-					Right:  "2",
-					Result: "3",
+					Left:   1,
+					Right:  2,
+					Result: 3,
 				},
 			},
 		},
@@ -137,7 +137,7 @@ func TestExpr(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 			},
@@ -146,7 +146,7 @@ func TestExpr(t *testing.T) {
 			nodes: []ast.Node{
 				&ast.Identifier{Name: "foo"},
 			},
-			err: errors.New(" undefined variable: foo"),
+			err: errors.New("undefined variable: foo"),
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
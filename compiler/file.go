@@ -1,8 +1,11 @@
 package compiler
 
 import (
+	"sort"
+
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/util"
 	"github.com/elliotchance/ok/vm"
 )
 
@@ -11,22 +14,31 @@ type Compiled struct {
 	Funcs      map[string]*vm.CompiledFunc
 	FuncDefs   map[string]*ast.Func
 	Tests      []*vm.CompiledTest
+	Benches    []*vm.CompiledBench
 	Interfaces map[string]map[string]string
 	Constants  map[string]*ast.Literal
+
+	// prefix is the package name, followed by a ".", when compiling an
+	// imported package. See qualify.
+	prefix string
+
+	// scope is the function being compiled.
+	scope *funcScope
 }
 
 // CompileFile translates a single file into a set of instructions. The number
 // of instructions returned may be zero.
-func CompileFile(f *parser.File, interfaces map[string]map[string]string, constants map[string]*ast.Literal) (*Compiled, error) {
-	return compile(f.Funcs, f.Tests, interfaces, constants)
+func CompileFile(f *parser.File, interfaces map[string]map[string]string, constants map[string]*ast.Literal) (*Compiled, []error) {
+	return compile(f.Funcs, f.Tests, f.Benches, interfaces, constants)
 }
 
 func compile(
 	funcs map[string]*ast.Func,
 	tests []*ast.Test,
+	benches []*ast.Bench,
 	interfaces map[string]map[string]string,
 	constants map[string]*ast.Literal,
-) (*Compiled, error) {
+) (*Compiled, []error) {
 	file := &Compiled{
 		Funcs:      map[string]*vm.CompiledFunc{},
 		FuncDefs:   funcs,
@@ -34,10 +46,33 @@ func compile(
 		Constants:  constants,
 	}
 
-	for name, fn := range funcs {
-		compiledFn, err := CompileFunc(fn, file)
+	if errs := compileFuncs(file, funcs, tests, benches); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return file, nil
+}
+
+// compileFuncs adds the compiled funcs, tests and benchmarks to file. Any function that is
+// called must already be in file.FuncDefs.
+//
+// Compilation continues after a function or test fails so that all of the
+// errors can be reported at once. The errors are ordered by their position.
+func compileFuncs(file *Compiled, funcs map[string]*ast.Func, tests []*ast.Test, benches []*ast.Bench) []error {
+	// Function literals are added to funcs as they are compiled, so we only
+	// compile the functions that were there at the start.
+	var names []string
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		compiledFn, err := CompileFunc(funcs[name], file)
 		if err != nil {
-			return nil, err
+			errs = appendErrors(errs, err)
+			continue
 		}
 
 		file.Funcs[name] = compiledFn
@@ -46,11 +81,24 @@ func compile(
 	for _, fn := range tests {
 		compiledFn, err := CompileTest(fn, file)
 		if err != nil {
-			return nil, err
+			errs = appendErrors(errs, err)
+			continue
 		}
 
 		file.Tests = append(file.Tests, compiledFn)
 	}
 
-	return file, nil
+	for _, fn := range benches {
+		compiledFn, err := CompileBench(fn, file)
+		if err != nil {
+			errs = appendErrors(errs, err)
+			continue
+		}
+
+		file.Benches = append(file.Benches, compiledFn)
+	}
+
+	util.SortErrors(errs)
+
+	return errs
 }
@@ -31,6 +31,7 @@ func TestCompileFile(t *testing.T) {
 						Statements: []ast.Node{
 							&ast.Call{
 								FunctionName: "print",
+								Pos:          "a.ok:2:5",
 							},
 						},
 					},
@@ -43,6 +44,7 @@ func TestCompileFile(t *testing.T) {
 							&vm.Print{},
 						},
 						Variables: map[string]string{},
+						Positions: []string{"a.ok:2:5"},
 					},
 				},
 			},
@@ -72,6 +74,7 @@ func TestCompileFile(t *testing.T) {
 						},
 						Variables: map[string]string{},
 						Registers: 0,
+						Positions: []string{""},
 					},
 					"add": {
 						Variables: map[string]string{},
@@ -114,26 +117,31 @@ func TestCompileFile(t *testing.T) {
 						Registers: 1,
 						Instructions: []vm.Instruction{
 							&vm.Assign{
-								VariableName: "1",
+								VariableName: 1,
 								Value:        asttest.NewLiteralNumber("123"),
 							},
 							&vm.Call{
 								FunctionName: "add",
-								Arguments:    []vm.Register{"1"},
+								Arguments:    []vm.Register{1},
 							},
 						},
+						Positions: []string{"", ""},
 					},
 					"add": {
 						Arguments: []string{"x"},
 						Variables: map[string]string{
 							"x": "number",
 						},
+						Locals: map[string]vm.Register{
+							"x": 1,
+						},
 						Registers: 1,
 						Instructions: []vm.Instruction{
 							&vm.Print{
-								Arguments: []vm.Register{"x"},
+								Arguments: []vm.Register{1},
 							},
 						},
+						Positions: []string{""},
 					},
 				},
 			},
@@ -171,6 +179,7 @@ func TestCompileFile(t *testing.T) {
 							&vm.Print{},
 						},
 						Variables: map[string]string{},
+						Positions: []string{""},
 					},
 				},
 				Tests: []*vm.CompiledTest{
@@ -178,27 +187,29 @@ func TestCompileFile(t *testing.T) {
 						CompiledFunc: &vm.CompiledFunc{
 							Instructions: []vm.Instruction{
 								&vm.Assign{
-									VariableName: "1",
+									VariableName: 1,
 									Value:        asttest.NewLiteralNumber("1"),
 								},
 								&vm.Assign{
-									VariableName: "2",
+									VariableName: 2,
 									Value:        asttest.NewLiteralNumber("2"),
 								},
 								&vm.LessThanNumber{
-									Left:   "1",
-									Right:  "2",
-									Result: "3",
+									Left:   1,
+									Right:  2,
+									Result: 3,
 								},
 								&vm.Assert{
-									Left:  "1",
-									Op:    "<",
-									Right: "2",
-									Final: "3",
+									Left:    1,
+									Op:      "<",
+									Right:   2,
+									Final:   3,
+									Message: vm.NoRegister,
 								},
 							},
 							Variables: map[string]string{},
 							Registers: 3,
+							Positions: []string{"", "", "", ""},
 						},
 						TestName: "test foo",
 					},
@@ -234,17 +245,21 @@ func TestCompileFile(t *testing.T) {
 						Instructions: []vm.Instruction{
 							&vm.Call{
 								FunctionName: "Person",
-								Results:      []vm.Register{"1"},
+								Results:      []vm.Register{1},
 							},
 							&vm.Assign{
-								VariableName: "p",
-								Register:     "1",
+								VariableName: 2,
+								Register:     1,
 							},
 						},
 						Variables: map[string]string{
 							"p": "Person",
 						},
-						Registers: 1,
+						Locals: map[string]vm.Register{
+							"p": 2,
+						},
+						Registers: 2,
+						Positions: []string{"", ""},
 					},
 					"Person": {
 						Variables: map[string]string{},
@@ -254,14 +269,15 @@ func TestCompileFile(t *testing.T) {
 								Results: []vm.Register{vm.StateRegister},
 							},
 						},
+						Positions: []string{""},
 					},
 				},
 			},
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			compiledFile, err := compiler.CompileFile(test.f, nil, nil)
-			require.NoError(t, err)
+			compiledFile, errs := compiler.CompileFile(test.f, nil, nil)
+			require.Nil(t, errs)
 
 			// It is not worth testing these because the Statements make it very
 			// verbose.
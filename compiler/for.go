@@ -1,8 +1,6 @@
 package compiler
 
 import (
-	"fmt"
-
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler/kind"
@@ -19,6 +17,10 @@ func compileFor(compiledFunc *vm.CompiledFunc, n *ast.For, file *Compiled) error
 		}
 	}
 
+	// The position to jump back to so that the condition is evaluated again.
+	// "-1" is to offset the +1 that will always occur after an instruction.
+	var conditionPosition int
+
 	var conditionResults []vm.Register
 	switch cond := n.Condition.(type) {
 	case nil:
@@ -38,21 +40,31 @@ func compileFor(compiledFunc *vm.CompiledFunc, n *ast.For, file *Compiled) error
 			// Allowed
 
 		default:
-			return fmt.Errorf("%s is not iterable", arrayOrMapKind[0])
+			return newDiagnostic(cond.Position(), CodeInvalidOperation,
+				"%s is not iterable", arrayOrMapKind[0])
 		}
 
-		compiledFunc.NewVariable(cond.Value, kind.ElementType(arrayOrMapKind[0]))
+		valueRegister := vm.NoRegister
+		if cond.Value != "" {
+			valueKind := "char"
+			if arrayOrMapKind[0] != "string" {
+				valueKind = kind.ElementType(arrayOrMapKind[0])
+			}
 
+			valueRegister = compiledFunc.NewVariable(cond.Value, valueKind)
+		}
+
+		keyRegister := vm.NoRegister
 		if cond.Key != "" {
 			switch {
 			case kind.IsArray(arrayOrMapKind[0]):
-				compiledFunc.NewVariable(cond.Key, "number")
+				keyRegister = compiledFunc.NewVariable(cond.Key, "number")
 
 			case kind.IsMap(arrayOrMapKind[0]):
-				compiledFunc.NewVariable(cond.Key, "string")
+				keyRegister = compiledFunc.NewVariable(cond.Key, "string")
 
 			case arrayOrMapKind[0] == "string":
-				compiledFunc.NewVariable(cond.Key, "char")
+				keyRegister = compiledFunc.NewVariable(cond.Key, "number")
 			}
 		}
 
@@ -68,8 +80,8 @@ func compileFor(compiledFunc *vm.CompiledFunc, n *ast.For, file *Compiled) error
 			compiledFunc.Append(&vm.NextArray{
 				Array:       arrayOrMapResults[0],
 				Cursor:      cursorRegister,
-				KeyResult:   vm.Register(cond.Key),
-				ValueResult: vm.Register(cond.Value),
+				KeyResult:   keyRegister,
+				ValueResult: valueRegister,
 				Result:      conditionResults[0],
 			})
 
@@ -77,8 +89,8 @@ func compileFor(compiledFunc *vm.CompiledFunc, n *ast.For, file *Compiled) error
 			compiledFunc.Append(&vm.NextMap{
 				Map:         arrayOrMapResults[0],
 				Cursor:      cursorRegister,
-				KeyResult:   vm.Register(cond.Key),
-				ValueResult: vm.Register(cond.Value),
+				KeyResult:   keyRegister,
+				ValueResult: valueRegister,
 				Result:      conditionResults[0],
 			})
 
@@ -86,13 +98,17 @@ func compileFor(compiledFunc *vm.CompiledFunc, n *ast.For, file *Compiled) error
 			compiledFunc.Append(&vm.NextString{
 				Str:         arrayOrMapResults[0],
 				Cursor:      cursorRegister,
-				KeyResult:   vm.Register(cond.Key),
-				ValueResult: vm.Register(cond.Value),
+				KeyResult:   keyRegister,
+				ValueResult: valueRegister,
 				Result:      conditionResults[0],
 			})
 		}
 
 	default:
+		// The condition may need several instructions, such as when it calls
+		// a function, so all of them must be run each iteration.
+		conditionPosition = len(compiledFunc.Instructions) - 1
+
 		var conditionKinds []string
 		var err error
 		conditionResults, conditionKinds, err = compileExpr(compiledFunc, n.Condition, file)
@@ -101,15 +117,18 @@ func compileFor(compiledFunc *vm.CompiledFunc, n *ast.For, file *Compiled) error
 		}
 
 		if conditionKinds[0] != "bool" {
-			return fmt.Errorf(
+			return newDiagnostic(n.Condition.Position(), CodeTypeMismatch,
 				"expression in for condition must be a bool, got %s",
 				conditionKinds[0])
 		}
 	}
 
-	// "-2" because we need to jump before the previous instruction + we
-	// need to offset the +1 that will always occur after an instruction.
-	conditionPosition := len(compiledFunc.Instructions) - 2
+	// Otherwise, the condition is the previous instruction. "-2" because we
+	// need to jump before the previous instruction + we need to offset the +1
+	// that will always occur after an instruction.
+	if _, ok := n.Condition.(*ast.In); ok || n.Condition == nil {
+		conditionPosition = len(compiledFunc.Instructions) - 2
+	}
 
 	ins := &vm.JumpUnless{
 		Condition: conditionResults[0],
@@ -38,63 +38,63 @@ func TestFor(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc array
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 
 				// set 2 elements
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "3",
-					Value: "4",
+					Array: 2,
+					Index: 3,
+					Value: 4,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("2.3"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "5",
-					Value: "6",
+					Array: 2,
+					Index: 5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// for in
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 9,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.NextArray{
-					Array:       "foo",
-					Cursor:      "7",
-					KeyResult:   "k",
-					ValueResult: "",
-					Result:      "8",
+					Array:       7,
+					Cursor:      9,
+					KeyResult:   8,
+					ValueResult: -1,
+					Result:      10,
 				},
 				&vm.JumpUnless{
-					Condition: "8",
+					Condition: 10,
 					To:        12,
 				},
 				&vm.Jump{
@@ -123,63 +123,63 @@ func TestFor(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc array
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 
 				// set 2 elements
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "3",
-					Value: "4",
+					Array: 2,
+					Index: 3,
+					Value: 4,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("2.3"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "5",
-					Value: "6",
+					Array: 2,
+					Index: 5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// for in
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 10,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.NextArray{
-					Array:       "foo",
-					Cursor:      "7",
-					KeyResult:   "k",
-					ValueResult: "v",
-					Result:      "8",
+					Array:       7,
+					Cursor:      10,
+					KeyResult:   9,
+					ValueResult: 8,
+					Result:      11,
 				},
 				&vm.JumpUnless{
-					Condition: "8",
+					Condition: 11,
 					To:        12,
 				},
 				&vm.Jump{
@@ -207,63 +207,63 @@ func TestFor(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc array
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// set 2 elements
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("2.3"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "5",
-					Value: "6",
+					Map:   2,
+					Key:   5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// for in
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 9,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.NextMap{
-					Map:         "foo",
-					Cursor:      "7",
-					KeyResult:   "k",
-					ValueResult: "",
-					Result:      "8",
+					Map:         7,
+					Cursor:      9,
+					KeyResult:   8,
+					ValueResult: -1,
+					Result:      10,
 				},
 				&vm.JumpUnless{
-					Condition: "8",
+					Condition: 10,
 					To:        12,
 				},
 				&vm.Jump{
@@ -292,63 +292,63 @@ func TestFor(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc array
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// set 2 elements
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("2.3"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("1.5"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "5",
-					Value: "6",
+					Map:   2,
+					Key:   5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// for in
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 10,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.NextMap{
-					Map:         "foo",
-					Cursor:      "7",
-					KeyResult:   "k",
-					ValueResult: "v",
-					Result:      "8",
+					Map:         7,
+					Cursor:      10,
+					KeyResult:   9,
+					ValueResult: 8,
+					Result:      11,
 				},
 				&vm.JumpUnless{
-					Condition: "8",
+					Condition: 11,
 					To:        12,
 				},
 				&vm.Jump{
@@ -389,46 +389,46 @@ func TestFor(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// a < 10
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("10"),
 				},
 				&vm.LessThanNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        8,
 				},
 
 				// print(a)
 				&vm.Print{
-					Arguments: []vm.Register{"a"},
+					Arguments: []vm.Register{2},
 				},
 
 				// ++a
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Add{
-					Left:   "a",
-					Right:  "4",
-					Result: "a",
+					Left:   2,
+					Right:  5,
+					Result: 2,
 				},
 				&vm.Jump{
-					To: 2,
+					To: 1,
 				},
 			},
 		},
@@ -460,26 +460,26 @@ func TestFor(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// a < 10
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("10"),
 				},
 				&vm.LessThanNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        8,
 				},
 
@@ -490,16 +490,16 @@ func TestFor(t *testing.T) {
 
 				// ++a
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Add{
-					Left:   "a",
-					Right:  "4",
-					Result: "a",
+					Left:   2,
+					Right:  5,
+					Result: 2,
 				},
 				&vm.Jump{
-					To: 2,
+					To: 1,
 				},
 			},
 		},
@@ -509,11 +509,11 @@ func TestFor(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.JumpUnless{
-					Condition: "1",
+					Condition: 1,
 					To:        2,
 				},
 				&vm.Jump{
@@ -562,47 +562,47 @@ func TestFor(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.JumpUnless{
-					Condition: "2",
+					Condition: 3,
 					To:        8,
 				},
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Assign{
-					VariableName: "bar",
-					Register:     "3",
+					VariableName: 5,
+					Register:     4,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Assign{
-					VariableName: "baz",
-					Register:     "4",
+					VariableName: 7,
+					Register:     6,
 				},
 				&vm.Jump{
 					To: 1,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 8,
 					Value:        asttest.NewLiteralNumber("4"),
 				},
 				&vm.Assign{
-					VariableName: "qux",
-					Register:     "5",
+					VariableName: 9,
+					Register:     8,
 				},
 			},
 		},
@@ -640,41 +640,41 @@ func TestFor(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("10"),
 				},
 				&vm.LessThanNumber{
-					Left:   "i",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        8,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Add{
-					Left:   "i",
-					Right:  "4",
-					Result: "5",
+					Left:   2,
+					Right:  5,
+					Result: 6,
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "5",
+					VariableName: 2,
+					Register:     6,
 				},
 				&vm.Jump{
-					To: 2,
+					To: 1,
 				},
 			},
 		},
@@ -720,50 +720,50 @@ func TestFor(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.JumpUnless{
-					Condition: "2",
+					Condition: 3,
 					To:        9,
 				},
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "3",
+					VariableName: 2,
+					Register:     4,
 				},
 				&vm.Jump{
 					To: 9,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "4",
+					VariableName: 2,
+					Register:     5,
 				},
 				&vm.Jump{
 					To: 1,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "5",
+					VariableName: 2,
+					Register:     6,
 				},
 			},
 		},
@@ -809,50 +809,50 @@ func TestFor(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.JumpUnless{
-					Condition: "2",
+					Condition: 3,
 					To:        9,
 				},
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "3",
+					VariableName: 2,
+					Register:     4,
 				},
 				&vm.Jump{
 					To: 1,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "4",
+					VariableName: 2,
+					Register:     5,
 				},
 				&vm.Jump{
 					To: 1,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "5",
+					VariableName: 2,
+					Register:     6,
 				},
 			},
 		},
@@ -13,17 +13,15 @@ func CompileFunc(fn *ast.Func, file *Compiled) (*vm.CompiledFunc, error) {
 		Interfaces: file.Interfaces,
 	}
 
-	// All variables in a function are stored internally as a map right now. So
-	// the first thing we need to do is initialise the map. Whether we return it
-	// at the end will depend on the return type.
-	//
-	// It is important that we always use a map (even if its not returns)
-	// because we may need it for a subscope (closure).
 	isObject := len(fn.Returns) == 1 && fn.Returns[0] == fn.Name
 
-	// Load the arguments from the registers.
+	file.scope = &funcScope{fn: fn, compiled: compiled, parent: file.scope}
+	defer func() {
+		file.scope = file.scope.parent
+	}()
+
+	// The arguments will be placed into the first registers.
 	for _, arg := range fn.Arguments {
-		compiled.NextRegister()
 		compiled.NewVariable(arg.Name, arg.Type)
 
 		compiled.Arguments = append(compiled.Arguments, arg.Name)
@@ -41,5 +39,22 @@ func CompileFunc(fn *ast.Func, file *Compiled) (*vm.CompiledFunc, error) {
 		})
 	}
 
+	// The variables of an object are its properties, and a function literal
+	// can access any variable through "^". In either case the variables must
+	// be kept in the state rather than in registers.
+	if isObject || hasFuncLiteral(compiled) {
+		compiled.ShareVariables()
+	}
+
 	return compiled, nil
 }
+
+func hasFuncLiteral(compiled *vm.CompiledFunc) bool {
+	for _, ins := range compiled.Instructions {
+		if _, ok := ins.(*vm.ParentScope); ok {
+			return true
+		}
+	}
+
+	return false
+}
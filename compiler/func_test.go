@@ -49,11 +49,11 @@ func TestFunc(t *testing.T) {
 			expected: []vm.Instruction{
 				&vm.Print{},
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("hello"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"1"},
+					Arguments: []vm.Register{1},
 				},
 			},
 		},
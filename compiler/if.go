@@ -1,8 +1,6 @@
 package compiler
 
 import (
-	"fmt"
-
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/vm"
 )
@@ -14,7 +12,7 @@ func compileIf(compiledFunc *vm.CompiledFunc, n *ast.If, breakIns, continueIns v
 	}
 
 	if conditionKinds[0] != "bool" {
-		return fmt.Errorf(
+		return newDiagnostic(n.Condition.Position(), CodeTypeMismatch,
 			"expression in if condition must be a bool, got %s",
 			conditionKinds[0])
 	}
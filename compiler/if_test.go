@@ -38,24 +38,24 @@ func TestIf(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        4,
 				},
 			},
@@ -94,42 +94,42 @@ func TestIf(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        8,
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "4",
+					VariableName: 2,
+					Register:     5,
 				},
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Add{
-					Left:   "a",
-					Right:  "5",
-					Result: "a",
+					Left:   2,
+					Right:  6,
+					Result: 2,
 				},
 			},
 		},
@@ -170,37 +170,37 @@ func TestIf(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// if a == 3
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        7,
 				},
 
 				// a = 1
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "4",
+					VariableName: 2,
+					Register:     5,
 				},
 				&vm.Jump{
 					To: 9,
@@ -208,13 +208,13 @@ func TestIf(t *testing.T) {
 
 				// ++a
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Add{
-					Left:   "a",
-					Right:  "5",
-					Result: "a",
+					Left:   2,
+					Right:  6,
+					Result: 2,
 				},
 			},
 		},
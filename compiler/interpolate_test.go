@@ -33,29 +33,29 @@ func TestInterpolate(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("hi "),
 				},
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Add{
-					Left:   "3",
-					Right:  "4",
-					Result: "5",
+					Left:   3,
+					Right:  4,
+					Result: 5,
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralString(" there"),
 				},
 				&vm.Interpolate{
-					Result: "1",
-					Args:   []vm.Register{"2", "5", "6"},
+					Result: 1,
+					Args:   []vm.Register{2, 5, 6},
 				},
 			},
 		},
@@ -35,13 +35,13 @@ func compileKey(compiledFunc *vm.CompiledFunc, n *ast.Key, file *Compiled) (vm.R
 
 	arrayOrMapRegisters, arrayOrMapKind, err := compileExpr(compiledFunc, n.Expr, file)
 	if err != nil {
-		return "", "", err
+		return vm.NoRegister, "", err
 	}
 
 	// TODO(elliot): Check key is the correct type.
 	keyRegisters, _, err := compileExpr(compiledFunc, n.Key, file)
 	if err != nil {
-		return "", "", err
+		return vm.NoRegister, "", err
 	}
 
 	resultRegister := compiledFunc.NextRegister()
@@ -83,7 +83,8 @@ func compileKey(compiledFunc *vm.CompiledFunc, n *ast.Key, file *Compiled) (vm.R
 			return resultRegister, ty, nil
 		}
 
-		return "", "", fmt.Errorf("unknown type: %s", arrayOrMapKind[0])
+		return vm.NoRegister, "", newDiagnostic(n.Position(),
+			CodeInvalidOperation, "unknown type: %s", arrayOrMapKind[0])
 
 	case arrayOrMapKind[0] == "string":
 		compiledFunc.Append(&vm.StringIndex{
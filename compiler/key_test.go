@@ -40,60 +40,60 @@ func TestKey(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 
 				// set 0
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "3",
-					Value: "4",
+					Array: 2,
+					Index: 3,
+					Value: 4,
 				},
 
 				// set 1
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("456"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "5",
-					Value: "6",
+					Array: 2,
+					Index: 5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// get 1
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.ArrayGet{
-					Array:  "foo",
-					Index:  "7",
-					Result: "8",
+					Array:  7,
+					Index:  8,
+					Result: 9,
 				},
 			},
 		},
@@ -126,60 +126,60 @@ func TestKey(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// "a": 123
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("a"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 
 				// "b": 456
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("456"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "5",
-					Value: "6",
+					Map:   2,
+					Key:   5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// get "b"
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.MapGet{
-					Map:    "foo",
-					Key:    "7",
-					Result: "8",
+					Map:    7,
+					Key:    8,
+					Result: 9,
 				},
 			},
 		},
@@ -214,60 +214,60 @@ func TestKey(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// "a": 123
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("a"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 
 				// "b": 456
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("456"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "5",
-					Value: "6",
+					Map:   2,
+					Key:   5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// get "b"
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.MapGet{
-					Map:    "foo",
-					Key:    "7",
-					Result: "8",
+					Map:    7,
+					Key:    8,
+					Result: 9,
 				},
 			},
 		},
@@ -301,64 +301,64 @@ func TestKey(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.ArrayAlloc{
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 					Kind:   "[]number",
 				},
 
 				// set 0
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "3",
-					Value: "4",
+					Array: 2,
+					Index: 3,
+					Value: 4,
 				},
 
 				// set 1
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("456"),
 				},
 				&vm.ArraySet{
-					Array: "2",
-					Index: "5",
-					Value: "6",
+					Array: 2,
+					Index: 5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// foo[1] = 2
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 9,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.ArraySet{
-					Array: "foo",
-					Index: "8",
-					Value: "7",
+					Array: 7,
+					Index: 9,
+					Value: 8,
 				},
 			},
 		},
@@ -398,64 +398,64 @@ func TestKey(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// "a": 123
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("a"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 
 				// "b": 456
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("456"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "5",
-					Value: "6",
+					Map:   2,
+					Key:   5,
+					Value: 6,
 				},
 
 				// assign foo
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "2",
+					VariableName: 7,
+					Register:     2,
 				},
 
 				// foo["b"] = 2
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 9,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.MapSet{
-					Map:   "foo",
-					Key:   "8",
-					Value: "7",
+					Map:   7,
+					Key:   9,
+					Value: 8,
 				},
 			},
 		},
@@ -476,23 +476,23 @@ func TestKey(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralString("bar"),
 				},
 				&vm.Assign{
-					VariableName: "foo",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// foo[1]
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.StringIndex{
-					Str:    "foo",
-					Index:  "2",
-					Result: "3",
+					Str:    2,
+					Index:  3,
+					Result: 4,
 				},
 			},
 		},
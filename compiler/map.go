@@ -26,7 +26,7 @@ func compileMap(
 	mapAlloc := &vm.MapAlloc{
 		Size:   sizeRegister,
 		Result: mapRegister,
-		Kind:   n.Kind,
+		Kind:   file.qualifiedType(n.Kind),
 	}
 	compiledFunc.Append(mapAlloc)
 
@@ -34,7 +34,7 @@ func compileMap(
 		// TODO(elliot): Check keyKind is string.
 		keyRegisters, _, err := compileExpr(compiledFunc, element.Key, file)
 		if err != nil {
-			return "", "", err
+			return vm.NoRegister, "", err
 		}
 
 		// TODO(elliot): Check value is the right type for map.
@@ -24,13 +24,13 @@ func TestMap(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 			},
 		},
@@ -54,58 +54,58 @@ func TestMap(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// "a": 2
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("a"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 
 				// "b": 5
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.Assign{
-					VariableName: "6",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("5"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "5",
-					Value: "6",
+					Map:   2,
+					Key:   5,
+					Value: 6,
 				},
 
 				// "c": 13
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 7,
 					Value:        asttest.NewLiteralString("c"),
 				},
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 8,
 					Value:        asttest.NewLiteralNumber("13"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "7",
-					Value: "8",
+					Map:   2,
+					Key:   7,
+					Value: 8,
 				},
 			},
 		},
@@ -128,34 +128,34 @@ func TestMap(t *testing.T) {
 			expected: []vm.Instruction{
 				// alloc
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.MapAlloc{
 					Kind:   "{}number",
-					Size:   "1",
-					Result: "2",
+					Size:   1,
+					Result: 2,
 				},
 
 				// "b": 123
 				&vm.Assign{
-					VariableName: "3",
+					VariableName: 3,
 					Value:        asttest.NewLiteralString("b"),
 				},
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 4,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.MapSet{
-					Map:   "2",
-					Key:   "3",
-					Value: "4",
+					Map:   2,
+					Key:   3,
+					Value: 4,
 				},
 
 				// assign a
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "2",
+					VariableName: 5,
+					Register:     2,
 				},
 			},
 		},
@@ -47,12 +47,12 @@ func TestObject(t *testing.T) {
 			expected: []vm.Instruction{
 				// bar = 123
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.Assign{
-					VariableName: "bar",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// return instance
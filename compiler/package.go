@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"path"
 	"path/filepath"
+	"sort"
 
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/parser"
@@ -11,81 +12,339 @@ import (
 	"github.com/elliotchance/ok/vm"
 )
 
+// CompilePackage compiles all of the files in dir. Imported packages are
+// compiled separately and cached so that they only need to be compiled again
+// when they, or one of their own imports, change.
 func CompilePackage(dir string, includeTests bool) (*Compiled, []error) {
-	// Step 1: Find all the files that need to be compiled.
+	return newImporter().compileDir(dir, "", includeTests)
+}
+
+// CompileLibPackage compiles dir in the same way as an imported package. That
+// is, every function and type is prefixed with the package name, such as
+// "strings.Split". This is used to build the standard library. See lib-gen.
+func CompileLibPackage(dir string) (*Compiled, []error) {
+	return newImporter().compileDir(dir, filepath.Base(dir)+".", false)
+}
+
+// CompilePackages is the same as calling CompilePackage for each of the dirs,
+// except that a package imported by more than one of them is only compiled
+// once. The errors from all of the packages are returned.
+func CompilePackages(dirs []string, includeTests bool) ([]*Compiled, []error) {
+	imp := newImporter()
+
+	var packages []*Compiled
+	var allErrs []error
+	for _, dir := range dirs {
+		compiled, errs := imp.compileDir(dir, "", includeTests)
+		packages = append(packages, compiled)
+		allErrs = append(allErrs, errs...)
+	}
+
+	return packages, allErrs
+}
+
+func (imp *importer) compileDir(dir, prefix string, includeTests bool) (*Compiled, []error) {
+	dir = path.Clean(dir)
+
+	// Imports are relative to the package being compiled, so the same name
+	// may refer to a different package this time.
+	imp.rootDir = dir
+	imp.packages = map[string]*importedPackage{}
+
+	// Step 1: Parse all files in the package.
+	pkg, errs := parsePackage(dir, prefix, includeTests)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Step 2: Compile (or load from the cache) each of the imports.
+	deps, errs := imp.importAll(pkg.imports)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Step 3: Compile the package with everything from the imports available.
+	compiled := newCompiled(deps)
+	for _, dep := range allImports(deps) {
+		for name, fn := range dep.program.Funcs {
+			compiled.Funcs[name] = fn
+		}
+	}
+
+	if errs := compilePackage(compiled, pkg, pkg.tests, pkg.benches); len(errs) > 0 {
+		return nil, locate(errs, pkg.sources)
+	}
+
+	return compiled, nil
+}
+
+// parsedPackage contains the combined declarations of all the files in a
+// package.
+type parsedPackage struct {
+	name       string
+	prefix     string
+	fileNames  []string
+	sources    map[string][]byte
+	funcs      map[string]*ast.Func
+	tests      []*ast.Test
+	benches    []*ast.Bench
+	interfaces map[string]map[string]string
+	constants  map[string]*ast.Literal
+
+	// imports does not include builtin packages.
+	imports []string
+}
+
+// parsePackage parses all of the files in dir. Each function and type will be
+// prefixed with prefix. See qualify.
+func parsePackage(dir, prefix string, includeTests bool) (*parsedPackage, []error) {
 	fileNames, err := util.GetAllOKFilesInPath(dir, includeTests)
 	if err != nil {
 		return nil, []error{err}
 	}
 
-	dir = path.Clean(dir)
+	pkg := &parsedPackage{
+		name:       filepath.Base(dir),
+		prefix:     prefix,
+		fileNames:  fileNames,
+		sources:    map[string][]byte{},
+		funcs:      map[string]*ast.Func{},
+		interfaces: map[string]map[string]string{},
+		constants:  map[string]*ast.Literal{},
+	}
 
-	// Step 2: Parse all files and combine.
 	var errs []error
-	funcs := map[string]*ast.Func{}
-	var tests []*ast.Test
-	interfaces := map[string]map[string]string{}
-	constants := map[string]*ast.Literal{}
-
-	for len(fileNames) > 0 {
-		fileName := fileNames[0]
+	imports := map[string]bool{}
+	for _, fileName := range fileNames {
 		data, err := ioutil.ReadFile(fileName)
 		if err != nil {
 			return nil, []error{err}
 		}
 
+		pkg.sources[fileName] = data
+
 		p := parser.ParseString(string(data), fileName)
-		errs = append(errs, p.Errors()...)
+		errs = append(errs, locate(p.Errors(), pkg.sources)...)
 
 		for name, fn := range p.File.Funcs {
 			// TODO(elliot): Check for already defined function.
-			if fileNameDir := path.Clean(path.Dir(fileName)); fileNameDir != dir {
-				pkg := filepath.Base(fileNameDir)
-				funcs[pkg+"."+name] = fn
-			} else {
-				funcs[name] = fn
-			}
+			pkg.funcs[prefix+name] = fn
 		}
 
-		tests = append(tests, p.File.Tests...)
+		pkg.tests = append(pkg.tests, p.File.Tests...)
+		pkg.benches = append(pkg.benches, p.File.Benches...)
 
 		for key, i := range p.Interfaces {
-			interfaces[key] = i
+			pkg.interfaces[key] = i
 		}
 
 		for key, i := range p.Constants {
-			constants[key] = i
+			pkg.constants[key] = i
 		}
 
-		for pkg := range p.File.Imports {
-			// TODO(elliot): Check import location exists.
-
+		for name := range p.File.Imports {
 			// Ignore builtin libraries as they are already provided to the VM
 			// through vm/lib.go. See Makefile.
-			if vm.Packages[pkg] {
-				continue
+			if !vm.Packages[name] {
+				imports[name] = true
 			}
+		}
+	}
 
-			newFileNames, err := util.GetAllOKFilesInPath(path.Join(dir, pkg), false)
-			if err != nil {
-				return nil, []error{err}
-			}
+	for name := range imports {
+		pkg.imports = append(pkg.imports, name)
+	}
+	sort.Strings(pkg.imports)
+
+	if prefix != "" {
+		pkg.qualify()
+	}
+
+	return pkg, errs
+}
+
+// importedPackage is a compiled package that has been imported.
+type importedPackage struct {
+	hash    string
+	program *vm.Program
+	imports map[string]*importedPackage
+}
+
+// importer makes sure that each package is only compiled once, no matter how
+// many times it is imported.
+type importer struct {
+	// rootDir is the directory of the package being compiled. All imports are
+	// relative to this directory.
+	rootDir string
+
+	packages map[string]*importedPackage
 
-			fileNames = append(fileNames, newFileNames...)
+	// loading is used to detect import cycles.
+	loading map[string]bool
+
+	// programs are kept between the packages compiled with CompilePackages.
+	// They are keyed by the hash of the package.
+	programs map[string]*vm.Program
+}
+
+func newImporter() *importer {
+	return &importer{
+		loading:  map[string]bool{},
+		programs: map[string]*vm.Program{},
+	}
+}
+
+func (imp *importer) importAll(names []string) (map[string]*importedPackage, []error) {
+	deps := map[string]*importedPackage{}
+	for _, name := range names {
+		dep, errs := imp.importPackage(name)
+		if len(errs) > 0 {
+			return nil, errs
 		}
 
-		fileNames = fileNames[1:]
+		deps[name] = dep
 	}
 
+	return deps, nil
+}
+
+func (imp *importer) importPackage(name string) (*importedPackage, []error) {
+	if dep, ok := imp.packages[name]; ok {
+		return dep, nil
+	}
+
+	if imp.loading[name] {
+		return nil, []error{newDiagnostic("", CodeImportCycle,
+			"import cycle through package %s", name)}
+	}
+
+	imp.loading[name] = true
+	defer delete(imp.loading, name)
+
+	// TODO(elliot): Check import location exists.
+	dir := path.Join(imp.rootDir, name)
+	pkg, errs := parsePackage(dir, filepath.Base(dir)+".", false)
 	if len(errs) > 0 {
 		return nil, errs
 	}
 
-	// Step 3: Compile everything all at once.
-	compiled, err := compile(funcs, tests, interfaces, constants)
-	if err != nil {
-		return nil, []error{err}
+	deps, errs := imp.importAll(pkg.imports)
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
-	return compiled, nil
+	dep := &importedPackage{
+		hash:    packageHash(pkg, deps),
+		imports: deps,
+	}
+
+	dep.program = imp.programs[dep.hash]
+	if dep.program == nil {
+		dep.program = loadCachedPackage(dep.hash)
+	}
+	if dep.program == nil {
+		compiled := newCompiled(deps)
+		if errs := compilePackage(compiled, pkg, nil, nil); len(errs) > 0 {
+			return nil, locate(errs, pkg.sources)
+		}
+
+		// Only the package's own declarations are kept. Everything else is
+		// provided by the other imported packages.
+		dep.program = &vm.Program{
+			Package:    pkg.name,
+			Funcs:      compiled.Funcs,
+			FuncDefs:   map[string]*ast.Func{},
+			Interfaces: pkg.interfaces,
+			Constants:  pkg.constants,
+		}
+		for name := range compiled.Funcs {
+			dep.program.FuncDefs[name] = compiled.FuncDefs[name]
+		}
+
+		saveCachedPackage(dep.hash, dep.program)
+	}
+
+	imp.programs[dep.hash] = dep.program
+	imp.packages[name] = dep
+
+	return dep, nil
+}
+
+// allImports returns all of the packages that are directly or indirectly
+// imported.
+func allImports(deps map[string]*importedPackage) []*importedPackage {
+	var all []*importedPackage
+	seen := map[*importedPackage]bool{}
+
+	var visit func(deps map[string]*importedPackage)
+	visit = func(deps map[string]*importedPackage) {
+		for _, dep := range deps {
+			if !seen[dep] {
+				seen[dep] = true
+				all = append(all, dep)
+				visit(dep.imports)
+			}
+		}
+	}
+	visit(deps)
+
+	return all
+}
+
+// newCompiled returns an empty package that can use the declarations from the
+// imported packages.
+func newCompiled(deps map[string]*importedPackage) *Compiled {
+	compiled := &Compiled{
+		Funcs:      map[string]*vm.CompiledFunc{},
+		FuncDefs:   map[string]*ast.Func{},
+		Interfaces: map[string]map[string]string{},
+		Constants:  map[string]*ast.Literal{},
+	}
+
+	for _, dep := range allImports(deps) {
+		for name, fn := range dep.program.FuncDefs {
+			compiled.FuncDefs[name] = fn
+		}
+
+		for name, i := range dep.program.Interfaces {
+			compiled.Interfaces[name] = i
+		}
+
+		for name, c := range dep.program.Constants {
+			compiled.Constants[name] = c
+		}
+	}
+
+	return compiled
+}
+
+func compilePackage(compiled *Compiled, pkg *parsedPackage, tests []*ast.Test, benches []*ast.Bench) []error {
+	compiled.prefix = pkg.prefix
+
+	for name, fn := range pkg.funcs {
+		compiled.FuncDefs[name] = fn
+	}
+
+	for name, i := range pkg.interfaces {
+		compiled.Interfaces[name] = i
+	}
+
+	for name, c := range pkg.constants {
+		compiled.Constants[name] = c
+	}
+
+	return compileFuncs(compiled, pkg.funcs, tests, benches)
+}
+
+// locate sets the end position of each diagnostic from the source files.
+func locate(errs []error, sources map[string][]byte) []error {
+	for _, err := range errs {
+		if d, ok := err.(*util.Diagnostic); ok && d.EndLine == 0 {
+			if source, ok := sources[d.File]; ok {
+				d.Locate(string(source))
+			}
+		}
+	}
+
+	return errs
 }
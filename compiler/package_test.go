@@ -0,0 +1,289 @@
+package compiler_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePackages creates the files (relative to the returned directory) and
+// points the cache to a new empty directory.
+func writePackages(t *testing.T, files map[string]string) (string, func()) {
+	dir, err := ioutil.TempDir("", "ok")
+	require.NoError(t, err)
+
+	for fileName, source := range files {
+		writeFile(t, filepath.Join(dir, fileName), source)
+	}
+
+	xdgCacheHome, hadXDGCacheHome := os.LookupEnv("XDG_CACHE_HOME")
+	require.NoError(t, os.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache")))
+
+	return dir, func() {
+		if hadXDGCacheHome {
+			os.Setenv("XDG_CACHE_HOME", xdgCacheHome)
+		} else {
+			os.Unsetenv("XDG_CACHE_HOME")
+		}
+
+		os.RemoveAll(dir)
+	}
+}
+
+func writeFile(t *testing.T, fileName, source string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(source), 0644))
+}
+
+func cachedPackages(t *testing.T) []string {
+	cacheDir, err := os.UserCacheDir()
+	require.NoError(t, err)
+
+	fileNames, err := filepath.Glob(filepath.Join(cacheDir, "ok", "*.okc"))
+	require.NoError(t, err)
+
+	return fileNames
+}
+
+func compilePackage(t *testing.T, dir string) *compiler.Compiled {
+	compiled, errs := compiler.CompilePackage(dir, false)
+	require.Nil(t, errs)
+
+	return compiled
+}
+
+func runPackage(t *testing.T, compiled *compiler.Compiled) string {
+	var buf bytes.Buffer
+	m := vm.NewVM(compiled.Funcs, compiled.Tests, compiled.Interfaces, "main")
+	m.Stdout = &buf
+	require.NoError(t, m.Run())
+
+	return buf.String()
+}
+
+var importedPackages = map[string]string{
+	"main.ok": `import "a"
+func main() {
+    print(a.Double(3))
+}`,
+	"a/a.ok": `import "b"
+func Double(n number) number {
+    return b.Add(n, n)
+}`,
+	"b/b.ok": `func Add(x, y number) number {
+    return x + y
+}`,
+}
+
+func TestCompilePackage_CachesImports(t *testing.T) {
+	dir, cleanup := writePackages(t, importedPackages)
+	defer cleanup()
+
+	compiled := compilePackage(t, dir)
+	assert.Equal(t, "6\n", runPackage(t, compiled))
+
+	// Only the imported packages are cached.
+	assert.Len(t, cachedPackages(t), 2)
+
+	// Nothing changed so nothing new is cached.
+	compiled = compilePackage(t, dir)
+	assert.Equal(t, "6\n", runPackage(t, compiled))
+	assert.Len(t, cachedPackages(t), 2)
+}
+
+func TestCompilePackage_ChangedImport(t *testing.T) {
+	dir, cleanup := writePackages(t, importedPackages)
+	defer cleanup()
+
+	compilePackage(t, dir)
+
+	// Changing "b" must also recompile "a" because it imports "b".
+	writeFile(t, filepath.Join(dir, "b/b.ok"), `func Add(x, y number) number {
+    return x + y + 1
+}`)
+
+	compiled := compilePackage(t, dir)
+	assert.Equal(t, "7\n", runPackage(t, compiled))
+	assert.Len(t, cachedPackages(t), 4)
+}
+
+func TestCompilePackage_LoadsFromCache(t *testing.T) {
+	dir, cleanup := writePackages(t, importedPackages)
+	defer cleanup()
+
+	compilePackage(t, dir)
+
+	// Replace the cached packages with something that could not have come
+	// from the source to prove that they are being used.
+	for _, fileName := range cachedPackages(t) {
+		f, err := os.Open(fileName)
+		require.NoError(t, err)
+		program, err := vm.DecodeProgram(f)
+		f.Close()
+		require.NoError(t, err)
+
+		program.Funcs["cached."+program.Package] = &vm.CompiledFunc{}
+
+		var buf bytes.Buffer
+		require.NoError(t, program.Encode(&buf))
+		require.NoError(t, ioutil.WriteFile(fileName, buf.Bytes(), 0644))
+	}
+
+	compiled := compilePackage(t, dir)
+	assert.Contains(t, compiled.Funcs, "cached.a")
+	assert.Contains(t, compiled.Funcs, "cached.b")
+}
+
+func TestCompilePackage_CorruptCache(t *testing.T) {
+	dir, cleanup := writePackages(t, importedPackages)
+	defer cleanup()
+
+	compilePackage(t, dir)
+
+	for _, fileName := range cachedPackages(t) {
+		require.NoError(t, ioutil.WriteFile(fileName, []byte("okc"), 0644))
+	}
+
+	compiled := compilePackage(t, dir)
+	assert.Equal(t, "6\n", runPackage(t, compiled))
+}
+
+func TestCompilePackage_WorkingDirectory(t *testing.T) {
+	dir, cleanup := writePackages(t, map[string]string{
+		"p/main.ok": `import "lib"
+func main() {
+    lib.Boom()
+}`,
+		"p/lib/l.ok": `func Boom() {
+    raise Error("boom")
+}`,
+	})
+	defer cleanup()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(wd)
+
+	// The positions of the imported package are relative to the working
+	// directory, so they must not be shared through the cache.
+	for _, test := range []struct {
+		wd, dir, pos string
+	}{
+		{dir, "p", "p/lib/l.ok:2:5"},
+		{filepath.Join(dir, "p"), ".", "lib/l.ok:2:5"},
+	} {
+		require.NoError(t, os.Chdir(test.wd))
+
+		compiled := compilePackage(t, test.dir)
+		assert.Contains(t, compiled.Funcs["lib.Boom"].Positions, test.pos)
+	}
+}
+
+func TestCompilePackage_ImportCycle(t *testing.T) {
+	dir, cleanup := writePackages(t, map[string]string{
+		"main.ok": `import "a"
+func main() {}`,
+		"a/a.ok": `import "b"
+func A() {}`,
+		"b/b.ok": `import "a"
+func B() {}`,
+	})
+	defer cleanup()
+
+	_, errs := compiler.CompilePackage(dir, false)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "import cycle through package a")
+}
+
+func TestCompilePackages(t *testing.T) {
+	dir, cleanup := writePackages(t, map[string]string{
+		"p/p.ok": `import "../a"
+func main() {
+    print(a.Double(3))
+}`,
+		"q/q.ok": `import "../a"
+func main() {
+    print(a.Double(4))
+}`,
+		"a/a.ok": `import "../b"
+func Double(n number) number {
+    return b.Add(n, n)
+}`,
+		"b/b.ok": `func Add(x, y number) number {
+    return x + y
+}`,
+	})
+	defer cleanup()
+
+	packages, errs := compiler.CompilePackages(
+		[]string{filepath.Join(dir, "p"), filepath.Join(dir, "q")}, false)
+	require.Nil(t, errs)
+	require.Len(t, packages, 2)
+
+	assert.Equal(t, "6\n", runPackage(t, packages[0]))
+	assert.Equal(t, "8\n", runPackage(t, packages[1]))
+
+	// Both packages import "a" which must only be compiled once.
+	assert.Same(t, packages[0].Funcs["a.Double"], packages[1].Funcs["a.Double"])
+}
+
+func TestCompilePackage_QualifiedDeclarations(t *testing.T) {
+	dir, cleanup := writePackages(t, map[string]string{
+		"main.ok": `import "a"
+func main() {
+    print(a.Double(3))
+    try {
+        a.Check(-1)
+    } on a.NegativeError {
+        print("negative", err.Error)
+    }
+}`,
+		"a/a.ok": `func NegativeError(Error string) NegativeError {
+}
+func add(x, y number) number {
+    return x + y
+}
+func Double(n number) number {
+    return add(n, n)
+}
+func Check(n number) {
+    if n < 0 {
+        raise NegativeError("{n}")
+    }
+}`,
+	})
+	defer cleanup()
+
+	compiled := compilePackage(t, dir)
+	assert.Equal(t, "6\nnegative -1\n", runPackage(t, compiled))
+	assert.Contains(t, compiled.Funcs, "a.add")
+	assert.Contains(t, compiled.Interfaces, "a.NegativeError")
+}
+
+func TestCompileLibPackage(t *testing.T) {
+	dir, cleanup := writePackages(t, map[string]string{
+		"a/a.ok": `func NegativeError(Error string) NegativeError {
+}
+func Abs(n number) number {
+    if n < 0 {
+        return -n
+    }
+    return n
+}`,
+	})
+	defer cleanup()
+
+	compiled, errs := compiler.CompileLibPackage(filepath.Join(dir, "a"))
+	require.Nil(t, errs)
+	assert.Contains(t, compiled.Funcs, "a.Abs")
+	assert.Contains(t, compiled.Interfaces, "a.NegativeError")
+	assert.Equal(t, []string{"a.NegativeError"},
+		compiled.FuncDefs["a.NegativeError"].Returns)
+}
@@ -0,0 +1,87 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/elliotchance/ok/ast"
+)
+
+// qualify prefixes the functions and types declared in an imported package
+// with the package name, such as "strings.Split". This stops them from
+// clashing with the declarations in other packages, and lets a value be traced
+// back to the package that created it.
+//
+// The function names are already prefixed by parsePackage.
+func (pkg *parsedPackage) qualify() {
+	isLocal := func(ty string) bool {
+		_, ok := pkg.interfaces[ty]
+		return ok
+	}
+
+	for _, fn := range pkg.funcs {
+		fn.Name = pkg.prefix + fn.Name
+
+		for _, arg := range fn.Arguments {
+			arg.Type = qualifyType(pkg.prefix, arg.Type, isLocal)
+		}
+
+		for i, ty := range fn.Returns {
+			fn.Returns[i] = qualifyType(pkg.prefix, ty, isLocal)
+		}
+	}
+
+	interfaces := map[string]map[string]string{}
+	for name, iface := range pkg.interfaces {
+		for property, ty := range iface {
+			iface[property] = qualifyType(pkg.prefix, ty, isLocal)
+		}
+
+		interfaces[pkg.prefix+name] = iface
+	}
+	pkg.interfaces = interfaces
+}
+
+// qualifyType prefixes the type, or the element type of an array or map, if it
+// is declared in the package being compiled.
+func qualifyType(prefix, ty string, isLocal func(string) bool) string {
+	base := ty
+	for strings.HasPrefix(base, "[]") || strings.HasPrefix(base, "{}") {
+		base = base[2:]
+	}
+
+	if !isLocal(base) {
+		return ty
+	}
+
+	return ty[:len(ty)-len(base)] + prefix + base
+}
+
+// qualifiedType returns the full name of a type that is used inside the
+// package being compiled.
+func (file *Compiled) qualifiedType(ty string) string {
+	if file.prefix == "" {
+		return ty
+	}
+
+	return qualifyType(file.prefix, ty, func(ty string) bool {
+		_, ok := file.Interfaces[file.prefix+ty]
+		return ok
+	})
+}
+
+// funcDef returns the function with the name, including functions in the
+// package being compiled that are referenced without the package name. The
+// full name of the function is also returned.
+func (file *Compiled) funcDef(name string) (*ast.Func, string) {
+	if fn, ok := file.FuncDefs[name]; ok {
+		return fn, name
+	}
+
+	if file.prefix != "" {
+		if fn, ok := file.FuncDefs[file.prefix+name]; ok {
+			return fn, file.prefix + name
+		}
+	}
+
+	return nil, ""
+}
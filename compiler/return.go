@@ -7,14 +7,19 @@ import (
 
 func compileReturn(compiledFunc *vm.CompiledFunc, n *ast.Return, file *Compiled) error {
 	var results []vm.Register
+	var kinds []string
 	for _, expr := range n.Exprs {
-		// TODO(elliot): Check return types are valid.
-		result, _, err := compileExpr(compiledFunc, expr, file)
+		result, kind, err := compileExpr(compiledFunc, expr, file)
 		if err != nil {
 			return err
 		}
 
 		results = append(results, result...)
+		kinds = append(kinds, kind...)
+	}
+
+	if err := checkReturn(file, n, kinds); err != nil {
+		return err
 	}
 
 	compiledFunc.Append(&vm.Return{
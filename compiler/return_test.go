@@ -1,6 +1,7 @@
 package compiler_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/elliotchance/ok/ast"
@@ -14,6 +15,7 @@ import (
 func TestReturn(t *testing.T) {
 	for testName, test := range map[string]struct {
 		node     ast.Node
+		returns  []string
 		expected []vm.Instruction
 		err      error
 	}{
@@ -29,13 +31,14 @@ func TestReturn(t *testing.T) {
 					asttest.NewLiteralNumber("123"),
 				},
 			},
+			returns: []string{"number"},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.Return{
-					Results: []vm.Register{"1"},
+					Results: []vm.Register{1},
 				},
 			},
 		},
@@ -46,23 +49,62 @@ func TestReturn(t *testing.T) {
 					asttest.NewLiteralString("foo"),
 				},
 			},
+			returns: []string{"number", "string"},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("123"),
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 2,
 					Value:        asttest.NewLiteralString("foo"),
 				},
 				&vm.Return{
-					Results: []vm.Register{"1", "2"},
+					Results: []vm.Register{1, 2},
+				},
+			},
+		},
+		"return-too-many-values": {
+			node: &ast.Return{
+				Exprs: []ast.Node{
+					asttest.NewLiteralNumber("123"),
+				},
+			},
+			err: errors.New("foo must return 0 values, but 1 were returned"),
+		},
+		"return-wrong-type": {
+			node: &ast.Return{
+				Exprs: []ast.Node{
+					asttest.NewLiteralNumber("123"),
+					asttest.NewLiteralNumber("456"),
+				},
+			},
+			returns: []string{"number", "string"},
+			err: errors.New(
+				"cannot use number as string for return value 2 of foo"),
+		},
+		"return-any": {
+			node: &ast.Return{
+				Exprs: []ast.Node{
+					asttest.NewLiteralNumber("123"),
+				},
+			},
+			returns: []string{"any"},
+			expected: []vm.Instruction{
+				&vm.Assign{
+					VariableName: 1,
+					Value:        asttest.NewLiteralNumber("123"),
+				},
+				&vm.Return{
+					Results: []vm.Register{1},
 				},
 			},
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			compiledFunc, err := compiler.CompileFunc(&ast.Func{
+				Name:    "foo",
+				Returns: test.returns,
 				Statements: []ast.Node{
 					test.node,
 				},
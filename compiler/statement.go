@@ -6,6 +6,11 @@ import (
 )
 
 func compileStatement(compiledFunc *vm.CompiledFunc, statement ast.Node, breakIns, continueIns vm.Instruction, file *Compiled) error {
+	// Nested statements will have already set their own positions so only the
+	// remaining instructions belong to this statement.
+	defer compiledFunc.SetPositions(len(compiledFunc.Instructions),
+		statement.Position())
+
 	switch n := statement.(type) {
 	case *ast.Break:
 		compiledFunc.Append(breakIns)
@@ -21,6 +26,9 @@ func compileStatement(compiledFunc *vm.CompiledFunc, statement ast.Node, breakIn
 	case *ast.Assert:
 		return compileAssert(compiledFunc, n, file)
 
+	case *ast.AssertRaise:
+		return compileAssertRaise(compiledFunc, n, file)
+
 	case *ast.For:
 		return compileFor(compiledFunc, n, file)
 
@@ -19,13 +19,13 @@ func compileCase(compiledFunc *vm.CompiledFunc, n *ast.Case, valueRegister vm.Re
 		}
 
 		if conditionKinds[0] != expectedConditionKind {
-			return fmt.Errorf(
+			return newDiagnostic(condition.Position(), CodeTypeMismatch,
 				"expression in case condition must be %s, got %s",
 				expectedConditionKind, conditionKinds[0])
 		}
 
 		// If we are comparing against a value we need to add the equality step.
-		if valueRegister != "" {
+		if valueRegister != vm.NoRegister {
 			result := compiledFunc.NextRegister()
 
 			op := fmt.Sprintf("%s == %s", conditionKinds[0], conditionKinds[0])
@@ -64,7 +64,7 @@ func compileSwitch(compiledFunc *vm.CompiledFunc, n *ast.Switch, breakIns, conti
 	// Condition must be a bool if no value has been provided, otherwise all
 	// conditions must be the same type as the value.
 	expectedConditionKinds := []string{"bool"}
-	valueRegisters := []vm.Register{""}
+	valueRegisters := []vm.Register{vm.NoRegister}
 	if n.Expr != nil {
 		var err error
 		valueRegisters, expectedConditionKinds, err = compileExpr(compiledFunc, n.Expr, file)
@@ -76,36 +76,36 @@ func TestSwitch(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// a == 1
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        7,
 				},
 
 				// print("ONE")
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("ONE"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"4"},
+					Arguments: []vm.Register{5},
 				},
 				&vm.Jump{
 					To: 13,
@@ -113,26 +113,26 @@ func TestSwitch(t *testing.T) {
 
 				// a == 2
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "5",
-					Result: "6",
+					Left:   2,
+					Right:  6,
+					Result: 7,
 				},
 				&vm.JumpUnless{
-					Condition: "6",
+					Condition: 7,
 					To:        13,
 				},
 
 				// print("TWO")
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralString("TWO"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"7"},
+					Arguments: []vm.Register{8},
 				},
 				&vm.Jump{
 					To: 13,
@@ -199,36 +199,36 @@ func TestSwitch(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// a == 1
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        7,
 				},
 
 				// print("ONE")
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("ONE"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"4"},
+					Arguments: []vm.Register{5},
 				},
 				&vm.Jump{
 					To: 15,
@@ -236,26 +236,26 @@ func TestSwitch(t *testing.T) {
 
 				// a == 2
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "5",
-					Result: "6",
+					Left:   2,
+					Right:  6,
+					Result: 7,
 				},
 				&vm.JumpUnless{
-					Condition: "6",
+					Condition: 7,
 					To:        13,
 				},
 
 				// print("TWO")
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralString("TWO"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"7"},
+					Arguments: []vm.Register{8},
 				},
 				&vm.Jump{
 					To: 15,
@@ -263,11 +263,11 @@ func TestSwitch(t *testing.T) {
 
 				// print("NO MATCH")
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 9,
 					Value:        asttest.NewLiteralString("NO MATCH"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"8"},
+					Arguments: []vm.Register{9},
 				},
 			},
 		},
@@ -328,36 +328,36 @@ func TestSwitch(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// a == 1
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        7,
 				},
 
 				// print("ONE OR TWO")
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("ONE OR TWO"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"4"},
+					Arguments: []vm.Register{5},
 				},
 				&vm.Jump{
 					To: 19,
@@ -365,26 +365,26 @@ func TestSwitch(t *testing.T) {
 
 				// a == 2
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "5",
-					Result: "6",
+					Left:   2,
+					Right:  6,
+					Result: 7,
 				},
 				&vm.JumpUnless{
-					Condition: "6",
+					Condition: 7,
 					To:        13,
 				},
 
 				// print("ONE OR TWO")
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralString("ONE OR TWO"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"7"},
+					Arguments: []vm.Register{8},
 				},
 				&vm.Jump{
 					To: 19,
@@ -392,26 +392,26 @@ func TestSwitch(t *testing.T) {
 
 				// a == 3
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 9,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "8",
-					Result: "9",
+					Left:   2,
+					Right:  9,
+					Result: 10,
 				},
 				&vm.JumpUnless{
-					Condition: "9",
+					Condition: 10,
 					To:        19,
 				},
 
 				// print("THREE")
 				&vm.Assign{
-					VariableName: "10",
+					VariableName: 11,
 					Value:        asttest.NewLiteralString("THREE"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"10"},
+					Arguments: []vm.Register{11},
 				},
 				&vm.Jump{
 					To: 19,
@@ -435,12 +435,12 @@ func TestSwitch(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 			},
 		},
@@ -490,36 +490,36 @@ func TestSwitch(t *testing.T) {
 			expected: []vm.Instruction{
 				// a = 0
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "a",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 
 				// case 1
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "2",
-					Result: "3",
+					Left:   2,
+					Right:  3,
+					Result: 4,
 				},
 				&vm.JumpUnless{
-					Condition: "3",
+					Condition: 4,
 					To:        7,
 				},
 
 				// print("ONE OR TWO")
 				&vm.Assign{
-					VariableName: "4",
+					VariableName: 5,
 					Value:        asttest.NewLiteralString("ONE OR TWO"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"4"},
+					Arguments: []vm.Register{5},
 				},
 				&vm.Jump{
 					To: 19,
@@ -527,26 +527,26 @@ func TestSwitch(t *testing.T) {
 
 				// case 2
 				&vm.Assign{
-					VariableName: "5",
+					VariableName: 6,
 					Value:        asttest.NewLiteralNumber("2"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "5",
-					Result: "6",
+					Left:   2,
+					Right:  6,
+					Result: 7,
 				},
 				&vm.JumpUnless{
-					Condition: "6",
+					Condition: 7,
 					To:        13,
 				},
 
 				// print("ONE OR TWO")
 				&vm.Assign{
-					VariableName: "7",
+					VariableName: 8,
 					Value:        asttest.NewLiteralString("ONE OR TWO"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"7"},
+					Arguments: []vm.Register{8},
 				},
 				&vm.Jump{
 					To: 19,
@@ -554,26 +554,26 @@ func TestSwitch(t *testing.T) {
 
 				// case 3
 				&vm.Assign{
-					VariableName: "8",
+					VariableName: 9,
 					Value:        asttest.NewLiteralNumber("3"),
 				},
 				&vm.EqualNumber{
-					Left:   "a",
-					Right:  "8",
-					Result: "9",
+					Left:   2,
+					Right:  9,
+					Result: 10,
 				},
 				&vm.JumpUnless{
-					Condition: "9",
+					Condition: 10,
 					To:        19,
 				},
 
 				// print("THREE")
 				&vm.Assign{
-					VariableName: "10",
+					VariableName: 11,
 					Value:        asttest.NewLiteralString("THREE"),
 				},
 				&vm.Print{
-					Arguments: []vm.Register{"10"},
+					Arguments: []vm.Register{11},
 				},
 				&vm.Jump{
 					To: 19,
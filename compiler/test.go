@@ -2,14 +2,59 @@ package compiler
 
 import (
 	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/compiler/kind"
 	"github.com/elliotchance/ok/vm"
 )
 
 // CompileTest will compile a test.
 func CompileTest(fn *ast.Test, file *Compiled) (*vm.CompiledTest, error) {
+	var arguments []*ast.Argument
+	var cases *vm.CompiledFunc
+	if fn.Cases != nil {
+		var casesKind string
+		var err error
+		cases, casesKind, err = compileTestCases(fn, file)
+		if err != nil {
+			return nil, err
+		}
+
+		// Each case is passed to the test as arguments, in the same way that
+		// the value and key would be set in a for loop.
+		arguments = append(arguments, &ast.Argument{
+			Name: fn.Cases.Value,
+			Type: kind.ElementType(casesKind),
+		})
+
+		if fn.Cases.Key != "" {
+			keyKind := "number"
+			if kind.IsMap(casesKind) {
+				keyKind = "string"
+			}
+
+			arguments = append(arguments, &ast.Argument{
+				Name: fn.Cases.Key,
+				Type: keyKind,
+			})
+		}
+	}
+
+	// The arguments of a fuzz test are generated randomly, so they can only be
+	// types that the VM knows how to generate.
+	var fuzzTypes []string
+	for _, arg := range fn.Arguments {
+		if !isFuzzable(arg.Type) {
+			return nil, newDiagnostic(fn.Pos, CodeTypeMismatch,
+				"cannot fuzz %s of type %s", arg.Name, arg.Type)
+		}
+
+		arguments = append(arguments, arg)
+		fuzzTypes = append(fuzzTypes, arg.Type)
+	}
+
 	// Tests can be compiled as if they were functions, then wrapped in a
 	// CompiledTest.
 	compiledFunc, err := CompileFunc(&ast.Func{
+		Arguments:  arguments,
 		Statements: fn.Statements,
 		Pos:        fn.Pos,
 	}, file)
@@ -20,5 +65,54 @@ func CompileTest(fn *ast.Test, file *Compiled) (*vm.CompiledTest, error) {
 	return &vm.CompiledTest{
 		CompiledFunc: compiledFunc,
 		TestName:     fn.Name,
+		Cases:        cases,
+		FuzzTypes:    fuzzTypes,
 	}, nil
 }
+
+// isFuzzable returns true if random values can be generated for the type.
+func isFuzzable(ty string) bool {
+	switch {
+	case kind.IsArray(ty), kind.IsMap(ty):
+		return isFuzzable(kind.ElementType(ty))
+
+	case ty == "number", ty == "string", ty == "char", ty == "bool",
+		ty == "data":
+		return true
+	}
+
+	return false
+}
+
+// compileTestCases compiles a function that returns the cases for a test.
+func compileTestCases(fn *ast.Test, file *Compiled) (*vm.CompiledFunc, string, error) {
+	compiled := &vm.CompiledFunc{
+		Variables:  map[string]string{},
+		Interfaces: file.Interfaces,
+	}
+
+	file.scope = &funcScope{
+		fn:       &ast.Func{Pos: fn.Pos},
+		compiled: compiled,
+		parent:   file.scope,
+	}
+	defer func() {
+		file.scope = file.scope.parent
+	}()
+
+	results, kinds, err := compileExpr(compiled, fn.Cases.Expr, file)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !kind.IsArray(kinds[0]) && !kind.IsMap(kinds[0]) {
+		return nil, "", newDiagnostic(fn.Cases.Position(), CodeTypeMismatch,
+			"test cases must be an array or map, not %s", kinds[0])
+	}
+
+	compiled.Append(&vm.Return{
+		Results: results,
+	})
+
+	return compiled, kinds[0], nil
+}
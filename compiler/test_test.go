@@ -1,9 +1,11 @@
 package compiler_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler"
 	"github.com/elliotchance/ok/vm"
 	"github.com/stretchr/testify/assert"
@@ -12,9 +14,10 @@ import (
 
 func TestTest(t *testing.T) {
 	for testName, test := range map[string]struct {
-		fn       *ast.Test
-		expected []vm.Instruction
-		err      error
+		fn        *ast.Test
+		expected  []vm.Instruction
+		arguments []string
+		err       error
 	}{
 		"no-statements": {
 			fn: &ast.Test{},
@@ -31,6 +34,51 @@ func TestTest(t *testing.T) {
 				&vm.Print{},
 			},
 		},
+		"cases": {
+			fn: &ast.Test{
+				Cases: &ast.In{
+					Value: "c",
+					Expr:  asttest.NewArrayNumbers([]string{"1", "2"}),
+				},
+			},
+			arguments: []string{"c"},
+		},
+		"cases-with-key": {
+			fn: &ast.Test{
+				Cases: &ast.In{
+					Value: "c",
+					Key:   "k",
+					Expr:  asttest.NewArrayNumbers([]string{"1", "2"}),
+				},
+			},
+			arguments: []string{"c", "k"},
+		},
+		"fuzz": {
+			fn: &ast.Test{
+				Arguments: []*ast.Argument{
+					{Name: "s", Type: "string"},
+					{Name: "n", Type: "{}[]number"},
+				},
+			},
+			arguments: []string{"s", "n"},
+		},
+		"fuzz-not-fuzzable": {
+			fn: &ast.Test{
+				Arguments: []*ast.Argument{
+					{Name: "f", Type: "[]func()"},
+				},
+			},
+			err: errors.New("cannot fuzz f of type []func()"),
+		},
+		"cases-not-iterable": {
+			fn: &ast.Test{
+				Cases: &ast.In{
+					Value: "c",
+					Expr:  asttest.NewLiteralNumber("1"),
+				},
+			},
+			err: errors.New("test cases must be an array or map, not number"),
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			compiledFunc, err := compiler.CompileTest(test.fn,
@@ -40,6 +88,7 @@ func TestTest(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.Equal(t, test.expected, compiledFunc.Instructions)
+				assert.Equal(t, test.arguments, compiledFunc.Arguments)
 			}
 		})
 	}
@@ -0,0 +1,144 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/compiler/kind"
+	"github.com/elliotchance/ok/vm"
+)
+
+// funcScope is the function currently being compiled. It is used to check
+// return values and to find the types of variables in the parent scope.
+type funcScope struct {
+	fn       *ast.Func
+	compiled *vm.CompiledFunc
+	parent   *funcScope
+}
+
+// checkType returns an error if a value of type actual cannot be used where a
+// value of type expected is required. The error does not include the position
+// so the caller can describe where the value was used.
+//
+// A value of type "any" is allowed anywhere because it can only be checked at
+// runtime. An object can be used in place of another type (including an
+// interface) if it has all of the same public properties with compatible
+// types.
+func checkType(file *Compiled, expected, actual string) error {
+	if err := checkTypeDetail(file, expected, actual); err != "" {
+		if err == "mismatch" {
+			return fmt.Errorf("cannot use %s as %s", actual, expected)
+		}
+
+		return fmt.Errorf("cannot use %s as %s (%s)", actual, expected, err)
+	}
+
+	return nil
+}
+
+func checkTypeDetail(file *Compiled, expected, actual string) string {
+	switch {
+	case expected == actual, expected == "any", actual == "any",
+		// The type is not known, such as a call to print.
+		actual == "":
+		return ""
+
+	case kind.IsArray(expected) && kind.IsArray(actual),
+		kind.IsMap(expected) && kind.IsMap(actual):
+		return checkTypeDetail(file, kind.ElementType(expected),
+			kind.ElementType(actual))
+	}
+
+	want, have := interfaceOf(file, expected), interfaceOf(file, actual)
+	if want == nil || have == nil {
+		return "mismatch"
+	}
+
+	var names []string
+	for name := range want {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ty, ok := have[name]
+		if !ok {
+			return fmt.Sprintf("missing property %s", name)
+		}
+
+		if checkTypeDetail(file, want[name], ty) != "" {
+			return fmt.Sprintf("property %s is %s, expected %s",
+				name, ty, want[name])
+		}
+	}
+
+	return ""
+}
+
+// interfaceOf returns the public properties of an object type, or nil if ty
+// is not an object.
+func interfaceOf(file *Compiled, ty string) map[string]string {
+	if i, ok := file.Interfaces[ty]; ok {
+		return i
+	}
+
+	// Types, like Error, from the standard library.
+	return vm.Interfaces[ty]
+}
+
+// checkArguments returns an error if the arguments cannot be used to call fn.
+func checkArguments(file *Compiled, pos, name string, fn *ast.Func, argKinds []string) error {
+	if len(argKinds) != len(fn.Arguments) {
+		return newDiagnostic(pos, CodeArgumentCount,
+			"%s expects %d arguments, but %d were provided",
+			name, len(fn.Arguments), len(argKinds))
+	}
+
+	for i, arg := range fn.Arguments {
+		if err := checkType(file, arg.Type, argKinds[i]); err != nil {
+			return newDiagnostic(pos, CodeTypeMismatch,
+				"%v for argument %d of %s", err, i+1, name)
+		}
+	}
+
+	return nil
+}
+
+// checkReturn returns an error if the values returned do not match the
+// function being compiled.
+func checkReturn(file *Compiled, n *ast.Return, kinds []string) error {
+	// Statements may be compiled outside of a function, such as in the REPL.
+	if file.scope == nil {
+		return nil
+	}
+
+	fn := file.scope.fn
+	if len(kinds) != len(fn.Returns) {
+		return newDiagnostic(n.Position(), CodeReturnCount,
+			"%s must return %d values, but %d were returned",
+			fn.Name, len(fn.Returns), len(kinds))
+	}
+
+	for i, ty := range fn.Returns {
+		if err := checkType(file, ty, kinds[i]); err != nil {
+			return newDiagnostic(n.Position(), CodeTypeMismatch,
+				"%v for return value %d of %s", err, i+1, fn.Name)
+		}
+	}
+
+	return nil
+}
+
+// parentVariableType returns the type of a variable in the scope that
+// contains the function being compiled. It is "number" if the variable cannot
+// be found.
+func parentVariableType(file *Compiled, name string) string {
+	if file.scope != nil && file.scope.parent != nil {
+		if ty, ok := file.scope.parent.compiled.Variables[name]; ok {
+			return ty
+		}
+	}
+
+	return "number"
+}
@@ -0,0 +1,144 @@
+package compiler_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypes(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src string
+		err string
+	}{
+		"argument-ok": {
+			src: `func foo(a number) {}
+func main() {
+    foo(1)
+}`,
+		},
+		"argument-wrong-type": {
+			src: `func foo(a number) {}
+func main() {
+    foo("bar")
+}`,
+			err: "a.ok:3:5 cannot use string as number for argument 1 of foo",
+		},
+		"argument-count": {
+			src: `func foo(a number) {}
+func main() {
+    foo(1, 2)
+}`,
+			err: "a.ok:3:5 foo expects 1 arguments, but 2 were provided",
+		},
+		"argument-builtin": {
+			src: `func main() {
+    char("a")
+}`,
+			err: "a.ok:2:5 cannot use string as number for argument 1 of char",
+		},
+		"argument-any": {
+			src: `func foo(a any) {}
+func main() {
+    foo("bar")
+}`,
+		},
+		"argument-interface": {
+			src: `func Named(Name string) Named {}
+func Person() Person {
+    Name = "Bob"
+    Age = 3
+}
+func foo(a Named) {}
+func main() {
+    foo(Person())
+}`,
+		},
+		"argument-interface-missing-property": {
+			src: `func Named(Name string) Named {}
+func Person() Person {
+    Age = 3
+}
+func foo(a Named) {}
+func main() {
+    foo(Person())
+}`,
+			err: "a.ok:7:5 cannot use Person as Named (missing property Name) for argument 1 of foo",
+		},
+		"argument-interface-wrong-property": {
+			src: `func Named(Name string) Named {}
+func Person() Person {
+    Name = 3
+}
+func foo(a Named) {}
+func main() {
+    foo(Person())
+}`,
+			err: "a.ok:7:5 cannot use Person as Named (property Name is number, expected string) for argument 1 of foo",
+		},
+		"argument-array": {
+			src: `func foo(a []number) {}
+func main() {
+    foo(["a"])
+}`,
+			err: "a.ok:3:5 cannot use []string as []number for argument 1 of foo",
+		},
+		"return-ok": {
+			src: `func foo() number {
+    return 1
+}`,
+		},
+		"return-wrong-type": {
+			src: `func foo() number {
+    return "a"
+}`,
+			err: "a.ok:2:5 cannot use string as number for return value 1 of foo",
+		},
+		"return-count": {
+			src: `func foo() (number, number) {
+    return 1
+}`,
+			err: "a.ok:2:5 foo must return 2 values, but 1 were returned",
+		},
+		"return-closure": {
+			src: `func foo() number {
+    bar = func() string {
+        return "a"
+    }
+    return 1
+}`,
+		},
+		"assign-wrong-type": {
+			src: `func main() {
+    a = 1
+    a = "b"
+}`,
+			err: "a.ok:3:5 cannot assign string to variable a (expecting number)",
+		},
+		"assign-parent-variable": {
+			src: `func main() {
+    a = "b"
+    f = func() {
+        b = ^a
+        b = "c"
+    }
+}`,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			p := parser.ParseString(test.src, "a.ok")
+			require.Nil(t, p.Errors())
+
+			_, errs := compiler.CompileFile(p.File, p.Interfaces, nil)
+			if test.err != "" {
+				require.Len(t, errs, 1)
+				assert.EqualError(t, errs[0], test.err)
+			} else {
+				assert.Nil(t, errs)
+			}
+		})
+	}
+}
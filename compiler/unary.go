@@ -9,7 +9,7 @@ import (
 func compileUnary(compiledFunc *vm.CompiledFunc, e *ast.Unary, file *Compiled) (vm.Register, string, error) {
 	returns1, kinds, err := compileExpr(compiledFunc, e.Expr, file)
 	if err != nil {
-		return "", "", err
+		return vm.NoRegister, "", err
 	}
 
 	var ins vm.Instruction
@@ -49,9 +49,9 @@ func compileUnary(compiledFunc *vm.CompiledFunc, e *ast.Unary, file *Compiled) (
 		})
 
 		ins = &vm.Add{
-			Left:   vm.Register(returns1[0]),
-			Right:  vm.Register(oneAt),
-			Result: vm.Register(returns1[0]),
+			Left:   returns1[0],
+			Right:  oneAt,
+			Result: returns1[0],
 		}
 		compiledFunc.Append(ins)
 
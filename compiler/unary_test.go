@@ -35,21 +35,21 @@ func TestUnary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Add{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -70,21 +70,21 @@ func TestUnary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralNumber("0"),
 				},
 				&vm.Assign{
-					VariableName: "i",
-					Register:     "1",
+					VariableName: 2,
+					Register:     1,
 				},
 				&vm.Assign{
-					VariableName: "2",
+					VariableName: 3,
 					Value:        asttest.NewLiteralNumber("1"),
 				},
 				&vm.Subtract{
-					Left:   "i",
-					Right:  "2",
-					Result: "i",
+					Left:   2,
+					Right:  3,
+					Result: 2,
 				},
 			},
 		},
@@ -97,12 +97,12 @@ func TestUnary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(true),
 				},
 				&vm.Not{
-					Left:   "1",
-					Result: "2",
+					Left:   1,
+					Result: 2,
 				},
 			},
 		},
@@ -115,12 +115,12 @@ func TestUnary(t *testing.T) {
 			},
 			expected: []vm.Instruction{
 				&vm.Assign{
-					VariableName: "1",
+					VariableName: 1,
 					Value:        asttest.NewLiteralBool(false),
 				},
 				&vm.Not{
-					Left:   "1",
-					Result: "2",
+					Left:   1,
+					Result: 2,
 				},
 			},
 		},
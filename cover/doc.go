@@ -0,0 +1,7 @@
+// Package cover implements the statement coverage used by "ok test -cover".
+//
+// The VM records the position of each statement as it is run (see
+// vm.VM.Coverage). A Profile compares those positions with all of the
+// statements in a package. Profiles are written in the same line based format
+// as "go test -coverprofile" so they can be used with the same tools.
+package cover
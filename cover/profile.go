@@ -0,0 +1,165 @@
+package cover
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/vm"
+)
+
+// Block is a single statement.
+type Block struct {
+	// Pos is the position of the statement, as it appears in
+	// vm.CompiledFunc.Positions.
+	Pos string
+
+	FileName               string
+	StartLine, StartColumn int
+	EndLine, EndColumn     int
+}
+
+// Profile is the coverage for a single package.
+type Profile struct {
+	// Blocks contains each statement in the package ordered by file name
+	// and position.
+	Blocks []*Block
+
+	// Covered contains the Pos of each statement that was run.
+	Covered map[string]bool
+}
+
+// NewProfile creates a profile for the statements in funcs that belong to one
+// of fileNames. Statements in other files, such as tests or imported packages,
+// are not included. covered is usually vm.VM.Coverage.
+func NewProfile(funcs map[string]*vm.CompiledFunc, fileNames []string, covered map[string]bool) (*Profile, error) {
+	inPackage := map[string]bool{}
+	for _, fileName := range fileNames {
+		inPackage[fileName] = true
+	}
+
+	profile := &Profile{Covered: covered}
+	seen := map[string]bool{}
+	add := func(positions []string) {
+		for _, pos := range positions {
+			p := lexer.ParsePos(pos)
+			if seen[pos] || !inPackage[p.FileName] {
+				continue
+			}
+
+			seen[pos] = true
+			profile.Blocks = append(profile.Blocks, &Block{
+				Pos:         pos,
+				FileName:    p.FileName,
+				StartLine:   p.LineNumber,
+				StartColumn: p.CharacterNumber,
+			})
+		}
+	}
+
+	for _, fn := range funcs {
+		add(fn.Positions)
+		for _, positions := range fn.FinallyPositions {
+			add(positions)
+		}
+	}
+
+	sort.Slice(profile.Blocks, func(i, j int) bool {
+		a, b := profile.Blocks[i], profile.Blocks[j]
+		switch {
+		case a.FileName != b.FileName:
+			return a.FileName < b.FileName
+		case a.StartLine != b.StartLine:
+			return a.StartLine < b.StartLine
+		}
+
+		return a.StartColumn < b.StartColumn
+	})
+
+	return profile, profile.setEnds()
+}
+
+// setEnds finishes each block at the start of the next statement on the same
+// line, or at the end of the line.
+func (p *Profile) setEnds() error {
+	lines := map[string][]string{}
+	for i, block := range p.Blocks {
+		if _, ok := lines[block.FileName]; !ok {
+			data, err := ioutil.ReadFile(block.FileName)
+			if err != nil {
+				return err
+			}
+
+			lines[block.FileName] = strings.Split(string(data), "\n")
+		}
+
+		block.EndLine = block.StartLine
+		block.EndColumn = block.StartColumn + 1
+		if line := block.StartLine - 1; line < len(lines[block.FileName]) {
+			block.EndColumn = len([]rune(lines[block.FileName][line])) + 1
+		}
+
+		if i+1 < len(p.Blocks) {
+			next := p.Blocks[i+1]
+			if next.FileName == block.FileName &&
+				next.StartLine == block.StartLine {
+				block.EndColumn = next.StartColumn
+			}
+		}
+	}
+
+	return nil
+}
+
+// Percent returns the percentage of statements that were run. A package
+// without any statements is 0%, see Blocks.
+func (p *Profile) Percent() float64 {
+	if len(p.Blocks) == 0 {
+		return 0
+	}
+
+	covered := 0
+	for _, block := range p.Blocks {
+		if p.Covered[block.Pos] {
+			covered++
+		}
+	}
+
+	return 100 * float64(covered) / float64(len(p.Blocks))
+}
+
+// WriteProfiles writes the profiles in the format used by
+// "go test -coverprofile":
+//
+//	mode: set
+//	main.ok:3.5,3.17 1 1
+//
+// Each line after the mode is "file:startLine.startColumn,endLine.endColumn"
+// followed by the number of statements (always 1) and whether the statement
+// was run.
+func WriteProfiles(w io.Writer, profiles []*Profile) error {
+	if _, err := fmt.Fprintln(w, "mode: set"); err != nil {
+		return err
+	}
+
+	for _, profile := range profiles {
+		for _, block := range profile.Blocks {
+			count := 0
+			if profile.Covered[block.Pos] {
+				count = 1
+			}
+
+			_, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d 1 %d\n", block.FileName,
+				block.StartLine, block.StartColumn, block.EndLine,
+				block.EndColumn, count)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package cover_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/cover"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok-cover")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "sign.ok")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(`func Sign(n number) number {
+    if n < 0 { return -1 }
+    if n > 0 {
+        return 1
+    }
+
+    return 0
+}
+`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "sign.okt"), []byte(`test "negative" {
+    assert(Sign(-5) == -1)
+}
+`), 0644))
+
+	pkg, errs := compiler.CompilePackage(dir, true)
+	require.Nil(t, errs)
+
+	m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "sign")
+	m.Stdout = ioutil.Discard
+	m.Coverage = map[string]bool{}
+	require.NoError(t, m.RunTests())
+
+	profile, err := cover.NewProfile(pkg.Funcs, []string{fileName}, m.Coverage)
+	require.NoError(t, err)
+
+	assert.Equal(t, 40.0, profile.Percent())
+
+	var buf bytes.Buffer
+	require.NoError(t, cover.WriteProfiles(&buf, []*cover.Profile{profile}))
+	assert.Equal(t, "mode: set\n"+
+		fileName+":2.5,2.16 1 1\n"+
+		fileName+":2.16,2.27 1 1\n"+
+		fileName+":3.5,3.15 1 0\n"+
+		fileName+":4.9,4.17 1 0\n"+
+		fileName+":7.5,7.13 1 0\n", buf.String())
+}
+
+func TestProfile_Percent(t *testing.T) {
+	assert.Equal(t, 0.0, (&cover.Profile{}).Percent())
+}
@@ -0,0 +1,187 @@
+package debugger
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/vm"
+)
+
+const cliHelp = `Commands:
+  c, continue       run until the next breakpoint
+  n, next           step over the current statement
+  s, step           step into the current statement
+  o, out            step out of the current function
+  b, break LOC      set a breakpoint at "file:line" or a function name
+  clear LOC         remove a breakpoint
+  bt, stack         show the stack trace
+  frame N           select a frame from the stack trace
+  l, locals         show the variables in the selected frame
+  parent            show the parent scope ("^") variables
+  r, registers      show the registers in the selected frame
+  p, print NAME     show a variable, "^" variable or "$" register
+  q, quit           stop the program
+  h, help           show this help
+
+An empty line repeats the previous command.
+`
+
+// CLI is an interactive, line-based frontend.
+type CLI struct {
+	in  *bufio.Scanner
+	out io.Writer
+
+	// frame is the index of the selected frame from Debugger.Frames.
+	frame int
+
+	lastCommand string
+	sources     map[string][]string
+}
+
+// NewCLI creates a frontend that reads commands from in.
+func NewCLI(in io.Reader, out io.Writer) *CLI {
+	return &CLI{
+		in:      bufio.NewScanner(in),
+		out:     out,
+		sources: map[string][]string{},
+	}
+}
+
+// Stopped implements Frontend.
+func (cli *CLI) Stopped(d *Debugger, reason string) Action {
+	cli.frame = 0
+	frames := d.Frames()
+	if reason != ReasonStep {
+		fmt.Fprintf(cli.out, "stopped (%s)\n", reason)
+	}
+	cli.showFrame(frames[0])
+
+	for {
+		fmt.Fprint(cli.out, "(debug) ")
+		if !cli.in.Scan() {
+			// There is nothing more to read so let the program finish.
+			fmt.Fprintln(cli.out)
+
+			return Continue
+		}
+
+		command := strings.TrimSpace(cli.in.Text())
+		if command == "" {
+			command = cli.lastCommand
+		}
+		cli.lastCommand = command
+
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			continue
+		}
+		arg := strings.TrimSpace(strings.TrimPrefix(command, fields[0]))
+
+		switch fields[0] {
+		case "c", "continue":
+			return Continue
+
+		case "n", "next":
+			return StepOver
+
+		case "s", "step":
+			return StepInto
+
+		case "o", "out":
+			return StepOut
+
+		case "b", "break":
+			if err := d.SetBreakpoint(arg); err != nil || arg == "" {
+				fmt.Fprintln(cli.out, "usage: break file:line|func")
+			}
+
+		case "clear":
+			if err := d.ClearBreakpoint(arg); err != nil || arg == "" {
+				fmt.Fprintln(cli.out, "usage: clear file:line|func")
+			}
+
+		case "bt", "stack":
+			for i, frame := range frames {
+				marker := " "
+				if i == cli.frame {
+					marker = "*"
+				}
+				fmt.Fprintf(cli.out, "%s %d %s at %s\n", marker, i,
+					frame.FuncName, frame.Pos)
+			}
+
+		case "frame":
+			i, err := strconv.Atoi(arg)
+			if err != nil || i < 0 || i >= len(frames) {
+				fmt.Fprintf(cli.out, "frame must be between 0 and %d\n",
+					len(frames)-1)
+				continue
+			}
+			cli.frame = i
+			cli.showFrame(frames[i])
+
+		case "l", "locals":
+			cli.showVariables(Locals(frames[cli.frame]))
+
+		case "parent":
+			cli.showVariables(ParentScope(frames[cli.frame]))
+
+		case "r", "registers":
+			cli.showVariables(Registers(frames[cli.frame]))
+
+		case "p", "print":
+			if variable, ok := Lookup(frames[cli.frame], arg); ok {
+				cli.showVariables([]Variable{variable})
+			} else {
+				fmt.Fprintf(cli.out, "undefined: %s\n", arg)
+			}
+
+		case "q", "quit":
+			os.Exit(0)
+
+		case "h", "help":
+			fmt.Fprint(cli.out, cliHelp)
+
+		default:
+			fmt.Fprintf(cli.out, "unknown command: %s (try \"help\")\n", fields[0])
+		}
+	}
+}
+
+func (cli *CLI) showFrame(frame *vm.Frame) {
+	fmt.Fprintf(cli.out, "%s in %s\n", frame.Pos, frame.FuncName)
+
+	pos := lexer.ParsePos(frame.Pos)
+	lines := cli.source(pos.FileName)
+	if pos.LineNumber > 0 && pos.LineNumber <= len(lines) {
+		fmt.Fprintf(cli.out, "%5d  %s\n", pos.LineNumber,
+			strings.TrimRight(lines[pos.LineNumber-1], " \t\r"))
+	}
+}
+
+func (cli *CLI) showVariables(variables []Variable) {
+	for _, variable := range variables {
+		fmt.Fprintf(cli.out, "%s %s = %s\n", variable.Name, variable.Type,
+			variable.Value)
+	}
+}
+
+// source returns the lines of a file. Files are only read once.
+func (cli *CLI) source(fileName string) []string {
+	if lines, ok := cli.sources[fileName]; ok {
+		return lines
+	}
+
+	// The source is only used to show the current line. It does not matter if
+	// it cannot be read.
+	data, _ := ioutil.ReadFile(fileName)
+	cli.sources[fileName] = strings.Split(string(data), "\n")
+
+	return cli.sources[fileName]
+}
@@ -0,0 +1,548 @@
+package debugger
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/util"
+	"github.com/elliotchance/ok/vm"
+)
+
+// threadID is used for the only thread. The VM is single threaded.
+const threadID = 1
+
+// Each frame has three scopes. Their variablesReference is the frame ID
+// multiplied by scopesPerFrame, plus the scope.
+const (
+	scopeLocals = iota + 1
+	scopeParent
+	scopeRegisters
+	scopesPerFrame
+)
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+type dapResponse struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Command    string      `json:"command"`
+	Success    bool        `json:"success"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+type dapSource struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type dapStackFrame struct {
+	ID     int        `json:"id"`
+	Name   string     `json:"name"`
+	Source *dapSource `json:"source,omitempty"`
+	Line   int        `json:"line"`
+	Column int        `json:"column"`
+}
+
+type dapScope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+type dapBreakpoint struct {
+	Verified bool `json:"verified"`
+	Line     int  `json:"line,omitempty"`
+}
+
+// DAPServer is a frontend that implements the Debug Adapter Protocol so that
+// the debugger can be used from an editor.
+type DAPServer struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// writeMu is needed because events are sent from the program while it
+	// is running.
+	writeMu sync.Mutex
+	seq     int
+
+	debugger *Debugger
+	program  *compiler.Compiled
+	pkgName  string
+	launched bool
+	running  bool
+
+	// mu protects frames, which is a snapshot of the stack when the program
+	// stopped.
+	mu     sync.Mutex
+	frames []*vm.Frame
+
+	// actions is how the requests are passed to the stopped program. resume
+	// is the action that will be sent after the response to the request.
+	actions chan Action
+	resume  *Action
+}
+
+// NewDAPServer creates a server that reads requests from in and writes
+// responses and events to out.
+func NewDAPServer(in io.Reader, out io.Writer) *DAPServer {
+	s := &DAPServer{
+		in:      bufio.NewReader(in),
+		out:     out,
+		actions: make(chan Action),
+	}
+	s.debugger = New(s)
+
+	return s
+}
+
+// Serve handles requests until the client disconnects or in is closed.
+func (s *DAPServer) Serve() error {
+	for {
+		body, err := util.ReadMessage(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var request dapRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return err
+		}
+
+		result, err := s.handle(request.Command, request.Arguments)
+		s.respond(request, result, err)
+
+		// The program must not continue until the client has received the
+		// response. Otherwise the next "stopped" event could arrive first.
+		if s.resume != nil {
+			s.actions <- *s.resume
+			s.resume = nil
+		}
+
+		switch request.Command {
+		case "initialize":
+			s.event("initialized", nil)
+
+		case "disconnect", "terminate":
+			return nil
+		}
+	}
+}
+
+// Stopped implements Frontend. It is called from the goroutine running the
+// program.
+func (s *DAPServer) Stopped(d *Debugger, reason string) Action {
+	s.mu.Lock()
+	s.frames = d.Frames()
+	s.mu.Unlock()
+
+	s.event("stopped", map[string]interface{}{
+		"reason":            reason,
+		"threadId":          threadID,
+		"allThreadsStopped": true,
+	})
+
+	return <-s.actions
+}
+
+// Write sends the output of the program to the client.
+func (s *DAPServer) Write(p []byte) (int, error) {
+	s.event("output", map[string]interface{}{
+		"category": "stdout",
+		"output":   string(p),
+	})
+
+	return len(p), nil
+}
+
+func (s *DAPServer) handle(command string, arguments json.RawMessage) (interface{}, error) {
+	switch command {
+	case "initialize":
+		return map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsFunctionBreakpoints":      true,
+			"supportsTerminateRequest":         true,
+			"supportsEvaluateForHovers":        true,
+		}, nil
+
+	case "launch":
+		return nil, s.launch(arguments)
+
+	case "setBreakpoints":
+		return s.setBreakpoints(arguments)
+
+	case "setFunctionBreakpoints":
+		return s.setFunctionBreakpoints(arguments)
+
+	case "setExceptionBreakpoints":
+		return map[string]interface{}{"breakpoints": []dapBreakpoint{}}, nil
+
+	case "configurationDone":
+		s.start()
+
+		return nil, nil
+
+	case "threads":
+		return map[string]interface{}{
+			"threads": []map[string]interface{}{
+				{"id": threadID, "name": "main"},
+			},
+		}, nil
+
+	case "stackTrace":
+		return s.stackTrace(), nil
+
+	case "scopes":
+		return s.scopes(arguments)
+
+	case "variables":
+		return s.variables(arguments)
+
+	case "evaluate":
+		return s.evaluate(arguments)
+
+	case "continue":
+		return map[string]interface{}{"allThreadsContinued": true},
+			s.continueWith(Continue)
+
+	case "next":
+		return nil, s.continueWith(StepOver)
+
+	case "stepIn":
+		return nil, s.continueWith(StepInto)
+
+	case "stepOut":
+		return nil, s.continueWith(StepOut)
+
+	case "pause":
+		s.debugger.Pause()
+
+		return nil, nil
+
+	case "disconnect", "terminate":
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unsupported request: %s", command)
+}
+
+func (s *DAPServer) launch(arguments json.RawMessage) error {
+	var args struct {
+		Program     string `json:"program"`
+		StopOnEntry bool   `json:"stopOnEntry"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return err
+	}
+
+	if args.Program == "" {
+		args.Program = "."
+	}
+
+	// The positions must be absolute so that the client can open the files.
+	dir, err := filepath.Abs(args.Program)
+	if err != nil {
+		return err
+	}
+
+	// Editors will often provide the file that is open rather than the
+	// package.
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	program, errs := compiler.CompilePackage(dir, false)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	s.program = program
+	s.pkgName = util.PackageNameFromPath("", dir)
+	s.debugger.StopOnEntry = args.StopOnEntry
+	s.launched = true
+
+	return nil
+}
+
+func (s *DAPServer) setBreakpoints(arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		Source      dapSource `json:"source"`
+		Breakpoints []struct {
+			Line int `json:"line"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+
+	var lines []int
+	breakpoints := []dapBreakpoint{}
+	for _, breakpoint := range args.Breakpoints {
+		lines = append(lines, breakpoint.Line)
+		breakpoints = append(breakpoints, dapBreakpoint{
+			Verified: true,
+			Line:     breakpoint.Line,
+		})
+	}
+	s.debugger.SetLineBreakpoints(args.Source.Path, lines)
+
+	return map[string]interface{}{"breakpoints": breakpoints}, nil
+}
+
+func (s *DAPServer) setFunctionBreakpoints(arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		Breakpoints []struct {
+			Name string `json:"name"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	breakpoints := []dapBreakpoint{}
+	for _, breakpoint := range args.Breakpoints {
+		names = append(names, breakpoint.Name)
+		breakpoints = append(breakpoints, dapBreakpoint{Verified: true})
+	}
+	s.debugger.SetFuncBreakpoints(names)
+
+	return map[string]interface{}{"breakpoints": breakpoints}, nil
+}
+
+// start runs the program in the background once it has been launched and the
+// client has finished configuring breakpoints.
+func (s *DAPServer) start() {
+	if !s.launched || s.running {
+		return
+	}
+	s.running = true
+
+	m := vm.NewVM(s.program.Funcs, s.program.Tests, s.program.Interfaces,
+		s.pkgName)
+	m.Stdout = s
+	m.Stderr = s
+	s.debugger.Attach(m)
+
+	go func() {
+		exitCode := 0
+		if err := m.Run(); err != nil {
+			if err, ok := err.(*vm.RuntimeError); ok {
+				fmt.Fprint(s, err.StackTrace())
+			} else {
+				fmt.Fprintln(s, err)
+			}
+			exitCode = 1
+		}
+
+		s.event("exited", map[string]interface{}{"exitCode": exitCode})
+		s.event("terminated", nil)
+	}()
+}
+
+// continueWith passes the action to the stopped program.
+func (s *DAPServer) continueWith(action Action) error {
+	s.mu.Lock()
+	stopped := s.frames != nil
+	s.frames = nil
+	s.mu.Unlock()
+
+	if !stopped {
+		return errors.New("program is not stopped")
+	}
+
+	s.resume = &action
+
+	return nil
+}
+
+// frame returns the frame for a frame ID. IDs start at 1 so that they are
+// never zero.
+func (s *DAPServer) frame(id int) *vm.Frame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 1 || id > len(s.frames) {
+		return nil
+	}
+
+	return s.frames[id-1]
+}
+
+func (s *DAPServer) stackTrace() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stackFrames := []dapStackFrame{}
+	for i, frame := range s.frames {
+		stackFrame := dapStackFrame{ID: i + 1, Name: frame.FuncName}
+		if pos := lexer.ParsePos(frame.Pos); pos.FileName != "" {
+			stackFrame.Source = &dapSource{
+				Name: filepath.Base(pos.FileName),
+				Path: pos.FileName,
+			}
+			stackFrame.Line = pos.LineNumber
+			stackFrame.Column = pos.CharacterNumber
+		}
+		stackFrames = append(stackFrames, stackFrame)
+	}
+
+	return map[string]interface{}{
+		"stackFrames": stackFrames,
+		"totalFrames": len(stackFrames),
+	}
+}
+
+func (s *DAPServer) scopes(arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		FrameID int `json:"frameId"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+
+	reference := args.FrameID * scopesPerFrame
+
+	return map[string]interface{}{
+		"scopes": []dapScope{
+			{Name: "Locals", VariablesReference: reference + scopeLocals},
+			{Name: "Parent Scope", VariablesReference: reference + scopeParent},
+			{Name: "Registers", VariablesReference: reference + scopeRegisters},
+		},
+	}, nil
+}
+
+func (s *DAPServer) variables(arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		VariablesReference int `json:"variablesReference"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+
+	frame := s.frame(args.VariablesReference / scopesPerFrame)
+	if frame == nil {
+		return nil, errors.New("program is not stopped")
+	}
+
+	var variables []Variable
+	switch args.VariablesReference % scopesPerFrame {
+	case scopeLocals:
+		variables = Locals(frame)
+
+	case scopeParent:
+		variables = ParentScope(frame)
+
+	case scopeRegisters:
+		variables = Registers(frame)
+	}
+
+	results := []dapVariable{}
+	for _, variable := range variables {
+		results = append(results, dapVariable{
+			Name:  variable.Name,
+			Value: variable.Value,
+			Type:  variable.Type,
+		})
+	}
+
+	return map[string]interface{}{"variables": results}, nil
+}
+
+func (s *DAPServer) evaluate(arguments json.RawMessage) (interface{}, error) {
+	var args struct {
+		Expression string `json:"expression"`
+		FrameID    int    `json:"frameId"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+
+	// The innermost frame is used when no frame is provided.
+	if args.FrameID == 0 {
+		args.FrameID = 1
+	}
+
+	frame := s.frame(args.FrameID)
+	if frame == nil {
+		return nil, errors.New("program is not stopped")
+	}
+
+	variable, ok := Lookup(frame, args.Expression)
+	if !ok {
+		return nil, fmt.Errorf("undefined: %s", args.Expression)
+	}
+
+	return map[string]interface{}{
+		"result":             variable.Value,
+		"type":               variable.Type,
+		"variablesReference": 0,
+	}, nil
+}
+
+func (s *DAPServer) respond(request dapRequest, body interface{}, err error) {
+	response := &dapResponse{
+		Type:       "response",
+		RequestSeq: request.Seq,
+		Command:    request.Command,
+		Success:    err == nil,
+		Body:       body,
+	}
+	if err != nil {
+		response.Message = err.Error()
+	}
+
+	s.send(func(seq int) interface{} {
+		response.Seq = seq
+
+		return response
+	})
+}
+
+func (s *DAPServer) event(event string, body interface{}) {
+	s.send(func(seq int) interface{} {
+		return &dapEvent{Seq: seq, Type: "event", Event: event, Body: body}
+	})
+}
+
+// send writes the message returned by msg. Each message needs the next
+// sequence number.
+func (s *DAPServer) send(msg func(seq int) interface{}) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.seq++
+
+	// There is nothing useful that can be done if the client has gone away.
+	_ = util.WriteMessage(s.out, msg(s.seq))
+}
@@ -0,0 +1,174 @@
+package debugger_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elliotchance/ok/debugger"
+	"github.com/elliotchance/ok/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dapMessage struct {
+	Type       string          `json:"type"`
+	Command    string          `json:"command"`
+	RequestSeq int             `json:"request_seq"`
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	Event      string          `json:"event"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// dapClient sends requests to a DAPServer running in the background.
+type dapClient struct {
+	t   *testing.T
+	in  io.Writer
+	out *bufio.Reader
+	seq int
+}
+
+func (c *dapClient) request(command string, arguments interface{}) dapMessage {
+	c.seq++
+	require.NoError(c.t, util.WriteMessage(c.in, map[string]interface{}{
+		"seq":       c.seq,
+		"type":      "request",
+		"command":   command,
+		"arguments": arguments,
+	}))
+
+	response := c.next("response")
+	assert.Equal(c.t, c.seq, response.RequestSeq)
+	assert.Equal(c.t, command, response.Command)
+
+	return response
+}
+
+// next returns the next message of the type ("response" or an event name).
+// Any output events are skipped.
+func (c *dapClient) next(ty string) dapMessage {
+	for {
+		body, err := util.ReadMessage(c.out)
+		require.NoError(c.t, err)
+
+		var msg dapMessage
+		require.NoError(c.t, json.Unmarshal(body, &msg))
+
+		if msg.Type == ty || msg.Event == ty {
+			return msg
+		}
+
+		if msg.Event != "output" {
+			c.t.Fatalf("expected %s but got %s", ty, body)
+		}
+	}
+}
+
+func body(t *testing.T, msg dapMessage) map[string]interface{} {
+	var v map[string]interface{}
+	require.NoError(t, json.Unmarshal(msg.Body, &v))
+
+	return v
+}
+
+func TestDAPServer(t *testing.T) {
+	dir := writeProgram(t)
+	defer os.RemoveAll(dir)
+
+	inReader, inWriter := io.Pipe()
+	outReader, outWriter := io.Pipe()
+	done := make(chan error)
+	go func() {
+		done <- debugger.NewDAPServer(inReader, outWriter).Serve()
+	}()
+
+	c := &dapClient{t: t, in: inWriter, out: bufio.NewReader(outReader)}
+
+	response := c.request("initialize", map[string]interface{}{})
+	assert.True(t, response.Success)
+	c.next("initialized")
+
+	response = c.request("launch", map[string]interface{}{"program": dir})
+	require.True(t, response.Success, response.Message)
+
+	fileName := filepath.Join(dir, "main.ok")
+	response = c.request("setBreakpoints", map[string]interface{}{
+		"source":      map[string]interface{}{"path": fileName},
+		"breakpoints": []map[string]interface{}{{"line": 3}},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"breakpoints": []interface{}{
+			map[string]interface{}{"verified": true, "line": 3.0},
+		},
+	}, body(t, response))
+
+	c.request("configurationDone", nil)
+	assert.Equal(t, "breakpoint", body(t, c.next("stopped"))["reason"])
+
+	response = c.request("stackTrace", map[string]interface{}{"threadId": 1})
+	assert.Equal(t, map[string]interface{}{
+		"stackFrames": []interface{}{
+			map[string]interface{}{
+				"id": 1.0, "name": "double", "line": 3.0, "column": 5.0,
+				"source": map[string]interface{}{
+					"name": "main.ok", "path": fileName,
+				},
+			},
+			map[string]interface{}{
+				"id": 2.0, "name": "main", "line": 11.0, "column": 5.0,
+				"source": map[string]interface{}{
+					"name": "main.ok", "path": fileName,
+				},
+			},
+		},
+		"totalFrames": 2.0,
+	}, body(t, response))
+
+	response = c.request("scopes", map[string]interface{}{"frameId": 2})
+	scopes := body(t, response)["scopes"].([]interface{})
+	assert.Len(t, scopes, 3)
+	locals := scopes[0].(map[string]interface{})
+	assert.Equal(t, "Locals", locals["name"])
+
+	response = c.request("variables", map[string]interface{}{
+		"variablesReference": locals["variablesReference"],
+	})
+	assert.Equal(t, map[string]interface{}{
+		"variables": []interface{}{
+			map[string]interface{}{
+				"name": "a", "type": "number", "value": "3",
+				"variablesReference": 0.0,
+			},
+			map[string]interface{}{
+				"name": "f", "type": "func() number", "value": "func 1",
+				"variablesReference": 0.0,
+			},
+		},
+	}, body(t, response))
+
+	response = c.request("evaluate", map[string]interface{}{"expression": "y"})
+	assert.Equal(t, "6", body(t, response)["result"])
+
+	response = c.request("evaluate", map[string]interface{}{"expression": "z"})
+	assert.False(t, response.Success)
+	assert.Equal(t, "undefined: z", response.Message)
+
+	response = c.request("stepOut", map[string]interface{}{"threadId": 1})
+	assert.True(t, response.Success)
+	assert.Equal(t, "step", body(t, c.next("stopped"))["reason"])
+
+	c.request("continue", map[string]interface{}{"threadId": 1})
+	assert.Equal(t, 0.0, body(t, c.next("exited"))["exitCode"])
+	c.next("terminated")
+
+	response = c.request("foo", nil)
+	assert.False(t, response.Success)
+	assert.Equal(t, "unsupported request: foo", response.Message)
+
+	c.request("disconnect", nil)
+	assert.NoError(t, <-done)
+}
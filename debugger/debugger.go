@@ -0,0 +1,254 @@
+package debugger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/vm"
+)
+
+// Action tells the debugger what to do after it has stopped.
+type Action int
+
+const (
+	// Continue runs until the next breakpoint.
+	Continue Action = iota
+
+	// StepOver stops at the next statement in the same function, or the
+	// function that called it.
+	StepOver
+
+	// StepInto stops at the next statement, even if it is inside a function
+	// that is called by the current statement.
+	StepInto
+
+	// StepOut stops at the next statement after the current function returns.
+	StepOut
+)
+
+// Reasons for stopping. These are passed to Frontend.Stopped.
+const (
+	ReasonEntry              = "entry"
+	ReasonStep               = "step"
+	ReasonBreakpoint         = "breakpoint"
+	ReasonFunctionBreakpoint = "function breakpoint"
+	ReasonPause              = "pause"
+)
+
+// Frontend is the user interface for the debugger.
+type Frontend interface {
+	// Stopped is called when the program has stopped at a statement. The
+	// program does not continue until Stopped returns.
+	Stopped(d *Debugger, reason string) Action
+}
+
+// Debugger implements vm.Debugger. It stops the program at breakpoints or
+// after stepping and hands control to a Frontend.
+type Debugger struct {
+	// StopOnEntry will stop at the first statement of the program.
+	StopOnEntry bool
+
+	frontend Frontend
+	vm       *vm.VM
+
+	// mu protects the breakpoints and pause because they can be changed by
+	// the frontend while the program is running.
+	mu sync.Mutex
+
+	// lines contains the line breakpoints for each file.
+	lines map[string]map[int]bool
+
+	// funcs contains the names of functions to stop at when they are entered.
+	funcs map[string]bool
+
+	pause bool
+
+	// action is the last action returned from the frontend and depth is the
+	// number of frames when it was returned.
+	action  Action
+	depth   int
+	started bool
+
+	// prevDepth is the number of frames at the previous statement. It is used
+	// to detect when a function has been entered.
+	prevDepth int
+}
+
+// New creates a debugger that will report to frontend. It must be attached to
+// the VM with Attach.
+func New(frontend Frontend) *Debugger {
+	return &Debugger{
+		frontend: frontend,
+		lines:    map[string]map[int]bool{},
+		funcs:    map[string]bool{},
+		action:   Continue,
+	}
+}
+
+// Attach sets the debugger for m.
+func (d *Debugger) Attach(m *vm.VM) {
+	d.vm = m
+	m.Debugger = d
+}
+
+// SetBreakpoint adds a breakpoint. The location may be in the form "file:line"
+// or the name of a function.
+func (d *Debugger) SetBreakpoint(location string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fileName, line, isLine, err := parseLocation(location)
+	if err != nil {
+		return err
+	}
+
+	if isLine {
+		if d.lines[fileName] == nil {
+			d.lines[fileName] = map[int]bool{}
+		}
+		d.lines[fileName][line] = true
+	} else {
+		d.funcs[location] = true
+	}
+
+	return nil
+}
+
+// ClearBreakpoint removes a breakpoint that was added with SetBreakpoint.
+func (d *Debugger) ClearBreakpoint(location string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fileName, line, isLine, err := parseLocation(location)
+	if err != nil {
+		return err
+	}
+
+	if isLine {
+		delete(d.lines[fileName], line)
+	} else {
+		delete(d.funcs, location)
+	}
+
+	return nil
+}
+
+// SetLineBreakpoints replaces all of the line breakpoints for a file.
+func (d *Debugger) SetLineBreakpoints(fileName string, lines []int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lines[fileName] = map[int]bool{}
+	for _, line := range lines {
+		d.lines[fileName][line] = true
+	}
+}
+
+// SetFuncBreakpoints replaces all of the function breakpoints.
+func (d *Debugger) SetFuncBreakpoints(names []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.funcs = map[string]bool{}
+	for _, name := range names {
+		d.funcs[name] = true
+	}
+}
+
+// Pause will stop at the next statement.
+func (d *Debugger) Pause() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pause = true
+}
+
+// Frames returns the functions that are currently running. The first element
+// is the innermost function. It is only safe to call from Frontend.Stopped.
+func (d *Debugger) Frames() []*vm.Frame {
+	var frames []*vm.Frame
+	for i := len(d.vm.Frames) - 1; i >= 0; i-- {
+		frames = append(frames, d.vm.Frames[i])
+	}
+
+	return frames
+}
+
+// BeforeStatement implements vm.Debugger.
+func (d *Debugger) BeforeStatement(m *vm.VM) {
+	depth := len(m.Frames)
+	reason := d.reason(m.Frames[depth-1], depth)
+	d.prevDepth = depth
+
+	if reason == "" {
+		return
+	}
+
+	d.action = d.frontend.Stopped(d, reason)
+	d.depth = depth
+}
+
+// reason returns why the debugger should stop at the current statement, or an
+// empty string if it should not stop.
+func (d *Debugger) reason(frame *vm.Frame, depth int) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.started {
+		d.started = true
+		if d.StopOnEntry {
+			return ReasonEntry
+		}
+	}
+
+	if d.pause {
+		d.pause = false
+
+		return ReasonPause
+	}
+
+	switch {
+	case d.action == StepInto,
+		d.action == StepOver && depth <= d.depth,
+		d.action == StepOut && depth < d.depth:
+		return ReasonStep
+	}
+
+	if d.funcs[frame.FuncName] && depth > d.prevDepth {
+		return ReasonFunctionBreakpoint
+	}
+
+	pos := lexer.ParsePos(frame.Pos)
+	for fileName, lines := range d.lines {
+		if lines[pos.LineNumber] && sameFile(pos.FileName, fileName) {
+			return ReasonBreakpoint
+		}
+	}
+
+	return ""
+}
+
+// sameFile allows breakpoints to be set with a path that is shorter than the
+// one used by the compiler.
+func sameFile(fileName, breakpoint string) bool {
+	return fileName == breakpoint ||
+		strings.HasSuffix(fileName, "/"+breakpoint) ||
+		strings.HasSuffix(breakpoint, "/"+fileName)
+}
+
+func parseLocation(location string) (fileName string, line int, isLine bool, err error) {
+	i := strings.LastIndex(location, ":")
+	if i < 0 {
+		return "", 0, false, nil
+	}
+
+	line, err = strconv.Atoi(location[i+1:])
+	if err != nil || line < 1 {
+		return "", 0, false, fmt.Errorf("invalid breakpoint: %s", location)
+	}
+
+	return location[:i], line, true, nil
+}
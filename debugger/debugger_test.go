@@ -0,0 +1,258 @@
+package debugger_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/debugger"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const program = `func double(x number) number {
+    y = x * 2
+    return y
+}
+
+func main() {
+    a = 3
+    f = func() number {
+        return ^a + 1
+    }
+    b = double(a)
+    print(b)
+    print(f())
+}
+`
+
+// script is a frontend that records where it stopped and replies with the
+// next action.
+type script struct {
+	actions []debugger.Action
+	stops   []string
+	inspect func(frame *vm.Frame) string
+}
+
+func (s *script) Stopped(d *debugger.Debugger, reason string) debugger.Action {
+	frame := d.Frames()[0]
+	stop := fmt.Sprintf("%s %s %s", reason, frame.FuncName,
+		strings.TrimPrefix(frame.Pos, "main.ok:"))
+	if s.inspect != nil {
+		stop += " " + s.inspect(frame)
+	}
+	s.stops = append(s.stops, stop)
+
+	if len(s.actions) == 0 {
+		return debugger.Continue
+	}
+
+	action := s.actions[0]
+	s.actions = s.actions[1:]
+
+	return action
+}
+
+// writeProgram creates a package containing main.ok. The directory must be
+// removed.
+func writeProgram(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "ok-debug")
+	require.NoError(t, err)
+
+	fileName := filepath.Join(dir, "main.ok")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(program), 0644))
+
+	return dir
+}
+
+func run(t *testing.T, d *debugger.Debugger) string {
+	dir := writeProgram(t)
+	defer os.RemoveAll(dir)
+
+	// Positions will be relative to the package so they are the same for each
+	// test.
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(cwd)
+
+	pkg, errs := compiler.CompilePackage(".", false)
+	require.Empty(t, errs)
+
+	var out bytes.Buffer
+	m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+	m.Stdout = &out
+	d.Attach(m)
+	require.NoError(t, m.Run())
+
+	return out.String()
+}
+
+func TestDebugger(t *testing.T) {
+	for testName, test := range map[string]struct {
+		stopOnEntry bool
+		breakpoints []string
+		actions     []debugger.Action
+		expected    []string
+	}{
+		"no-breakpoints": {},
+		"entry": {
+			stopOnEntry: true,
+			expected:    []string{"entry main 7:5"},
+		},
+		"step-over": {
+			stopOnEntry: true,
+			actions: []debugger.Action{
+				debugger.StepOver, debugger.StepOver, debugger.StepOver,
+			},
+			expected: []string{
+				"entry main 7:5", "step main 8:5", "step main 11:5",
+				"step main 12:5",
+			},
+		},
+		"step-into": {
+			breakpoints: []string{"main.ok:11"},
+			actions: []debugger.Action{
+				debugger.StepInto, debugger.StepInto, debugger.StepInto,
+			},
+			expected: []string{
+				"breakpoint main 11:5", "step double 2:5", "step double 3:5",
+				"step main 12:5",
+			},
+		},
+		"step-into-closure": {
+			breakpoints: []string{"main.ok:13"},
+			actions:     []debugger.Action{debugger.StepInto},
+			expected:    []string{"breakpoint main 13:5", "step 1 9:9"},
+		},
+		"step-out": {
+			breakpoints: []string{"main.ok:2"},
+			actions:     []debugger.Action{debugger.StepOut},
+			expected:    []string{"breakpoint double 2:5", "step main 12:5"},
+		},
+		"function-breakpoint": {
+			breakpoints: []string{"double"},
+			expected:    []string{"function breakpoint double 2:5"},
+		},
+		"breakpoint-full-path": {
+			breakpoints: []string{"/some/dir/main.ok:12"},
+			expected:    []string{"breakpoint main 12:5"},
+		},
+		"breakpoint-other-file": {
+			breakpoints: []string{"other.ok:12"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			frontend := &script{actions: test.actions}
+			d := debugger.New(frontend)
+			d.StopOnEntry = test.stopOnEntry
+			for _, breakpoint := range test.breakpoints {
+				require.NoError(t, d.SetBreakpoint(breakpoint))
+			}
+
+			assert.Equal(t, "6\n4\n", run(t, d))
+			assert.Equal(t, test.expected, frontend.stops)
+		})
+	}
+}
+
+func TestDebugger_ClearBreakpoint(t *testing.T) {
+	frontend := &script{}
+	d := debugger.New(frontend)
+	require.NoError(t, d.SetBreakpoint("main.ok:12"))
+	require.NoError(t, d.SetBreakpoint("double"))
+	require.NoError(t, d.ClearBreakpoint("main.ok:12"))
+	require.NoError(t, d.ClearBreakpoint("double"))
+
+	run(t, d)
+	assert.Empty(t, frontend.stops)
+}
+
+func TestDebugger_SetBreakpointInvalid(t *testing.T) {
+	d := debugger.New(&script{})
+	assert.EqualError(t, d.SetBreakpoint("main.ok:foo"),
+		"invalid breakpoint: main.ok:foo")
+	assert.EqualError(t, d.SetBreakpoint("main.ok:0"),
+		"invalid breakpoint: main.ok:0")
+}
+
+func TestDebugger_Variables(t *testing.T) {
+	show := func(variables []debugger.Variable) string {
+		var s []string
+		for _, v := range variables {
+			s = append(s, fmt.Sprintf("%s:%s=%s", v.Name, v.Type, v.Value))
+		}
+
+		return strings.Join(s, ",")
+	}
+
+	frontend := &script{
+		inspect: func(frame *vm.Frame) string {
+			return fmt.Sprintf("[%s] [%s] [%s]", show(debugger.Locals(frame)),
+				show(debugger.ParentScope(frame)),
+				show(debugger.Registers(frame)))
+		},
+	}
+	d := debugger.New(frontend)
+	require.NoError(t, d.SetBreakpoint("main.ok:3"))
+	require.NoError(t, d.SetBreakpoint("main.ok:9"))
+	run(t, d)
+
+	assert.Equal(t, []string{
+		"breakpoint double 3:5 [x:number=3,y:number=6] [] [$2:number=2,$3:number=6]",
+		"breakpoint 1 9:9 [] [^a:number=3,^b:number=6,^f:func() number=func 1] []",
+	}, frontend.stops)
+}
+
+func TestLookup(t *testing.T) {
+	var found []string
+	frontend := &script{
+		inspect: func(frame *vm.Frame) string {
+			for _, name := range []string{"x", "^a", "$3", "z", ""} {
+				if v, ok := debugger.Lookup(frame, name); ok {
+					found = append(found, v.Name+"="+v.Value)
+				}
+			}
+
+			return ""
+		},
+	}
+	d := debugger.New(frontend)
+	require.NoError(t, d.SetBreakpoint("main.ok:3"))
+	require.NoError(t, d.SetBreakpoint("main.ok:9"))
+	run(t, d)
+
+	assert.Equal(t, []string{"x=3", "$3=6", "^a=3"}, found)
+}
+
+func TestCLI(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("n\n\nl\nbt\nframe 1\np b\nb main.ok:3\nc\nfoo\nc\n")
+	d := debugger.New(debugger.NewCLI(in, &out))
+	d.StopOnEntry = true
+	run(t, d)
+
+	assert.Equal(t, `stopped (entry)
+main.ok:7:5 in main
+    7      a = 3
+(debug) main.ok:8:5 in main
+    8      f = func() number {
+(debug) main.ok:11:5 in main
+   11      b = double(a)
+(debug) a number = 3
+f func() number = func 1
+(debug) * 0 main at main.ok:11:5
+(debug) frame must be between 0 and 0
+(debug) undefined: b
+(debug) (debug) stopped (breakpoint)
+main.ok:3:5 in double
+    3      return y
+(debug) unknown command: foo (try "help")
+(debug) `, out.String())
+}
@@ -0,0 +1,124 @@
+package debugger
+
+import (
+	"sort"
+
+	"github.com/elliotchance/ok/vm"
+)
+
+// Variable is a value that can be inspected when the program is stopped.
+type Variable struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// Locals returns the named variables in the frame, sorted by name.
+func Locals(frame *vm.Frame) []Variable {
+	if frame.Func == nil || frame.Scope == nil {
+		return nil
+	}
+
+	var variables []Variable
+	for name, register := range frame.Func.Locals {
+		// Variables that have not been set yet are not shown.
+		if value := frame.Scope.Get(register); name[0] != '^' && value != nil {
+			variables = append(variables, newVariable(name, value))
+		}
+	}
+
+	return sortVariables(variables)
+}
+
+// ParentScope returns the variables that the function can access through "^".
+func ParentScope(frame *vm.Frame) []Variable {
+	if frame.Scope == nil {
+		return nil
+	}
+
+	state := frame.Scope.Registers[vm.StateRegister]
+	if state == nil || state.Map[vm.StateKey] == nil {
+		return nil
+	}
+
+	var variables []Variable
+	for name, value := range state.Map[vm.StateKey].Map {
+		if name != vm.StateKey {
+			variables = append(variables, newVariable("^"+name, value))
+		}
+	}
+
+	return sortVariables(variables)
+}
+
+// Registers returns the temporary registers in the frame, in numerical order.
+// Registers that belong to variables are not included.
+func Registers(frame *vm.Frame) []Variable {
+	if frame.Scope == nil {
+		return nil
+	}
+
+	isVariable := map[vm.Register]bool{}
+	if frame.Func != nil {
+		for _, register := range frame.Func.Locals {
+			isVariable[register] = true
+		}
+	}
+
+	var variables []Variable
+	for i, value := range frame.Scope.Registers {
+		register := vm.Register(i)
+		if register != vm.StateRegister && !isVariable[register] && value != nil {
+			variables = append(variables, newVariable(register.String(), value))
+		}
+	}
+
+	return variables
+}
+
+// Lookup finds a variable in the frame. The name may be a variable, a parent
+// scope variable ("^a") or a register ("$1").
+func Lookup(frame *vm.Frame, name string) (Variable, bool) {
+	var variables []Variable
+	switch {
+	case name == "":
+		return Variable{}, false
+
+	case name[0] == '^':
+		variables = ParentScope(frame)
+
+	case name[0] == '$':
+		variables = Registers(frame)
+
+	default:
+		variables = Locals(frame)
+	}
+
+	for _, variable := range variables {
+		if variable.Name == name {
+			return variable, true
+		}
+	}
+
+	return Variable{}, false
+}
+
+func newVariable(name string, value *vm.Value) Variable {
+	if value == nil {
+		return Variable{Name: name}
+	}
+
+	return Variable{
+		Name:  name,
+		Type:  value.Kind,
+		Value: vm.RenderValue(value, true),
+	}
+}
+
+func sortVariables(variables []Variable) []Variable {
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+
+	return variables
+}
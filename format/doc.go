@@ -0,0 +1,7 @@
+// Package format implements the canonical formatting of ok source code. It is
+// used by "ok fmt".
+//
+// The source is parsed into the ast structures and printed back out. Since the
+// ast does not retain comments they are collected separately by the lexer and
+// interleaved with the printed code based on their original positions.
+package format
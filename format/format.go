@@ -0,0 +1,779 @@
+package format
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/parser"
+)
+
+// Indent is used for each level of nesting.
+const Indent = "    "
+
+// Source returns the canonically formatted version of a single file. If the
+// file cannot be parsed the parser errors are returned.
+func Source(src, fileName string) (string, []error) {
+	p := parser.ParseString(src, fileName)
+	if errs := p.Errors(); len(errs) > 0 {
+		return "", errs
+	}
+
+	// The parser discards comments from the token stream, so we need to lex the
+	// file again to find out where they are.
+	tokens, _, err := lexer.TokenizeString(src, lexer.Options{
+		IncludeComments: true,
+	}, fileName)
+	if err != nil {
+		return "", []error{err}
+	}
+
+	pr := newPrinter(src, p.File.Tokens, tokens)
+	pr.file(p)
+
+	return pr.String(), nil
+}
+
+// Expr returns the canonical source of a single expression. It is used where
+// the original source is not available, such as in the message of a failed
+// assertion.
+func Expr(n ast.Node) string {
+	p := newPrinter("", nil, nil)
+	p.expr(n)
+
+	return p.String()
+}
+
+type decl struct {
+	// start is the offset of the first token.
+	start int
+	node  ast.Node
+
+	// name is only used for constants.
+	name string
+}
+
+func (p *printer) file(parsed *parser.Parser) {
+	var decls []decl
+
+	for i, tok := range p.tokens {
+		if tok.Kind == lexer.TokenImport {
+			decls = append(decls, decl{
+				start: i,
+				node: &ast.Import{
+					PackageName: p.tokens[i+1].Value,
+					Pos:         tok.Pos.String(),
+				},
+			})
+		}
+	}
+
+	for name, value := range parsed.Constants {
+		// The literal is preceded by the name and "=".
+		decls = append(decls, decl{
+			start: p.offset(value.Pos) - 2,
+			node:  value,
+			name:  name,
+		})
+	}
+
+	for _, fn := range parsed.File.Funcs {
+		decls = append(decls, decl{start: p.offset(fn.Pos), node: fn})
+	}
+
+	for _, t := range parsed.File.Tests {
+		decls = append(decls, decl{start: p.offset(t.Pos), node: t})
+	}
+
+	for _, b := range parsed.File.Benches {
+		decls = append(decls, decl{start: p.offset(b.Pos), node: b})
+	}
+
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].start < decls[j].start
+	})
+
+	for i, d := range decls {
+		// The last token of a declaration is always the one before the next
+		// declaration. The final token is always the EOF.
+		end := len(p.tokens) - 2
+		if i < len(decls)-1 {
+			end = decls[i+1].start - 1
+		}
+
+		// Imports are always separated from the rest of the file, and each
+		// function or test will always be surrounded by empty lines.
+		_, isImport := d.node.(*ast.Import)
+		if i > 0 {
+			_, wasImport := decls[i-1].node.(*ast.Import)
+			if isImport != wasImport {
+				p.needBlank = true
+			}
+		}
+
+		line := p.tokens[d.start].Pos.LineNumber
+		p.flushComments(line)
+		p.blankLineBefore(line)
+
+		switch n := d.node.(type) {
+		case *ast.Import:
+			p.write("import ")
+			p.write(quote(n.PackageName, '"'))
+			p.newline(line)
+
+		case *ast.Literal:
+			// The tab aligns the "=" of consecutive constants.
+			p.write(d.name + "\t= ")
+			p.expr(n)
+			p.newline(line)
+
+		case *ast.Func:
+			p.fn(n.Name, n, end)
+			p.newline(p.line(end))
+			p.needBlank = true
+
+		case *ast.Test:
+			if len(n.Arguments) > 0 {
+				p.write("fuzz ")
+				p.write(quote(n.Name, '"'))
+				p.write(" ")
+				p.arguments(&ast.Func{Arguments: n.Arguments, Pos: n.Pos})
+			} else {
+				p.write("test ")
+				p.write(quote(n.Name, '"'))
+			}
+			if n.Cases != nil {
+				p.write(" for ")
+				p.expr(n.Cases)
+			}
+			p.write(" ")
+			p.block(n.Statements, end)
+			p.newline(p.line(end))
+			p.needBlank = true
+
+		case *ast.Bench:
+			p.write("bench ")
+			p.write(quote(n.Name, '"'))
+			p.write(" ")
+			p.block(n.Statements, end)
+			p.newline(p.line(end))
+			p.needBlank = true
+		}
+	}
+
+	p.flushComments(maxLine)
+}
+
+// stmt writes a complete statement, including the new line. end is the offset
+// of the last token in the statement, or -1 if it is not known.
+func (p *printer) stmt(n ast.Node, end int) {
+	p.writeIndent()
+
+	switch n := n.(type) {
+	case *ast.Assign:
+		// Nested functions are converted to assignments by the parser. We can
+		// tell them apart because the variable will not have a position.
+		if fn, ok := n.Rights[0].(*ast.Func); ok && n.Lefts[0].Position() == "" {
+			p.fn(n.Lefts[0].(*ast.Identifier).Name, fn, end)
+			break
+		}
+
+		p.expr(n)
+
+	case *ast.If:
+		p.write("if ")
+		p.expr(n.Condition)
+		p.write(" ")
+
+		// The parser does not keep an else block that has no statements. It
+		// must still be written when it contains comments, otherwise they
+		// would be moved outside of the if.
+		if n.False == nil && !p.hasEmptyElse(end) {
+			p.block(n.True, end)
+			break
+		}
+
+		// The "}" of the true block is before "else {" and the first token of
+		// the false block.
+		trueEnd := -1
+		if len(n.False) > 0 {
+			if offset := p.firstOffset(n.False); offset >= 0 {
+				trueEnd = offset - 3
+			}
+		} else if end >= 0 {
+			trueEnd = end - 3
+		}
+
+		p.block(n.True, trueEnd)
+		p.write(" else ")
+		p.block(n.False, end)
+
+	case *ast.For:
+		p.write("for ")
+		if n.Init != nil {
+			p.expr(n.Init)
+			p.write("; ")
+			p.expr(n.Condition)
+			p.write("; ")
+			p.expr(n.Next)
+			p.write(" ")
+		} else if n.Condition != nil {
+			p.expr(n.Condition)
+			p.write(" ")
+		}
+		p.block(n.Statements, end)
+
+	case *ast.Switch:
+		p.write("switch ")
+		if n.Expr != nil {
+			p.expr(n.Expr)
+			p.write(" ")
+		}
+		p.write("{")
+		p.newline(p.line(p.offset(n.Pos)))
+
+		// Like an if, an else block that only contains comments must be kept.
+		hasElse := n.Else != nil || (end >= 0 && p.hasEmptyElse(end-1))
+
+		p.indent++
+		for i, c := range n.Cases {
+			// Each case block finishes just before the next case. The last
+			// block has to be found by looking inside it.
+			caseEnd := -1
+			if i < len(n.Cases)-1 {
+				caseEnd = p.offset(n.Cases[i+1].Pos) - 1
+			} else if !hasElse && end >= 0 {
+				caseEnd = end - 1
+			} else if len(n.Else) > 0 {
+				// The "}" of the case is before "else {" and the first token
+				// of the else block.
+				if offset := p.firstOffset(n.Else); offset >= 0 {
+					caseEnd = offset - 3
+				}
+			} else if end >= 0 {
+				caseEnd = end - 4
+			}
+
+			line := p.line(p.offset(c.Pos))
+			p.flushComments(line)
+			p.blankLineBefore(line)
+			p.writeIndent()
+			p.write("case ")
+			p.exprs(c.Conditions)
+			p.write(" ")
+			p.block(c.Statements, caseEnd)
+			p.newline(p.line(caseEnd))
+		}
+
+		if hasElse {
+			elseEnd := -1
+			if end >= 0 {
+				elseEnd = end - 1
+			}
+
+			p.writeIndent()
+			p.write("else ")
+			p.block(n.Else, elseEnd)
+			p.newline(p.line(elseEnd))
+		}
+
+		p.flushComments(p.line(end))
+		p.indent--
+		p.writeIndent()
+		p.write("}")
+
+	case *ast.ErrorScope:
+		// Each block finishes just before the keyword of the next block.
+		var starts []int
+		for _, on := range n.On {
+			starts = append(starts, p.offset(on.Pos))
+		}
+		if n.Finally != nil {
+			starts = append(starts, p.offset(n.Finally.Pos))
+		}
+		blockEnd := func(i int) int {
+			if i < len(starts) {
+				if starts[i] < 0 {
+					return -1
+				}
+
+				return starts[i] - 1
+			}
+
+			return end
+		}
+
+		p.write("try ")
+		p.block(n.Statements, blockEnd(0))
+
+		for i, on := range n.On {
+			p.write(" on " + on.Type + " ")
+			p.block(on.Statements, blockEnd(i+1))
+		}
+
+		if n.Finally != nil {
+			p.write(" finally ")
+			p.block(n.Finally.Statements, end)
+		}
+
+	default:
+		p.expr(n)
+	}
+
+	line := p.line(end)
+	if line == 0 {
+		line = p.line(p.offset(position(n)))
+	}
+	p.newline(line)
+}
+
+// block writes a block of statements surrounded by curly brackets. The closing
+// bracket is not followed by a new line. end is the offset of the closing
+// bracket, or -1 if it's not known.
+func (p *printer) block(stmts []ast.Node, end int) {
+	// Nested functions are hoisted by the parser. We need to put them back to
+	// where they were.
+	stmts = append([]ast.Node{}, stmts...)
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return p.offset(position(stmts[i])) < p.offset(position(stmts[j]))
+	})
+
+	// The end is easy to find by looking after the last statement. Otherwise
+	// we rely on what the caller has provided.
+	if len(stmts) > 0 {
+		end = p.closingBracket(p.offset(position(stmts[len(stmts)-1])))
+	}
+	endLine := p.line(end)
+
+	if len(stmts) == 0 && !p.hasCommentsBefore(endLine) {
+		p.write("{}")
+		return
+	}
+
+	// The opening bracket is always the token before the first statement.
+	open := end - 1
+	if len(stmts) > 0 {
+		open = p.offset(position(stmts[0])) - 1
+	}
+
+	p.write("{")
+	p.newline(p.line(open))
+	p.startOfBlock = true
+	p.indent++
+
+	for i, stmt := range stmts {
+		// Like with declarations, each statement finishes on the token before
+		// the next one.
+		stmtEnd := -1
+		if i < len(stmts)-1 {
+			if next := p.offset(position(stmts[i+1])); next >= 0 {
+				stmtEnd = next - 1
+			}
+		} else if end >= 0 {
+			stmtEnd = end - 1
+		}
+
+		line := p.line(p.offset(position(stmt)))
+		p.flushComments(line)
+		p.blankLineBefore(line)
+		p.stmt(stmt, stmtEnd)
+	}
+
+	p.flushComments(endLine)
+	p.indent--
+	p.writeIndent()
+	p.write("}")
+}
+
+// fn writes a function signature and body. It's used for package-level and
+// nested functions as well as function literals. The name will be empty for
+// function literals.
+func (p *printer) fn(name string, fn *ast.Func, end int) {
+	p.write("func")
+	if name != "" {
+		p.write(" " + name)
+	}
+
+	p.arguments(fn)
+
+	switch {
+	case len(fn.Returns) == 0:
+
+	// A map would be confused with the start of the function body.
+	case len(fn.Returns) == 1 && !strings.HasPrefix(fn.Returns[0], "{}"):
+		p.write(" " + fn.Returns[0])
+
+	default:
+		p.write(" (" + strings.Join(fn.Returns, ", ") + ")")
+	}
+
+	p.write(" ")
+	p.block(fn.Statements, end)
+}
+
+// arguments writes the function arguments. Arguments that share a type may
+// have been declared together, like "a, b number". We have to go back to the
+// tokens to know which style was used.
+func (p *printer) arguments(fn *ast.Func) {
+	var groups [][]*ast.Argument
+	grouped := p.groupedArguments(fn)
+	for i, arg := range fn.Arguments {
+		if i > 0 && grouped[i-1] {
+			groups[len(groups)-1] = append(groups[len(groups)-1], arg)
+		} else {
+			groups = append(groups, []*ast.Argument{arg})
+		}
+	}
+
+	var args []string
+	for _, group := range groups {
+		var names []string
+		for _, arg := range group {
+			names = append(names, arg.Name)
+		}
+
+		args = append(args,
+			strings.Join(names, ", ")+" "+group[len(group)-1].Type)
+	}
+
+	p.list("(", args, ")", p.isMultiline(fn.Pos, lexer.TokenParenOpen, ""))
+}
+
+// groupedArguments returns if each of the arguments shares its type with the
+// next argument.
+func (p *printer) groupedArguments(fn *ast.Func) []bool {
+	grouped := make([]bool, len(fn.Arguments))
+
+	offset := p.opening(fn.Pos, lexer.TokenParenOpen, "")
+	if offset < 0 {
+		return grouped
+	}
+
+	var found []bool
+	depth := 0
+	for i := offset; i < len(p.tokens); i++ {
+		switch p.tokens[i].Kind {
+		case lexer.TokenParenOpen:
+			depth++
+		case lexer.TokenParenClose:
+			depth--
+		case lexer.TokenIdentifier:
+			previous := p.tokens[i-1].Kind
+			if depth == 1 &&
+				(previous == lexer.TokenParenOpen || previous == lexer.TokenComma) {
+				found = append(found, p.tokens[i+1].Kind == lexer.TokenComma)
+			}
+		}
+
+		if depth == 0 {
+			break
+		}
+	}
+
+	// This should not happen, but it's safer to print them all individually.
+	if len(found) != len(fn.Arguments) {
+		return grouped
+	}
+
+	return found
+}
+
+// list writes a comma separated list. If multiline is true, each item is
+// placed on its own line.
+func (p *printer) list(open string, items []string, close string, multiline bool) {
+	if !multiline || len(items) == 0 {
+		p.write(open + strings.Join(items, ", ") + close)
+		return
+	}
+
+	p.write(open + "\n")
+	p.indent++
+	for i, item := range items {
+		p.writeIndent()
+		p.write(item)
+		if i < len(items)-1 {
+			p.write(",")
+		}
+		p.write("\n")
+	}
+	p.indent--
+	p.writeIndent()
+	p.write(close)
+}
+
+func (p *printer) exprs(nodes []ast.Node) {
+	for i, node := range nodes {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.expr(node)
+	}
+}
+
+// exprList is like list, except the items may contain function literals that
+// must be written with the correct indentation.
+func (p *printer) exprList(open string, nodes []ast.Node, close string, multiline bool) {
+	if !multiline || len(nodes) == 0 {
+		p.write(open)
+		p.exprs(nodes)
+		p.write(close)
+		return
+	}
+
+	p.write(open + "\n")
+	p.indent++
+	for i, node := range nodes {
+		p.writeIndent()
+		p.expr(node)
+		if i < len(nodes)-1 {
+			p.write(",")
+		}
+		p.write("\n")
+	}
+	p.indent--
+	p.writeIndent()
+	p.write(close)
+}
+
+var typeNames = map[string]bool{
+	lexer.TokenAny:    true,
+	lexer.TokenBool:   true,
+	lexer.TokenChar:   true,
+	lexer.TokenData:   true,
+	lexer.TokenNumber: true,
+	lexer.TokenString: true,
+}
+
+// expr writes anything that appears on a single line, this includes simple
+// statements.
+func (p *printer) expr(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Literal:
+		switch n.Kind {
+		case "string":
+			p.write(quote(n.Value, '"'))
+		case "char":
+			p.write(quote(n.Value, '\''))
+		case "data":
+			p.write(quote(n.Value, '`'))
+		default:
+			p.write(n.Value)
+		}
+
+	case *ast.Interpolate:
+		p.write(`"`)
+		for _, part := range n.Parts {
+			switch part := part.(type) {
+			case *ast.Group:
+				p.write("{")
+				p.expr(part.Expr)
+				p.write("}")
+
+			case *ast.Literal:
+				s := quote(part.Value, '"')
+				p.write(s[1 : len(s)-1])
+			}
+		}
+		p.write(`"`)
+
+	case *ast.Identifier:
+		p.write(n.Name)
+
+	case *ast.Binary:
+		p.expr(n.Left)
+		p.write(" " + n.Op + " ")
+		p.expr(n.Right)
+
+	case *ast.Unary:
+		p.write(n.Op)
+		if n.Op == lexer.TokenNot {
+			p.write(" ")
+		}
+		p.expr(n.Expr)
+
+	case *ast.Group:
+		p.write("(")
+		p.expr(n.Expr)
+		p.write(")")
+
+	case *ast.Call:
+		// Casting a value looks like a call, but is actually a type followed by
+		// a single expression.
+		if typeNames[n.FunctionName] && len(n.Arguments) == 1 {
+			p.write(n.FunctionName)
+			if _, ok := n.Arguments[0].(*ast.Group); !ok {
+				p.write(" ")
+			}
+			p.expr(n.Arguments[0])
+			break
+		}
+
+		p.write(n.FunctionName)
+		p.exprList("(", n.Arguments, ")",
+			p.isMultiline(n.Pos, lexer.TokenParenOpen, ""))
+
+	case *ast.Array:
+		p.kind(n.Kind, n.Pos)
+		p.exprList("[", n.Elements, "]",
+			p.isMultiline(n.Pos, lexer.TokenSquareOpen, lexer.TokenSquareClose))
+
+	case *ast.Map:
+		p.kind(n.Kind, n.Pos)
+		var elements []ast.Node
+		for _, element := range n.Elements {
+			elements = append(elements, element)
+		}
+		p.exprList("{", elements, "}",
+			p.isMultiline(n.Pos, lexer.TokenCurlyOpen, lexer.TokenCurlyClose))
+
+	case *ast.KeyValue:
+		p.expr(n.Key)
+		p.write(": ")
+		p.expr(n.Value)
+
+	case *ast.Key:
+		p.expr(n.Expr)
+
+		// Properties are converted into a string key by the parser. We can
+		// tell them apart because they have no position.
+		if key, ok := n.Key.(*ast.Literal); ok && key.Kind == "string" &&
+			key.Pos == "" {
+			p.write("." + key.Value)
+			break
+		}
+
+		p.write("[")
+		p.expr(n.Key)
+		p.write("]")
+
+	case *ast.Func:
+		p.fn("", n, -1)
+
+	case *ast.Assign:
+		p.exprs(n.Lefts)
+		p.write(" = ")
+		p.exprs(n.Rights)
+
+	case *ast.In:
+		p.write(n.Value)
+		if n.Key != "" {
+			p.write(", " + n.Key)
+		}
+		p.write(" in ")
+		p.expr(n.Expr)
+
+	case *ast.Return:
+		p.write("return")
+		if len(n.Exprs) > 0 {
+			p.write(" ")
+			p.exprs(n.Exprs)
+		}
+
+	case *ast.Raise:
+		p.write("raise ")
+		p.expr(n.Err)
+
+	case *ast.Assert:
+		p.write("assert(")
+		p.expr(n.Expr)
+		p.assertMessage(n.Message)
+		p.write(")")
+
+	case *ast.AssertRaise:
+		p.write("assert(")
+		p.expr(n.Expr)
+		p.write(" raise " + n.Type)
+		p.assertMessage(n.Message)
+		p.write(")")
+
+	case *ast.Break:
+		p.write("break")
+
+	case *ast.Continue:
+		p.write("continue")
+	}
+}
+
+func (p *printer) assertMessage(message ast.Node) {
+	if message != nil {
+		p.write(", ")
+		p.expr(message)
+	}
+}
+
+// kind writes the optional type in front of an array or map. The parser removes
+// "any" because it's redundant, but we do not want to change the source.
+func (p *printer) kind(kind, pos string) {
+	if kind == "" {
+		if offset := p.offset(pos); offset >= 0 &&
+			p.tokens[offset].Kind == lexer.TokenAny {
+			kind = lexer.TokenAny
+		}
+	}
+
+	if kind != "" {
+		p.write(kind + " ")
+	}
+}
+
+var escapes = map[rune]string{
+	'\a': `\a`,
+	'\b': `\b`,
+	'\f': `\f`,
+	'\n': `\n`,
+	'\r': `\r`,
+	'\t': `\t`,
+	'\v': `\v`,
+	'\\': `\\`,
+}
+
+// quote is the opposite of the lexer reading a quoted literal.
+func quote(s string, q rune) string {
+	var b strings.Builder
+	b.WriteRune(q)
+	for _, c := range s {
+		switch {
+		case escapes[c] != "":
+			b.WriteString(escapes[c])
+
+		case c == '"' && q == '"':
+			b.WriteString(`\"`)
+
+		// A literal "{" can only appear in a string if it was escaped,
+		// otherwise it would have been an interpolation.
+		case c == '{' && q == '"':
+			b.WriteString(`\{`)
+
+		default:
+			b.WriteRune(c)
+		}
+	}
+	b.WriteRune(q)
+
+	return b.String()
+}
+
+// position returns the position of the first token of a statement.
+func position(n ast.Node) string {
+	// Nested functions are converted to an assignment by the parser, but the
+	// variable has no position.
+	if assign, ok := n.(*ast.Assign); ok && assign.Lefts[0].Position() == "" {
+		return assign.Rights[0].Position()
+	}
+
+	return n.Position()
+}
+
+// firstOffset returns the offset of the statement that appears first in the
+// source.
+func (p *printer) firstOffset(stmts []ast.Node) int {
+	first := p.offset(position(stmts[0]))
+	for _, stmt := range stmts[1:] {
+		if offset := p.offset(position(stmt)); offset < first {
+			first = offset
+		}
+	}
+
+	return first
+}
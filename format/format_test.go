@@ -0,0 +1,206 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/format"
+	"github.com/elliotchance/ok/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src      string
+		expected string
+	}{
+		"empty-func": {
+			"func main() {\n}",
+			"func main() {}\n",
+		},
+		"indent": {
+			"func main() {\nprint(1+2)\n  a=3\n}",
+			"func main() {\n    print(1 + 2)\n    a = 3\n}\n",
+		},
+		"blank-lines": {
+			"func main() {\n\n    a = 1\n\n\n    b = 2\n\n}\n",
+			"func main() {\n    a = 1\n\n    b = 2\n}\n",
+		},
+		"funcs-separated": {
+			"func a() {}\nfunc b() {}\ntest \"c\" {}\n",
+			"func a() {}\n\nfunc b() {}\n\ntest \"c\" {}\n",
+		},
+		"imports": {
+			"import \"math\"\nimport \"strings\"\nfunc main() {}\n",
+			"import \"math\"\nimport \"strings\"\n\nfunc main() {}\n",
+		},
+		"constants": {
+			"A = 1 // one\nFoo = \"bar\"\n\nB = true\n",
+			"A   = 1 // one\nFoo = \"bar\"\n\nB = true\n",
+		},
+		"comments": {
+			"// Doc.\nfunc main() {\n    // okie\n    print(\"hi\") // Even here!\n    // dokie\n}\n\n// End.",
+			"// Doc.\nfunc main() {\n    // okie\n    print(\"hi\") // Even here!\n    // dokie\n}\n\n// End.\n",
+		},
+		"trailing-comments": {
+			"func main() {\n    a = 123 // a\n    b = 4 // b\n}\n",
+			"func main() {\n    a = 123 // a\n    b = 4   // b\n}\n",
+		},
+		"comment-in-empty-block": {
+			"func main() {\n    if true {\n        // todo\n    }\n}\n",
+			"func main() {\n    if true {\n        // todo\n    }\n}\n",
+		},
+		"comment-at-end-of-block": {
+			"func main() {\n    if true {\n        a = 1\n        // a\n    }\n    // b\n}\n",
+			"func main() {\n    if true {\n        a = 1\n        // a\n    }\n    // b\n}\n",
+		},
+		"map-return": {
+			"func a() ({}string) {}\nfunc b() (number) {}\n",
+			"func a() ({}string) {}\n\nfunc b() number {}\n",
+		},
+		"nested-func-order": {
+			"func main() {\n    a = 1\n    func b() {\n        print(a)\n    }\n}\n",
+			"func main() {\n    a = 1\n    func b() {\n        print(a)\n    }\n}\n",
+		},
+		"func-literal": {
+			"func main() {\n    f = func (a, b number, c string) (number, string) {\n        return a, c\n    }\n}\n",
+			"func main() {\n    f = func(a, b number, c string) (number, string) {\n        return a, c\n    }\n}\n",
+		},
+		"if-else": {
+			"func main() {\n    if a {\n        b()\n    } else {\n        c()\n    }\n}\n",
+			"func main() {\n    if a {\n        b()\n    } else {\n        c()\n    }\n}\n",
+		},
+		"if-else-comment": {
+			"func main() {\n    if a {\n        b()\n    } else {\n        // note\n    }\n    c()\n}\n",
+			"func main() {\n    if a {\n        b()\n    } else {\n        // note\n    }\n    c()\n}\n",
+		},
+		"if-else-empty": {
+			"func main() {\n    if a {\n        b()\n    } else {}\n}\n",
+			"func main() {\n    if a {\n        b()\n    }\n}\n",
+		},
+		"for": {
+			"func main() {\n    for {}\n    for i < 3 {}\n    for i = 0; i < 3; ++i {}\n    for v, k in m {}\n}\n",
+			"func main() {\n    for {}\n    for i < 3 {}\n    for i = 0; i < 3; ++i {}\n    for v, k in m {}\n}\n",
+		},
+		"switch": {
+			"func main() {\n    switch a {\n        case 1, 2 {\n            b()\n        }\n        else {\n            c()\n        }\n    }\n}\n",
+			"func main() {\n    switch a {\n        case 1, 2 {\n            b()\n        }\n        else {\n            c()\n        }\n    }\n}\n",
+		},
+		"switch-else-comment": {
+			"func main() {\n    switch {\n        case x == 1 {\n            b()\n        }\n        else {\n            // nothing\n        }\n    }\n}\n",
+			"func main() {\n    switch {\n        case x == 1 {\n            b()\n        }\n        else {\n            // nothing\n        }\n    }\n}\n",
+		},
+		"switch-comment-blocks": {
+			"func main() {\n    switch x {\n        case 1 {\n            // one\n        }\n        else {\n            // nothing\n        }\n    }\n}\n",
+			"func main() {\n    switch x {\n        case 1 {\n            // one\n        }\n        else {\n            // nothing\n        }\n    }\n}\n",
+		},
+		"switch-comment-case": {
+			"func main() {\n    switch x {\n        case 1 {\n            // one\n        }\n        else {\n            b()\n        }\n    }\n}\n",
+			"func main() {\n    switch x {\n        case 1 {\n            // one\n        }\n        else {\n            b()\n        }\n    }\n}\n",
+		},
+		"try": {
+			"func main() {\n    try {\n        a()\n    } on MyError {\n        b()\n    } on Error {\n    } finally {\n        c()\n    }\n}\n",
+			"func main() {\n    try {\n        a()\n    } on MyError {\n        b()\n    } on Error {} finally {\n        c()\n    }\n}\n",
+		},
+		"literals": {
+			"func main() {\n    print(\"a\\\"b\\n\", 'c', `d`, true, 1.50, -3)\n}\n",
+			"func main() {\n    print(\"a\\\"b\\n\", 'c', `d`, true, 1.50, -3)\n}\n",
+		},
+		"interpolate": {
+			"func main() {\n    print(\"a { b+1 } \\{c}\")\n}\n",
+			"func main() {\n    print(\"a {b + 1} \\{c}\")\n}\n",
+		},
+		"arrays-and-maps": {
+			"func main() {\n    a = []number [1,2]\n    b = any [1]\n    c = {}string {}\n    d = {\"a\":a[0], \"b\":x.y}\n}\n",
+			"func main() {\n    a = []number [1, 2]\n    b = any [1]\n    c = {}string {}\n    d = {\"a\": a[0], \"b\": x.y}\n}\n",
+		},
+		"multiline": {
+			"func Foo(\n    A number,\n    B, C string\n) Foo {}\n\nfunc main() {\n    a = Foo(\n    1,\n    \"b\", \"c\")\n}\n",
+			"func Foo(\n    A number,\n    B, C string\n) Foo {}\n\nfunc main() {\n    a = Foo(\n        1,\n        \"b\",\n        \"c\"\n    )\n}\n",
+		},
+		"unary-and-casts": {
+			"func main() {\n    print(not a, -b, number c, string(d), char (e+1))\n    ++^f\n}\n",
+			"func main() {\n    print(not a, -b, number c, string(d), char(e + 1))\n    ++^f\n}\n",
+		},
+		"statements": {
+			"func main() {\n    raise Error(\"a\")\n    return\n    return 1, 2\n    break\n    continue\n    a, b = c()\n    d += 1\n}\n",
+			"func main() {\n    raise Error(\"a\")\n    return\n    return 1, 2\n    break\n    continue\n    a, b = c()\n    d += 1\n}\n",
+		},
+		"test": {
+			"test \"a b\" {\nassert(1==1)\n}",
+			"test \"a b\" {\n    assert(1 == 1)\n}\n",
+		},
+		"bench": {
+			"bench \"a b\"   {\nf()\n}\ntest \"c\" {}",
+			"bench \"a b\" {\n    f()\n}\n\ntest \"c\" {}\n",
+		},
+		"fuzz": {
+			"fuzz \"a\"(s,t string,n []number) {\nassert(len(s)>=0)\n}",
+			"fuzz \"a\" (s, t string, n []number) {\n    assert(len(s) >= 0)\n}\n",
+		},
+		"test-cases": {
+			"test \"a\"   for  c,k  in [1,2] {\nassert(c==k+1)\n}",
+			"test \"a\" for c, k in [1, 2] {\n    assert(c == k + 1)\n}\n",
+		},
+		"assert-forms": {
+			"test \"a\" {\nassert(a==1,\"why\")\nassert(f()   raise   MyError)\nassert(f(1) raise Error ,  \"b\")\n}",
+			"test \"a\" {\n    assert(a == 1, \"why\")\n    assert(f() raise MyError)\n    assert(f(1) raise Error, \"b\")\n}\n",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			actual, errs := format.Source(test.src, "a.ok")
+			require.Nil(t, errs)
+			assert.Equal(t, test.expected, actual)
+
+			// Formatting must be stable.
+			again, errs := format.Source(actual, "a.ok")
+			require.Nil(t, errs)
+			assert.Equal(t, actual, again)
+		})
+	}
+}
+
+func TestSource_Errors(t *testing.T) {
+	_, errs := format.Source("func main() {", "a.ok")
+	assert.NotEmpty(t, errs)
+}
+
+func TestExpr(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src      string
+		expected string
+	}{
+		"call": {
+			"foo(  1,2 )",
+			"foo(1, 2)",
+		},
+		"multiline-call": {
+			"foo(\n1,\n2)",
+			"foo(1, 2)",
+		},
+		"key": {
+			"a[ \"c\" ]",
+			`a["c"]`,
+		},
+		"property": {
+			"a.b",
+			"a.b",
+		},
+		"interpolate": {
+			`"a{b+1}c"`,
+			`"a{b + 1}c"`,
+		},
+		"func": {
+			"func() { a() }",
+			"func() {\n    a()\n}",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			p := parser.ParseString("func main() {\nx = "+test.src+"\n}", "a.ok")
+			require.Nil(t, p.Errors())
+			assign := p.File.Funcs["main"].Statements[0].(*ast.Assign)
+			assert.Equal(t, test.expected, format.Expr(assign.Rights[0]))
+		})
+	}
+}
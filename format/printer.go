@@ -0,0 +1,246 @@
+package format
+
+import (
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/elliotchance/ok/lexer"
+)
+
+// maxLine is used to flush all remaining comments.
+const maxLine = math.MaxInt32
+
+type printer struct {
+	out    strings.Builder
+	indent int
+
+	// lines is the original source. It's used to preserve empty lines.
+	lines []string
+
+	// tokens are the tokens seen by the parser (without comments). offsets maps
+	// the position of each token back to its index in tokens.
+	tokens  []lexer.Token
+	offsets map[string]int
+
+	// comments are waiting to be written, in the order they appear in the
+	// source. codeLines contains every line that has a token that isn't a
+	// comment.
+	comments  []lexer.Token
+	codeLines map[int]bool
+
+	// startOfBlock prevents empty lines being written directly after an opening
+	// bracket. needBlank will force the next empty line.
+	startOfBlock, needBlank bool
+}
+
+func newPrinter(src string, tokens, tokensWithComments []lexer.Token) *printer {
+	p := &printer{
+		lines:        strings.Split(src, "\n"),
+		tokens:       tokens,
+		offsets:      map[string]int{},
+		codeLines:    map[int]bool{},
+		startOfBlock: true,
+	}
+
+	for i, tok := range tokens {
+		// Interpolated strings contain tokens that share a position. We only
+		// care about the first one.
+		pos := tok.Pos.String()
+		if _, ok := p.offsets[pos]; !ok {
+			p.offsets[pos] = i
+		}
+
+		if tok.Kind != lexer.TokenEOF {
+			p.codeLines[tok.Pos.LineNumber] = true
+		}
+	}
+
+	for _, tok := range tokensWithComments {
+		if tok.Kind == lexer.TokenComment {
+			p.comments = append(p.comments, tok)
+		}
+	}
+
+	return p
+}
+
+// offset returns the index of the token for a position, or -1 if it's not
+// known.
+func (p *printer) offset(pos string) int {
+	if offset, ok := p.offsets[pos]; ok {
+		return offset
+	}
+
+	return -1
+}
+
+// line returns the line number for a token offset, or 0 if the offset is not
+// valid.
+func (p *printer) line(offset int) int {
+	if offset < 0 || offset >= len(p.tokens) {
+		return 0
+	}
+
+	return p.tokens[offset].Pos.LineNumber
+}
+
+// closingBracket finds the "}" that closes the block that contains the token at
+// offset.
+func (p *printer) closingBracket(offset int) int {
+	if offset < 0 {
+		return -1
+	}
+
+	depth := 0
+	for i := offset; i < len(p.tokens); i++ {
+		switch p.tokens[i].Kind {
+		case lexer.TokenCurlyOpen:
+			depth++
+
+		case lexer.TokenCurlyClose:
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// opening returns the offset of the first open token after pos. If skip is
+// provided then an open token that is immediately followed by skip is ignored.
+// This is needed for types like "[]number" in front of arrays.
+func (p *printer) opening(pos, open, skip string) int {
+	offset := p.offset(pos)
+	if offset < 0 {
+		return -1
+	}
+
+	for i := offset; i < len(p.tokens)-1; i++ {
+		if p.tokens[i].Kind == open && p.tokens[i+1].Kind != skip {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isMultiline returns true if the elements following the open token started on
+// a new line in the original source.
+func (p *printer) isMultiline(pos, open, skip string) bool {
+	offset := p.opening(pos, open, skip)
+	if offset < 0 {
+		return false
+	}
+
+	return p.line(offset+1) > p.line(offset)
+}
+
+// hasCommentsBefore returns true if there are comments waiting to be written
+// before line.
+func (p *printer) hasCommentsBefore(line int) bool {
+	return len(p.comments) > 0 && p.comments[0].Pos.LineNumber < line
+}
+
+// hasEmptyElse returns true if end is the "}" of an "else" block that contains
+// only comments.
+func (p *printer) hasEmptyElse(end int) bool {
+	if end < 2 || p.tokens[end-2].Kind != lexer.TokenElse {
+		return false
+	}
+
+	first, last := p.line(end-1), p.line(end)
+	for _, comment := range p.comments {
+		if line := comment.Pos.LineNumber; line >= first && line < last {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flushComments writes all the comments that appear before line on their own
+// lines.
+func (p *printer) flushComments(line int) {
+	for p.hasCommentsBefore(line) {
+		comment := p.comments[0]
+		p.comments = p.comments[1:]
+
+		p.blankLineBefore(comment.Pos.LineNumber)
+		for _, s := range strings.Split(comment.Value, "\n") {
+			p.writeIndent()
+			p.writeComment(s)
+			p.write("\n")
+		}
+	}
+}
+
+// blankLineBefore writes an empty line if there was one before line in the
+// original source.
+func (p *printer) blankLineBefore(line int) {
+	isBlank := line >= 2 && line-2 < len(p.lines) &&
+		strings.TrimSpace(p.lines[line-2]) == ""
+
+	if !p.startOfBlock && (isBlank || p.needBlank) {
+		p.write("\n")
+	}
+
+	p.startOfBlock = false
+	p.needBlank = false
+}
+
+// newline finishes the current line. If there is a comment on the same source
+// line it will be appended. Comments that continue onto following lines are
+// written after.
+func (p *printer) newline(line int) {
+	if line > 0 && len(p.comments) > 0 &&
+		p.comments[0].Pos.LineNumber == line && p.codeLines[line] {
+		comment := p.comments[0]
+		p.comments = p.comments[1:]
+
+		// The tab aligns the comments on consecutive lines.
+		lines := strings.Split(comment.Value, "\n")
+		p.write("\t")
+		p.writeComment(lines[0])
+		p.write("\n")
+		for _, s := range lines[1:] {
+			p.writeIndent()
+			p.writeComment(s)
+			p.write("\n")
+		}
+
+		return
+	}
+
+	p.write("\n")
+}
+
+// writeComment escapes the comment so that any tabs it contains are not
+// treated as cells to be aligned.
+func (p *printer) writeComment(s string) {
+	escape := string([]byte{tabwriter.Escape})
+	p.write(escape + "//" + s + escape)
+}
+
+// String returns the written source. Cells separated by tabs on consecutive
+// lines will be aligned.
+func (p *printer) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 8, 1, ' ', tabwriter.StripEscape)
+
+	// Neither of these can fail when writing to a strings.Builder.
+	_, _ = w.Write([]byte(p.out.String()))
+	_ = w.Flush()
+
+	return b.String()
+}
+
+func (p *printer) write(s string) {
+	p.out.WriteString(s)
+}
+
+func (p *printer) writeIndent() {
+	p.write(strings.Repeat(Indent, p.indent))
+}
@@ -6,6 +6,5 @@ require (
 	github.com/cockroachdb/apd/v2 v2.0.2
 	github.com/google/go-cmp v0.5.0
 	github.com/lib/pq v1.7.0 // indirect
-	github.com/pkg/errors v0.8.0
 	github.com/stretchr/testify v1.6.0
 )
@@ -1,6 +1,10 @@
 package lexer
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Pos describes the position of a token.
 type Pos struct {
@@ -23,3 +27,35 @@ func (pos *Pos) nextLine() {
 func (pos *Pos) String() string {
 	return fmt.Sprintf("%s:%d:%d", pos.FileName, pos.LineNumber, pos.CharacterNumber)
 }
+
+// ParsePos is the inverse of Pos.String. Positions that cannot be parsed (such
+// as the empty string used for nodes created internally by the parser) will
+// return a zero Pos.
+func ParsePos(s string) Pos {
+	// File names may contain colons, so we have to work backwards.
+	colon2 := strings.LastIndex(s, ":")
+	if colon2 < 0 {
+		return Pos{}
+	}
+
+	colon1 := strings.LastIndex(s[:colon2], ":")
+	if colon1 < 0 {
+		return Pos{}
+	}
+
+	line, err := strconv.Atoi(s[colon1+1 : colon2])
+	if err != nil {
+		return Pos{}
+	}
+
+	character, err := strconv.Atoi(s[colon2+1:])
+	if err != nil {
+		return Pos{}
+	}
+
+	return Pos{
+		FileName:        s[:colon1],
+		LineNumber:      line,
+		CharacterNumber: character,
+	}
+}
@@ -0,0 +1,28 @@
+package lexer_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePos(t *testing.T) {
+	for testName, test := range map[string]struct {
+		s        string
+		expected lexer.Pos
+	}{
+		"empty":       {"", lexer.Pos{}},
+		"no-file":     {":3:7", lexer.Pos{FileName: "", LineNumber: 3, CharacterNumber: 7}},
+		"file":        {"main.ok:12:5", lexer.Pos{FileName: "main.ok", LineNumber: 12, CharacterNumber: 5}},
+		"dir":         {"a/b/main.ok:1:1", lexer.Pos{FileName: "a/b/main.ok", LineNumber: 1, CharacterNumber: 1}},
+		"colon-file":  {"c:/main.ok:2:3", lexer.Pos{FileName: "c:/main.ok", LineNumber: 2, CharacterNumber: 3}},
+		"bad-line":    {"main.ok:x:3", lexer.Pos{}},
+		"bad-char":    {"main.ok:3:x", lexer.Pos{}},
+		"only-number": {"3", lexer.Pos{}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, lexer.ParsePos(test.s))
+		})
+	}
+}
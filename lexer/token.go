@@ -21,6 +21,7 @@ const (
 	TokenAnd      = "and"
 	TokenAny      = "any"
 	TokenAssert   = "assert"
+	TokenBench    = "bench"
 	TokenBool     = "bool"
 	TokenBreak    = "break"
 	TokenCase     = "case"
@@ -31,6 +32,7 @@ const (
 	TokenFinally  = "finally"
 	TokenFor      = "for"
 	TokenFunc     = "func"
+	TokenFuzz     = "fuzz"
 	TokenIf       = "if"
 	TokenImport   = "import"
 	TokenIn       = "in"
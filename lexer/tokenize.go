@@ -388,7 +388,7 @@ func tokenWord(word string, pos Pos) Token {
 		"break", "case", "continue", "else", "if", "for", "switch", "in",
 
 		// Testing
-		"test", "assert",
+		"test", "bench", "fuzz", "assert",
 
 		// Types
 		"any", "bool", "char", "data", "number", "string",
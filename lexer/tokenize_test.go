@@ -948,6 +948,20 @@ func TestTokenizeString(t *testing.T) {
 				{lexer.TokenEOF, "", false, pos(5)},
 			},
 		},
+		"bench": {
+			str: `bench`,
+			expected: []lexer.Token{
+				{lexer.TokenBench, "bench", false, pos(1)},
+				{lexer.TokenEOF, "", false, pos(6)},
+			},
+		},
+		"fuzz": {
+			str: `fuzz`,
+			expected: []lexer.Token{
+				{lexer.TokenFuzz, "fuzz", false, pos(1)},
+				{lexer.TokenEOF, "", false, pos(5)},
+			},
+		},
 		"assert": {
 			str: `assert`,
 			expected: []lexer.Token{
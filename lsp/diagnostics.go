@@ -0,0 +1,126 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/util"
+)
+
+// compileDiagnostics compiles the package in dir (including tests). Errors that
+// have no position are reported against fallback.
+func (s *Server) compileDiagnostics(dir, fallback string) (diagnostics map[string][]Diagnostic) {
+	// The compiler is not always tolerant of broken code. A panic should not
+	// bring down the server.
+	defer func() {
+		if r := recover(); r != nil {
+			diagnostics = s.diagnostics([]error{
+				fmt.Errorf("internal compiler error: %v", r),
+			}, fallback)
+		}
+	}()
+
+	_, errs := compiler.CompilePackage(dir, true)
+
+	return s.diagnostics(errs, fallback)
+}
+
+// parseDiagnostics only parses a single file. This is much faster than
+// compiling the whole package so it is used while the document is being edited.
+func (s *Server) parseDiagnostics(fileName string) map[string][]Diagnostic {
+	p, err := parse(s.source(fileName), fileName)
+	if err != nil {
+		return s.diagnostics([]error{err}, fileName)
+	}
+
+	return s.diagnostics(p.Errors(), fileName)
+}
+
+// parse is the same as parser.ParseString except that a panic is returned as
+// an error. Broken code is very common while the document is being edited.
+func parse(src, fileName string) (p *parser.Parser, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p, err = nil, fmt.Errorf("internal parser error: %v", r)
+		}
+	}()
+
+	return parser.ParseString(src, fileName), nil
+}
+
+func (s *Server) diagnostics(errs []error, fallback string) map[string][]Diagnostic {
+	diagnostics := map[string][]Diagnostic{}
+	for _, err := range errs {
+		fileName, diagnostic := s.diagnostic(err, fallback)
+		diagnostics[fileName] = append(diagnostics[fileName], diagnostic)
+	}
+
+	return diagnostics
+}
+
+// diagnostic converts an error into a diagnostic. Errors from the parser and
+// compiler are a *util.Diagnostic. Any other error may still start with the
+// position, like "main.ok:3:5 undefined variable: a".
+func (s *Server) diagnostic(err error, fallback string) (string, Diagnostic) {
+	d, ok := err.(*util.Diagnostic)
+	if !ok {
+		d = &util.Diagnostic{
+			Severity: util.SeverityError,
+			Message:  err.Error(),
+		}
+
+		parts := strings.SplitN(d.Message, " ", 2)
+		if pos := lexer.ParsePos(parts[0]); pos.LineNumber > 0 && len(parts) == 2 {
+			d.File, d.Line, d.Column = pos.FileName, pos.LineNumber,
+				pos.CharacterNumber
+			d.Message = parts[1]
+		}
+	}
+
+	fileName := fallback
+	var start Position
+	if d.Line > 0 {
+		fileName, _ = filepath.Abs(d.File)
+
+		// Diagnostics use one-based lines and columns.
+		start = Position{
+			Line:      d.Line - 1,
+			Character: d.Column - 1,
+		}
+		if start.Character < 0 {
+			start.Character = 0
+		}
+	}
+
+	line := lineAt(s.source(fileName), start.Line)
+	length := util.TokenLength(line, start.Character)
+	if d.EndLine == d.Line && d.EndColumn > d.Column {
+		length = d.EndColumn - d.Column
+	}
+	if length == 0 {
+		length = 1
+	}
+
+	end := Position{
+		Line:      start.Line,
+		Character: toUTF16(line, start.Character+length),
+	}
+	start.Character = toUTF16(line, start.Character)
+
+	severity := DiagnosticSeverityError
+	if d.Severity == util.SeverityWarning {
+		severity = DiagnosticSeverityWarning
+	}
+
+	return fileName, Diagnostic{
+		Range:    Range{Start: start, End: end},
+		Severity: severity,
+		Code:     d.Code,
+		Source:   "ok",
+		Message:  d.Message,
+	}
+}
@@ -0,0 +1,7 @@
+// Package lsp implements a Language Server Protocol server for ok. It is used
+// by "ok lsp" and communicates with the editor over stdin and stdout.
+//
+// Only the small subset of the protocol needed for diagnostics, hover,
+// go-to-definition and completion is implemented. See
+// https://microsoft.github.io/language-server-protocol/specification
+package lsp
@@ -0,0 +1,191 @@
+package lsp
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/util"
+	"github.com/elliotchance/ok/vm"
+)
+
+// symbol is a function or constant declared in the package (or one of the
+// packages it imports).
+type symbol struct {
+	fn       *ast.Func
+	constant *ast.Literal
+
+	// doc is the comment attached to the function.
+	doc string
+
+	// pos is the position of the name of the declaration.
+	pos lexer.Pos
+}
+
+// index contains the declarations for a package. Names are qualified the same
+// way as the compiler (see compiler.CompilePackage) so imported functions will
+// be in the form "pkg.Name".
+type index struct {
+	symbols map[string]*symbol
+
+	// packages are the names of all imported packages, including the builtin
+	// ones.
+	packages map[string]bool
+}
+
+// buildIndex parses all the files in dir. Open documents are used in place of
+// the files on disk so the index reflects any unsaved changes.
+func (s *Server) buildIndex(dir string) *index {
+	idx := &index{
+		symbols:  map[string]*symbol{},
+		packages: map[string]bool{},
+	}
+
+	// Errors are ignored because we want to index as much as possible. They
+	// will be reported through diagnostics.
+	fileNames, _ := util.GetAllOKFilesInPath(dir, true)
+	seen := map[string]bool{}
+	for len(fileNames) > 0 {
+		fileName := fileNames[0]
+		fileNames = fileNames[1:]
+
+		prefix := ""
+		if fileNameDir := path.Clean(path.Dir(fileName)); fileNameDir != dir {
+			prefix = filepath.Base(fileNameDir) + "."
+		}
+
+		p, err := parse(s.source(fileName), fileName)
+		if err != nil {
+			continue
+		}
+		idx.add(p, prefix)
+
+		for pkg := range p.File.Imports {
+			idx.packages[pkg] = true
+			if vm.Packages[pkg] || seen[pkg] {
+				continue
+			}
+
+			seen[pkg] = true
+			newFileNames, _ := util.GetAllOKFilesInPath(path.Join(dir, pkg), false)
+			fileNames = append(fileNames, newFileNames...)
+		}
+	}
+
+	return idx
+}
+
+func (idx *index) add(p *parser.Parser, prefix string) {
+	offsets := map[string]int{}
+	for i, tok := range p.File.Tokens {
+		if _, ok := offsets[tok.Pos.String()]; !ok {
+			offsets[tok.Pos.String()] = i
+		}
+	}
+
+	// nameAt returns the position of the token with the expected name, relative
+	// to the token at pos.
+	nameAt := func(pos string, delta int, name string) lexer.Pos {
+		if offset, ok := offsets[pos]; ok {
+			offset += delta
+			if offset >= 0 && offset < len(p.File.Tokens) &&
+				p.File.Tokens[offset].Value == name {
+				return p.File.Tokens[offset].Pos
+			}
+		}
+
+		return lexer.ParsePos(pos)
+	}
+
+	for name, fn := range p.File.Funcs {
+		var doc []string
+		for _, comment := range p.File.Comments {
+			if comment.Func == name {
+				doc = append(doc, comment.String())
+			}
+		}
+
+		idx.symbols[prefix+name] = &symbol{
+			fn:  fn,
+			doc: strings.Join(doc, "\n"),
+			pos: nameAt(fn.Pos, 1, name),
+		}
+	}
+
+	for name, constant := range p.Constants {
+		idx.symbols[prefix+name] = &symbol{
+			constant: constant,
+			pos:      nameAt(constant.Pos, -2, name),
+		}
+	}
+}
+
+// names returns the sorted names of all declarations in the package, as well
+// as the builtin functions.
+func (idx *index) names() []string {
+	unique := map[string]bool{}
+	for name := range idx.symbols {
+		unique[name] = true
+	}
+
+	// Private functions in the library do not have a definition because they
+	// cannot be used outside of their package.
+	for name, def := range vm.Lib {
+		if def.FuncDef != nil {
+			unique[name] = true
+		}
+	}
+
+	for name := range vm.Constants {
+		unique[name] = true
+	}
+
+	var names []string
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// lookup finds a function or constant by name. Declarations in the package
+// take precedence over the builtin library.
+func (idx *index) lookup(name string) *symbol {
+	if sym, ok := idx.symbols[name]; ok {
+		return sym
+	}
+
+	if def, ok := vm.Lib[name]; ok && def.FuncDef != nil {
+		return &symbol{fn: def.FuncDef}
+	}
+
+	if constant, ok := vm.Constants[name]; ok {
+		return &symbol{constant: constant}
+	}
+
+	return nil
+}
+
+// signature is the code shown when hovering or completing.
+func (sym *symbol) signature(name string) string {
+	if sym.fn != nil {
+		// Functions from imported packages need to be shown with the name they
+		// are referenced by.
+		fn := *sym.fn
+		fn.Name = name
+
+		return fn.String()
+	}
+
+	switch sym.constant.Kind {
+	case "bool", "number":
+		return name + " = " + sym.constant.Value
+	}
+
+	return name + " = " + sym.constant.String()
+}
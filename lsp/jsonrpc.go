@@ -0,0 +1,27 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/elliotchance/ok/util"
+)
+
+func readMessage(r *bufio.Reader) (*message, error) {
+	body, err := util.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func writeMessage(w io.Writer, v interface{}) error {
+	return util.WriteMessage(w, v)
+}
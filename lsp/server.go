@@ -0,0 +1,377 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// errExit is used internally to stop the server when the "exit" notification
+// is received.
+var errExit = errors.New("exit")
+
+// Server handles the messages from a single editor.
+type Server struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// docs contains the text of documents that are open in the editor, by
+	// absolute file path. The editor is the source of truth for these files
+	// rather than the file system.
+	docs map[string]string
+
+	// diagnosed contains the files that were last published with diagnostics.
+	// They need to be cleared once the errors are fixed.
+	diagnosed map[string]bool
+
+	shutdown bool
+}
+
+// NewServer creates a server that reads requests from in and writes responses
+// to out.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		docs:      map[string]string{},
+		diagnosed: map[string]bool{},
+	}
+}
+
+// Serve will handle messages until the editor sends "exit" or closes the
+// input. An error is returned if the editor did not request a shutdown before
+// exiting.
+func (s *Server) Serve() error {
+	for {
+		msg, err := readMessage(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = s.handle(msg)
+		if err == errExit {
+			if !s.shutdown {
+				return errors.New("exit received before shutdown")
+			}
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	var result interface{}
+	var rpcErr *responseError
+
+	switch msg.Method {
+	case "initialize":
+		result = s.initialize()
+
+	case "shutdown":
+		s.shutdown = true
+
+	case "exit":
+		return errExit
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			fileName := uriToPath(params.TextDocument.URI)
+			s.docs[fileName] = params.TextDocument.Text
+			if err := s.compile(fileName); err != nil {
+				return err
+			}
+		}
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			fileName := uriToPath(params.TextDocument.URI)
+			for _, change := range params.ContentChanges {
+				s.docs[fileName] = change.Text
+			}
+
+			err := s.publish(s.parseDiagnostics(fileName), func(f string) bool {
+				return f == fileName
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			if err := s.compile(uriToPath(params.TextDocument.URI)); err != nil {
+				return err
+			}
+		}
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			delete(s.docs, uriToPath(params.TextDocument.URI))
+		}
+
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			result = s.hover(params)
+		}
+
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			result = s.definition(params)
+		}
+
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if rpcErr = decodeParams(msg, &params); rpcErr == nil {
+			result = s.completion(params)
+		}
+
+	default:
+		rpcErr = &responseError{
+			Code:    codeMethodNotFound,
+			Message: "method not found: " + msg.Method,
+		}
+	}
+
+	// Notifications (such as "initialized") never receive a response.
+	if msg.ID == nil {
+		return nil
+	}
+
+	response := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      msg.ID,
+	}
+	if rpcErr != nil {
+		response["error"] = rpcErr
+	} else {
+		response["result"] = result
+	}
+
+	return writeMessage(s.out, response)
+}
+
+func decodeParams(msg *message, v interface{}) *responseError {
+	if err := json.Unmarshal(msg.Params, v); err != nil {
+		return &responseError{
+			Code:    codeInvalidParams,
+			Message: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return writeMessage(s.out, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	})
+}
+
+func (s *Server) initialize() *InitializeResult {
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncKindFull,
+				Save:      true,
+			},
+			HoverProvider:      true,
+			DefinitionProvider: true,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{"."},
+			},
+		},
+	}
+}
+
+// source returns the text of a file. Open documents are preferred over the
+// file on disk.
+func (s *Server) source(fileName string) string {
+	if text, ok := s.docs[fileName]; ok {
+		return text
+	}
+
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// compile publishes the diagnostics for the package that contains fileName.
+func (s *Server) compile(fileName string) error {
+	dir := filepath.Dir(fileName)
+
+	return s.publish(s.compileDiagnostics(dir, fileName), func(f string) bool {
+		return strings.HasPrefix(f, dir+string(filepath.Separator))
+	})
+}
+
+// publish sends the diagnostics for each file. Files that previously had
+// diagnostics, and are matched by stale, are cleared if they no longer have
+// any errors.
+func (s *Server) publish(diagnostics map[string][]Diagnostic, stale func(string) bool) error {
+	var fileNames []string
+	for fileName := range s.diagnosed {
+		if _, ok := diagnostics[fileName]; !ok && stale(fileName) {
+			fileNames = append(fileNames, fileName)
+			delete(s.diagnosed, fileName)
+		}
+	}
+
+	for fileName := range diagnostics {
+		fileNames = append(fileNames, fileName)
+		s.diagnosed[fileName] = true
+	}
+
+	// Sorted so the notifications are predictable.
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		err := s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         pathToURI(fileName),
+			Diagnostics: append([]Diagnostic{}, diagnostics[fileName]...),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// position returns the line of text and the rune column that the editor is
+// referring to.
+func (s *Server) position(params TextDocumentPositionParams) (fileName, line string, column int) {
+	fileName = uriToPath(params.TextDocument.URI)
+	line = lineAt(s.source(fileName), params.Position.Line)
+
+	return fileName, line, toRune(line, params.Position.Character)
+}
+
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	fileName, line, column := s.position(params)
+	name := wordAt(line, column)
+	sym := s.buildIndex(filepath.Dir(fileName)).lookup(name)
+	if sym == nil {
+		return nil
+	}
+
+	value := "```ok\n" + sym.signature(name) + "\n```"
+	if sym.doc != "" {
+		value += "\n\n" + sym.doc
+	}
+
+	return &Hover{
+		Contents: MarkupContent{
+			Kind:  "markdown",
+			Value: value,
+		},
+	}
+}
+
+func (s *Server) definition(params TextDocumentPositionParams) []Location {
+	fileName, line, column := s.position(params)
+	name := wordAt(line, column)
+	sym := s.buildIndex(filepath.Dir(fileName)).symbols[name]
+
+	// Declarations from the builtin library do not have a location that can be
+	// opened.
+	if sym == nil || sym.pos.LineNumber == 0 {
+		return []Location{}
+	}
+
+	declFileName, _ := filepath.Abs(sym.pos.FileName)
+	declLine := lineAt(s.source(declFileName), sym.pos.LineNumber-1)
+	start := sym.pos.CharacterNumber - 1
+	end := start + len([]rune(strings.TrimPrefix(name, packagePrefix(name))))
+
+	return []Location{
+		{
+			URI: pathToURI(declFileName),
+			Range: Range{
+				Start: Position{
+					Line:      sym.pos.LineNumber - 1,
+					Character: toUTF16(declLine, start),
+				},
+				End: Position{
+					Line:      sym.pos.LineNumber - 1,
+					Character: toUTF16(declLine, end),
+				},
+			},
+		},
+	}
+}
+
+func (s *Server) completion(params TextDocumentPositionParams) []CompletionItem {
+	fileName, line, column := s.position(params)
+	prefix := packagePrefix(wordBefore(line, column))
+	idx := s.buildIndex(filepath.Dir(fileName))
+
+	items := []CompletionItem{}
+	for _, name := range idx.names() {
+		if packagePrefix(name) != prefix {
+			continue
+		}
+
+		sym := idx.lookup(name)
+		item := CompletionItem{
+			Label:  strings.TrimPrefix(name, prefix),
+			Kind:   CompletionItemKindFunction,
+			Detail: sym.signature(name),
+		}
+		if sym.constant != nil {
+			item.Kind = CompletionItemKindConstant
+		}
+
+		items = append(items, item)
+	}
+
+	// Packages can only be completed at the start of a name.
+	if prefix == "" {
+		var packages []string
+		for pkg := range idx.packages {
+			packages = append(packages, pkg)
+		}
+		sort.Strings(packages)
+
+		for _, pkg := range packages {
+			items = append(items, CompletionItem{
+				Label: pkg,
+				Kind:  CompletionItemKindModule,
+			})
+		}
+	}
+
+	return items
+}
+
+// packagePrefix returns the "pkg." part of a qualified name, or an empty string
+// if the name is not qualified.
+func packagePrefix(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i+1]
+	}
+
+	return ""
+}
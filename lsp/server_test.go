@@ -0,0 +1,378 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elliotchance/ok/lsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+func frame(v map[string]interface{}) string {
+	v["jsonrpc"] = "2.0"
+	body, _ := json.Marshal(v)
+
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func request(id int, method string, params interface{}) string {
+	return frame(map[string]interface{}{
+		"id":     id,
+		"method": method,
+		"params": params,
+	})
+}
+
+func notification(method string, params interface{}) string {
+	return frame(map[string]interface{}{
+		"method": method,
+		"params": params,
+	})
+}
+
+func textDocument(uri string, line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	}
+}
+
+func didOpen(uri, text string) string {
+	return notification("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "text": text},
+	})
+}
+
+// serve runs the server until all of the input has been consumed and returns
+// the messages sent back to the editor.
+func serve(t *testing.T, in ...string) []message {
+	var out bytes.Buffer
+	s := lsp.NewServer(strings.NewReader(strings.Join(in, "")), &out)
+	require.NoError(t, s.Serve())
+
+	var messages []message
+	r := bufio.NewReader(&out)
+	for {
+		header, err := r.ReadString('\n')
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		var length int
+		_, err = fmt.Sscanf(header, "Content-Length: %d", &length)
+		require.NoError(t, err)
+		_, err = r.ReadString('\n')
+		require.NoError(t, err)
+
+		body := make([]byte, length)
+		_, err = io.ReadFull(r, body)
+		require.NoError(t, err)
+
+		var msg message
+		require.NoError(t, json.Unmarshal(body, &msg))
+		messages = append(messages, msg)
+	}
+
+	return messages
+}
+
+// response finds the response for a request.
+func response(t *testing.T, messages []message, id int, v interface{}) {
+	for _, msg := range messages {
+		if msg.ID != nil && *msg.ID == id {
+			require.Nil(t, msg.Error)
+			require.NoError(t, json.Unmarshal(msg.Result, v))
+
+			return
+		}
+	}
+
+	t.Fatalf("no response for request %d", id)
+}
+
+func diagnostics(t *testing.T, messages []message) []lsp.PublishDiagnosticsParams {
+	var all []lsp.PublishDiagnosticsParams
+	for _, msg := range messages {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params lsp.PublishDiagnosticsParams
+			require.NoError(t, json.Unmarshal(msg.Params, &params))
+			all = append(all, params)
+		}
+	}
+
+	return all
+}
+
+// writePackage creates the files in a temporary directory and returns the
+// directory (which must be removed) and a function to build URIs for each
+// file.
+func writePackage(t *testing.T, files map[string]string) (string, func(string) string) {
+	dir, err := ioutil.TempDir("", "ok-lsp")
+	require.NoError(t, err)
+
+	for fileName, src := range files {
+		fileName = filepath.Join(dir, fileName)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+		require.NoError(t, ioutil.WriteFile(fileName, []byte(src), 0644))
+	}
+
+	return dir, func(fileName string) string {
+		return "file://" + filepath.ToSlash(filepath.Join(dir, fileName))
+	}
+}
+
+func TestServer_Initialize(t *testing.T) {
+	messages := serve(t,
+		request(1, "initialize", map[string]interface{}{}),
+		notification("initialized", map[string]interface{}{}),
+		request(2, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	require.Len(t, messages, 2)
+
+	var result lsp.InitializeResult
+	response(t, messages, 1, &result)
+	assert.True(t, result.Capabilities.HoverProvider)
+	assert.True(t, result.Capabilities.DefinitionProvider)
+	assert.Equal(t, lsp.TextDocumentSyncKindFull,
+		result.Capabilities.TextDocumentSync.Change)
+	assert.Equal(t, []string{"."},
+		result.Capabilities.CompletionProvider.TriggerCharacters)
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	s := lsp.NewServer(strings.NewReader(notification("exit", nil)),
+		ioutil.Discard)
+	assert.Error(t, s.Serve())
+}
+
+func TestServer_MethodNotFound(t *testing.T) {
+	messages := serve(t, request(1, "textDocument/foo", nil))
+
+	require.Len(t, messages, 1)
+	require.NotNil(t, messages[0].Error)
+	assert.Equal(t, -32601, messages[0].Error.Code)
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	src := "func main() {\n    print(a)\n}\n"
+	dir, uri := writePackage(t, map[string]string{"main.ok": src})
+	defer os.RemoveAll(dir)
+
+	for testName, test := range map[string]struct {
+		messages []string
+		expected []lsp.PublishDiagnosticsParams
+	}{
+		"compile-error": {
+			messages: []string{didOpen(uri("main.ok"), src)},
+			expected: []lsp.PublishDiagnosticsParams{
+				{
+					URI: uri("main.ok"),
+					Diagnostics: []lsp.Diagnostic{
+						{
+							Range: lsp.Range{
+								Start: lsp.Position{Line: 1, Character: 10},
+								End:   lsp.Position{Line: 1, Character: 11},
+							},
+							Severity: lsp.DiagnosticSeverityError,
+							Code:     "undefined-variable",
+							Source:   "ok",
+							Message:  "undefined variable: a",
+						},
+					},
+				},
+			},
+		},
+		"parse-error-while-editing": {
+			messages: []string{
+				notification("textDocument/didChange", map[string]interface{}{
+					"textDocument": map[string]interface{}{"uri": uri("main.ok")},
+					"contentChanges": []interface{}{
+						map[string]interface{}{"text": "func main() {\n    print('')\n}\n"},
+					},
+				}),
+			},
+			expected: []lsp.PublishDiagnosticsParams{
+				{
+					URI: uri("main.ok"),
+					Diagnostics: []lsp.Diagnostic{
+						{
+							Range: lsp.Range{
+								Start: lsp.Position{Line: 1, Character: 10},
+								End:   lsp.Position{Line: 1, Character: 12},
+							},
+							Severity: lsp.DiagnosticSeverityError,
+							Code:     "syntax",
+							Source:   "ok",
+							Message:  "character literal cannot be empty",
+						},
+					},
+				},
+			},
+		},
+		"cleared-when-fixed": {
+			messages: []string{
+				didOpen(uri("main.ok"), src),
+				notification("textDocument/didChange", map[string]interface{}{
+					"textDocument": map[string]interface{}{"uri": uri("main.ok")},
+					"contentChanges": []interface{}{
+						map[string]interface{}{"text": "func main() {}\n"},
+					},
+				}),
+			},
+			expected: []lsp.PublishDiagnosticsParams{
+				{URI: uri("main.ok"), Diagnostics: []lsp.Diagnostic{}},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			messages := serve(t, test.messages...)
+			actual := diagnostics(t, messages)
+			assert.Equal(t, test.expected, actual[len(actual)-len(test.expected):])
+		})
+	}
+}
+
+func TestServer_Hover(t *testing.T) {
+	src := "import \"math\"\n\n// Add returns the sum.\nfunc Add(a, b number) number {\n    return a + b\n}\n\nLimit = 10\n\nfunc main() {\n    print(Add(math.Abs(Limit), 2))\n}\n"
+	dir, uri := writePackage(t, map[string]string{"main.ok": src})
+	defer os.RemoveAll(dir)
+
+	for testName, test := range map[string]struct {
+		character int
+		expected  string
+	}{
+		"func":          {11, "```ok\nfunc Add(a number, b number) number\n```\n\nAdd returns the sum."},
+		"lib-func":      {21, "```ok\nfunc math.Abs(x number) number\n```"},
+		"constant":      {24, "```ok\nLimit = 10\n```"},
+		"package":       {16, ""},
+		"not-a-symbol":  {5, ""},
+		"after-the-end": {100, ""},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			messages := serve(t,
+				didOpen(uri("main.ok"), src),
+				request(1, "textDocument/hover", textDocument(uri("main.ok"), 10, test.character)),
+			)
+
+			var hover *lsp.Hover
+			response(t, messages, 1, &hover)
+			if test.expected == "" {
+				assert.Nil(t, hover)
+			} else {
+				require.NotNil(t, hover)
+				assert.Equal(t, test.expected, hover.Contents.Value)
+			}
+		})
+	}
+}
+
+func TestServer_Definition(t *testing.T) {
+	files := map[string]string{
+		"main.ok":         "import \"shapes\"\n\nfunc main() {\n    print(Add(Limit, 2))\n    print(shapes.Circle(1))\n}\n",
+		"add.ok":          "Limit = 10\n\nfunc Add(a, b number) number {\n    return a + b\n}\n",
+		"shapes/shape.ok": "func Circle(Radius number) Circle {}\n",
+	}
+	dir, uri := writePackage(t, files)
+	defer os.RemoveAll(dir)
+
+	for testName, test := range map[string]struct {
+		line, character int
+		expected        []lsp.Location
+	}{
+		"func": {3, 11, []lsp.Location{{
+			URI: uri("add.ok"),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 2, Character: 5},
+				End:   lsp.Position{Line: 2, Character: 8},
+			},
+		}}},
+		"constant": {3, 16, []lsp.Location{{
+			URI: uri("add.ok"),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 0},
+				End:   lsp.Position{Line: 0, Character: 5},
+			},
+		}}},
+		"constructor": {4, 20, []lsp.Location{{
+			URI: uri("shapes/shape.ok"),
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 0, Character: 5},
+				End:   lsp.Position{Line: 0, Character: 11},
+			},
+		}}},
+		"builtin": {3, 6, []lsp.Location{}},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			messages := serve(t,
+				request(1, "textDocument/definition", textDocument(uri("main.ok"), test.line, test.character)),
+			)
+
+			var locations []lsp.Location
+			response(t, messages, 1, &locations)
+			assert.Equal(t, test.expected, locations)
+		})
+	}
+}
+
+func TestServer_Completion(t *testing.T) {
+	src := "import \"math\"\n\nPi = 3\n\nfunc foo() {}\n\nfunc main() {\n    math.\n    f\n}\n"
+	dir, uri := writePackage(t, map[string]string{"main.ok": src})
+	defer os.RemoveAll(dir)
+
+	labels := func(line, character int) map[string]int {
+		messages := serve(t,
+			didOpen(uri("main.ok"), src),
+			request(1, "textDocument/completion", textDocument(uri("main.ok"), line, character)),
+		)
+
+		var items []lsp.CompletionItem
+		response(t, messages, 1, &items)
+
+		kinds := map[string]int{}
+		for _, item := range items {
+			kinds[item.Label] = item.Kind
+		}
+
+		return kinds
+	}
+
+	t.Run("package-members", func(t *testing.T) {
+		kinds := labels(7, 9)
+		assert.Equal(t, lsp.CompletionItemKindFunction, kinds["Abs"])
+		assert.Equal(t, lsp.CompletionItemKindConstant, kinds["Pi"])
+		assert.NotContains(t, kinds, "foo")
+		assert.NotContains(t, kinds, "math")
+	})
+
+	t.Run("package-level", func(t *testing.T) {
+		kinds := labels(8, 5)
+		assert.Equal(t, lsp.CompletionItemKindFunction, kinds["foo"])
+		assert.Equal(t, lsp.CompletionItemKindFunction, kinds["main"])
+		assert.Equal(t, lsp.CompletionItemKindConstant, kinds["Pi"])
+		assert.Equal(t, lsp.CompletionItemKindModule, kinds["math"])
+		assert.NotContains(t, kinds, "Abs")
+		assert.NotContains(t, kinds, "math.Abs")
+	})
+}
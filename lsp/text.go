@@ -0,0 +1,95 @@
+package lsp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// lineAt returns the zero-based line from text, or an empty string if it does
+// not exist.
+func lineAt(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+// toUTF16 converts a zero-based column counted in runes (which is what the
+// lexer uses) into the UTF-16 code units expected by the editor.
+func toUTF16(line string, column int) int {
+	units := 0
+	for i, r := range []rune(line) {
+		if i >= column {
+			break
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+
+	// Columns past the end of the line are left as is.
+	if runes := len([]rune(line)); column > runes {
+		units += column - runes
+	}
+
+	return units
+}
+
+// toRune is the inverse of toUTF16.
+func toRune(line string, character int) int {
+	column, units := 0, 0
+	for _, r := range line {
+		if units >= character {
+			break
+		}
+		units += len(utf16.Encode([]rune{r}))
+		column++
+	}
+
+	return column
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordAt returns the name under the zero-based rune column. Names may be
+// qualified with a package, like "math.Abs". Only the part of the name up to
+// the end of the segment under the column is returned, so hovering over "math"
+// in "math.Abs" returns "math".
+func wordAt(line string, column int) string {
+	runes := []rune(line)
+	if column > len(runes) {
+		column = len(runes)
+	}
+
+	start := column
+	for start > 0 && (isWordChar(runes[start-1]) || runes[start-1] == '.') {
+		start--
+	}
+
+	end := column
+	for end < len(runes) && isWordChar(runes[end]) {
+		end++
+	}
+
+	return strings.Trim(string(runes[start:end]), ".")
+}
+
+// wordBefore returns the partial name immediately before the zero-based rune
+// column. Unlike wordAt the trailing "." is kept so that "math." can be
+// completed.
+func wordBefore(line string, column int) string {
+	runes := []rune(line)
+	if column > len(runes) {
+		column = len(runes)
+	}
+
+	start := column
+	for start > 0 && (isWordChar(runes[start-1]) || runes[start-1] == '.') {
+		start--
+	}
+
+	return string(runes[start:column])
+}
@@ -9,10 +9,16 @@ import (
 
 	"github.com/elliotchance/ok/cmd/asm"
 	"github.com/elliotchance/ok/cmd/build"
+	"github.com/elliotchance/ok/cmd/compile"
+	"github.com/elliotchance/ok/cmd/debug"
 	"github.com/elliotchance/ok/cmd/doc"
+	fmtcmd "github.com/elliotchance/ok/cmd/fmt"
+	"github.com/elliotchance/ok/cmd/lsp"
+	"github.com/elliotchance/ok/cmd/repl"
 	"github.com/elliotchance/ok/cmd/run"
 	"github.com/elliotchance/ok/cmd/test"
 	"github.com/elliotchance/ok/cmd/version"
+	"github.com/elliotchance/ok/cmd/vet"
 )
 
 type command interface {
@@ -23,13 +29,24 @@ type command interface {
 var commands = map[string]command{
 	"asm":     &asm.Command{},
 	"build":   &build.Command{},
+	"compile": &compile.Command{},
+	"debug":   &debug.Command{},
 	"doc":     &doc.Command{},
+	"fmt":     &fmtcmd.Command{},
+	"lsp":     &lsp.Command{},
+	"repl":    &repl.Command{},
 	"run":     &run.Command{},
 	"test":    &test.Command{},
 	"version": &version.Command{},
+	"vet":     &vet.Command{},
 }
 
 func main() {
+	// Executables created by "ok build" run their program instead.
+	if build.RunEmbedded() {
+		return
+	}
+
 	flag.Usage = func() {
 		fmt.Println("ok is a tool for managing ok source code.")
 		fmt.Println("")
@@ -5,7 +5,8 @@ import (
 	"github.com/elliotchance/ok/lexer"
 )
 
-func consumeAssert(parser *Parser, offset int) (*ast.Assert, int, error) {
+// consumeAssert returns an *ast.Assert or *ast.AssertRaise.
+func consumeAssert(parser *Parser, offset int) (ast.Node, int, error) {
 	originalOffset := offset
 	var err error
 	var expr ast.Node
@@ -21,13 +22,45 @@ func consumeAssert(parser *Parser, offset int) (*ast.Assert, int, error) {
 		return nil, originalOffset, err
 	}
 
+	// "raise" is only permitted here to check the type of error.
+	raiseType := ""
+	if parser.File.Tokens[offset].Kind == lexer.TokenRaise {
+		var ident *ast.Identifier
+		ident, offset, err = consumeIdentifier(parser, offset+1)
+		if err != nil {
+			return nil, originalOffset, err
+		}
+
+		raiseType = ident.Name
+	}
+
+	// The message is optional.
+	var message ast.Node
+	if parser.File.Tokens[offset].Kind == lexer.TokenComma {
+		message, offset, err = consumeExpr(parser, offset+1, unlimitedTokens)
+		if err != nil {
+			return nil, originalOffset, err
+		}
+	}
+
 	offset, err = consume(parser.File, offset, []string{lexer.TokenParenClose})
 	if err != nil {
 		return nil, originalOffset, err
 	}
 
+	pos := parser.File.Pos(originalOffset)
+	if raiseType != "" {
+		return &ast.AssertRaise{
+			Expr:    expr,
+			Type:    raiseType,
+			Message: message,
+			Pos:     pos,
+		}, offset, nil
+	}
+
 	assert := &ast.Assert{
-		Pos: parser.File.Pos(originalOffset),
+		Message: message,
+		Pos:     pos,
 	}
 	if e, ok := expr.(*ast.Binary); ok {
 		assert.Expr = e
@@ -27,6 +27,37 @@ func TestAssert(t *testing.T) {
 				),
 			},
 		},
+		"message": {
+			str: `assert(a == 1, "a is wrong")`,
+			expected: &ast.Assert{
+				Expr: asttest.NewBinary(
+					&ast.Identifier{Name: "a"},
+					lexer.TokenEqual,
+					asttest.NewLiteralNumber("1"),
+				),
+				Message: asttest.NewLiteralString("a is wrong"),
+			},
+		},
+		"raise": {
+			str: "assert(foo() raise MyError)",
+			expected: &ast.AssertRaise{
+				Expr: &ast.Call{FunctionName: "foo"},
+				Type: "MyError",
+			},
+		},
+		"raise-message": {
+			str: `assert(foo(1) raise MyError, "why")`,
+			expected: &ast.AssertRaise{
+				Expr: &ast.Call{
+					FunctionName: "foo",
+					Arguments: []ast.Node{
+						asttest.NewLiteralNumber("1"),
+					},
+				},
+				Type:    "MyError",
+				Message: asttest.NewLiteralString("why"),
+			},
+		},
 		"not-binary": {
 			str:      "assert(false)",
 			expected: &ast.Assert{},
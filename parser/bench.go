@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/lexer"
+)
+
+func consumeBench(parser *Parser, offset int) (*ast.Bench, int, error) {
+	originalOffset := offset
+	var err error
+
+	offset, err = consume(parser.File, offset, []string{
+		lexer.TokenBench, lexer.TokenStringLiteral})
+	if err != nil {
+		return nil, originalOffset, err
+	}
+
+	b := &ast.Bench{
+		Name: parser.File.Tokens[offset-1].Value,
+		Pos:  parser.File.Pos(originalOffset),
+	}
+
+	b.Statements, offset, err = consumeBlock(parser, offset)
+	if err != nil {
+		return nil, originalOffset, err
+	}
+
+	return b, offset, nil
+}
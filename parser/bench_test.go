@@ -0,0 +1,47 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/ast/asttest"
+	"github.com/elliotchance/ok/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBench(t *testing.T) {
+	for testName, test := range map[string]struct {
+		str      string
+		expected *ast.Bench
+		errs     []error
+	}{
+		"empty": {
+			str: `bench "foo bar" {}`,
+			expected: &ast.Bench{
+				Name: "foo bar",
+			},
+		},
+		"statements": {
+			str: `bench "split" { a = 1 }`,
+			expected: &ast.Bench{
+				Name: "split",
+				Statements: []ast.Node{
+					&ast.Assign{
+						Lefts:  []ast.Node{&ast.Identifier{Name: "a"}},
+						Rights: []ast.Node{asttest.NewLiteralNumber("1")},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			p := parser.ParseString(test.str, "a.ok")
+
+			assertEqualErrors(t, test.errs, p.Errors())
+			asttest.AssertEqual(t, []*ast.Bench{test.expected}, p.File.Benches)
+			assert.Len(t, p.File.Funcs, 0)
+			assert.Len(t, p.File.Tests, 0)
+		})
+	}
+}
@@ -56,14 +56,14 @@ func consumeOn(parser *Parser, offset int) (*ast.On, int, error) {
 		return nil, originalOffset, err
 	}
 
-	var ident *ast.Identifier
-	ident, offset, err = consumeIdentifier(parser, offset)
+	var ty string
+	ty, offset, err = consumeTypeName(parser, offset)
 	if err != nil {
 		return nil, originalOffset, err
 	}
 
 	node := &ast.On{
-		Type: ident.Name,
+		Type: ty,
 		Pos:  parser.File.Pos(originalOffset),
 	}
 
@@ -45,6 +45,21 @@ func TestErrorScope(t *testing.T) {
 				},
 			},
 		},
+		"try-on-package-type": {
+			str: "try { print() } on fs.NotFoundError {}",
+			expected: &ast.ErrorScope{
+				Statements: []ast.Node{
+					&ast.Call{
+						FunctionName: "print",
+					},
+				},
+				On: []*ast.On{
+					{
+						Type: "fs.NotFoundError",
+					},
+				},
+			},
+		},
 		"try-on-2": {
 			str: "try { print() } on SomeError {} on SomethingElse { foo() }",
 			expected: &ast.ErrorScope{
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"errors"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/lexer"
+)
+
+func consumeFuzz(parser *Parser, offset int) (*ast.Test, int, error) {
+	originalOffset := offset
+	var err error
+
+	offset, err = consume(parser.File, offset, []string{
+		lexer.TokenFuzz, lexer.TokenStringLiteral, lexer.TokenParenOpen})
+	if err != nil {
+		return nil, originalOffset, err
+	}
+
+	t := &ast.Test{
+		Name: parser.File.Tokens[offset-2].Value,
+		Pos:  parser.File.Pos(originalOffset),
+	}
+
+	t.Arguments, offset, err = consumeArguments(parser, offset)
+	if err != nil {
+		return nil, originalOffset, err
+	}
+
+	if len(t.Arguments) == 0 {
+		return nil, originalOffset, errors.New("fuzz test must have at least one argument")
+	}
+
+	offset, err = consume(parser.File, offset, []string{lexer.TokenParenClose})
+	if err != nil {
+		return nil, originalOffset, err
+	}
+
+	t.Statements, offset, err = consumeBlock(parser, offset)
+	if err != nil {
+		return nil, originalOffset, err
+	}
+
+	return t, offset, nil
+}
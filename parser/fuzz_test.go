@@ -0,0 +1,65 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/ast/asttest"
+	"github.com/elliotchance/ok/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzz(t *testing.T) {
+	for testName, test := range map[string]struct {
+		str      string
+		expected []*ast.Test
+		errs     []error
+	}{
+		"one-argument": {
+			str: `fuzz "foo bar" (s string) {}`,
+			expected: []*ast.Test{
+				{
+					Name: "foo bar",
+					Arguments: []*ast.Argument{
+						{Name: "s", Type: "string"},
+					},
+				},
+			},
+		},
+		"grouped-arguments": {
+			str: `fuzz "split" (s, delimiter string, n []number) { a = 1 }`,
+			expected: []*ast.Test{
+				{
+					Name: "split",
+					Arguments: []*ast.Argument{
+						{Name: "s", Type: "string"},
+						{Name: "delimiter", Type: "string"},
+						{Name: "n", Type: "[]number"},
+					},
+					Statements: []ast.Node{
+						&ast.Assign{
+							Lefts:  []ast.Node{&ast.Identifier{Name: "a"}},
+							Rights: []ast.Node{asttest.NewLiteralNumber("1")},
+						},
+					},
+				},
+			},
+		},
+		"no-arguments": {
+			str: `fuzz "foo" () {}`,
+			errs: []error{
+				errors.New("a.ok:1:1 fuzz test must have at least one argument"),
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			p := parser.ParseString(test.str, "a.ok")
+
+			assertEqualErrors(t, test.errs, p.Errors())
+			asttest.AssertEqual(t, test.expected, p.File.Tests)
+			assert.Len(t, p.File.Funcs, 0)
+		})
+	}
+}
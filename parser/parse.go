@@ -15,12 +15,7 @@ func ParseString(s string, fileName string) *Parser {
 	}
 	parser.File.Funcs = map[string]*ast.Func{}
 	parser.File.Imports = map[string]string{}
-	defer func() {
-		err := parser.resolveInterfaces()
-		if err != nil {
-			parser.AppendErrorAt("", err.Error())
-		}
-	}()
+	defer parser.resolveInterfaces()
 
 	var err error
 	options := lexer.Options{
@@ -77,6 +72,26 @@ func ParseString(s string, fileName string) *Parser {
 			}
 			parser.File.Tests = append(parser.File.Tests, t)
 
+		case lexer.TokenFuzz:
+			var t *ast.Test
+			t, offset, err = consumeFuzz(parser, offset)
+			if err != nil {
+				parser.AppendErrorAt(parser.File.Pos(offset), err.Error())
+
+				goto done
+			}
+			parser.File.Tests = append(parser.File.Tests, t)
+
+		case lexer.TokenBench:
+			var b *ast.Bench
+			b, offset, err = consumeBench(parser, offset)
+			if err != nil {
+				parser.AppendError(b, err.Error())
+
+				goto done
+			}
+			parser.File.Benches = append(parser.File.Benches, b)
+
 		case lexer.TokenImport:
 			var imp *ast.Import
 			imp, offset, err = consumeImport(parser, offset)
@@ -5,9 +5,13 @@ import (
 	"reflect"
 
 	"github.com/elliotchance/ok/ast"
-	"github.com/pkg/errors"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/util"
 )
 
+// CodeSyntax is the code for all diagnostics produced by the parser.
+const CodeSyntax = "syntax"
+
 type Parser struct {
 	errors           []error
 	File             *File
@@ -24,15 +28,18 @@ type Parser struct {
 	Constants map[string]*ast.Literal
 }
 
-// AppendError adds an error to the stack.
+// AppendErrorAt adds an error to the stack. The pos may be empty if the
+// position is not known.
 func (p *Parser) AppendErrorAt(pos string, message string) {
-	var err error
-	if pos != "" {
-		err = fmt.Errorf("%s %s", pos, message)
-	} else {
-		err = errors.New(message)
-	}
-	p.errors = append(p.errors, err)
+	at := lexer.ParsePos(pos)
+	p.errors = append(p.errors, &util.Diagnostic{
+		File:     at.FileName,
+		Line:     at.LineNumber,
+		Column:   at.CharacterNumber,
+		Severity: util.SeverityError,
+		Code:     CodeSyntax,
+		Message:  message,
+	})
 }
 
 // AppendError adds an error to the stack.
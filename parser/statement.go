@@ -56,7 +56,7 @@ func consumeStatement(parser *Parser, offset int) (_ ast.Node, _ int, hoist bool
 		return assign, offset, hoist, nil
 	}
 
-	var assert *ast.Assert
+	var assert ast.Node
 	assert, offset, err = consumeAssert(parser, offset)
 	if err == nil {
 		return assert, offset, hoist, nil
@@ -20,6 +20,14 @@ func consumeTest(parser *Parser, offset int) (*ast.Test, int, error) {
 		Pos:  parser.File.Pos(originalOffset),
 	}
 
+	// Cases are optional.
+	if parser.File.Tokens[offset].Kind == lexer.TokenFor {
+		t.Cases, offset, err = consumeIn(parser, offset+1)
+		if err != nil {
+			return nil, originalOffset, err
+		}
+	}
+
 	t.Statements, offset, err = consumeBlock(parser, offset)
 	if err != nil {
 		return nil, originalOffset, err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/ast/asttest"
+	"github.com/elliotchance/ok/lexer"
 	"github.com/elliotchance/ok/parser"
 
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,41 @@ func TestTest(t *testing.T) {
 				Name: "foo bar",
 			},
 		},
+		"cases": {
+			str: `test "foo" for c in [1, 2] { assert(c > 0) }`,
+			expected: &ast.Test{
+				Name: "foo",
+				Cases: &ast.In{
+					Value: "c",
+					Expr: &ast.Array{
+						Elements: []ast.Node{
+							asttest.NewLiteralNumber("1"),
+							asttest.NewLiteralNumber("2"),
+						},
+					},
+				},
+				Statements: []ast.Node{
+					&ast.Assert{
+						Expr: asttest.NewBinary(
+							&ast.Identifier{Name: "c"},
+							lexer.TokenGreaterThan,
+							asttest.NewLiteralNumber("0"),
+						),
+					},
+				},
+			},
+		},
+		"cases-with-key": {
+			str: `test "foo" for c, name in cases {}`,
+			expected: &ast.Test{
+				Name: "foo",
+				Cases: &ast.In{
+					Value: "c",
+					Key:   "name",
+					Expr:  &ast.Identifier{Name: "cases"},
+				},
+			},
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			p := parser.ParseString(test.str, "a.ok")
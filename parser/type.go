@@ -62,14 +62,11 @@ done:
 	var t lexer.Token
 	t, offset, err = consumeOneOf(parser.File, offset, types)
 	if err != nil {
-		// Any identifier is also valid.
-		var ident *ast.Identifier
-		ident, offset, err = consumeIdentifier(parser, offset)
+		// Any type name is also valid.
+		t.Kind, offset, err = consumeTypeName(parser, offset)
 		if err != nil {
 			return "", originalOffset, err
 		}
-
-		t.Kind = ident.Name
 	}
 
 	ty += strings.Split(t.Kind, " ")[0]
@@ -123,3 +120,26 @@ func consumeTypes(parser *Parser, offset int, allowEmpty bool) ([]string, int, e
 
 	return types, offset, nil
 }
+
+// consumeTypeName consumes the name of an object type. It may include the
+// package name, like "fs.NotFoundError".
+func consumeTypeName(parser *Parser, offset int) (string, int, error) {
+	originalOffset := offset
+
+	ident, offset, err := consumeIdentifier(parser, offset)
+	if err != nil {
+		return "", originalOffset, err
+	}
+
+	name := ident.Name
+	if parser.File.Tokens[offset].Kind == lexer.TokenDot {
+		ident, offset, err = consumeIdentifier(parser, offset+1)
+		if err != nil {
+			return "", originalOffset, err
+		}
+
+		name += "." + ident.Name
+	}
+
+	return name, offset, nil
+}
@@ -47,6 +47,10 @@ func TestType(t *testing.T) {
 			str:      "{}Person {}",
 			expected: &ast.Map{Kind: "{}Person"},
 		},
+		"array-package-type": {
+			str:      "[]fs.File []",
+			expected: &ast.Array{Kind: "[]fs.File"},
+		},
 		"func-1": {
 			str:      "{}func(number) {}",
 			expected: &ast.Map{Kind: "{}func(number)"},
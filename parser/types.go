@@ -1,20 +1,15 @@
 package parser
 
-import (
-	"fmt"
-)
-
-func (p *Parser) resolveInterfaces() error {
+func (p *Parser) resolveInterfaces() {
 	for _, fn := range p.File.Funcs {
 		if fn.IsConstructor() {
 			ty, err := fn.Interface()
 			if err != nil {
-				return fmt.Errorf("%v %s", fn.Position(), err)
+				p.AppendErrorAt(fn.Position(), err.Error())
+				return
 			}
 
 			p.Interfaces[fn.Name] = ty
 		}
 	}
-
-	return nil
 }
@@ -0,0 +1,6 @@
+// Package repl implements the interactive "ok repl".
+//
+// Each input is compiled against the functions, interfaces and constants from
+// the previous inputs and run in the top level scope of a single VM so that
+// variables persist between inputs.
+package repl
@@ -0,0 +1,464 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/util"
+	"github.com/elliotchance/ok/vm"
+)
+
+// fileName is used for the positions of all inputs.
+const fileName = "repl"
+
+// funcName is the function that each input is wrapped in so it can be parsed.
+const funcName = "__repl"
+
+// REPL holds the state that persists between each input.
+type REPL struct {
+	out io.Writer
+	vm  *vm.VM
+
+	// file contains all of the functions, interfaces and constants that have
+	// been declared or imported.
+	file *compiler.Compiled
+
+	// variables contains the types of all variables in the top level scope.
+	// Their values are held by the VM.
+	variables map[string]string
+
+	// inputs is used to create unique names for function literals. Otherwise
+	// they would clash with the function literals from previous inputs.
+	inputs int
+}
+
+// New creates a REPL that writes results to out.
+func New(out io.Writer) *REPL {
+	r := &REPL{out: out}
+	r.Reset()
+
+	return r
+}
+
+// Reset forgets all variables and declarations.
+func (r *REPL) Reset() {
+	r.file = &compiler.Compiled{
+		Funcs:      map[string]*vm.CompiledFunc{},
+		FuncDefs:   map[string]*ast.Func{},
+		Interfaces: map[string]map[string]string{},
+		Constants:  map[string]*ast.Literal{},
+	}
+	r.variables = map[string]string{}
+	r.vm = vm.NewVM(r.file.Funcs, nil, r.file.Interfaces, "")
+	r.vm.Stdout = r.out
+	r.vm.Stderr = r.out
+}
+
+// Run reads inputs until the end of in. Each input is evaluated once all of
+// its brackets are closed, so blocks can be spread over multiple lines.
+func (r *REPL) Run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	input := ""
+
+	fmt.Fprint(r.out, "> ")
+	for scanner.Scan() {
+		input += scanner.Text() + "\n"
+		if !IsComplete(input) {
+			fmt.Fprint(r.out, "... ")
+			continue
+		}
+
+		for _, err := range r.Eval(input) {
+			fmt.Fprintln(r.out, trimPosition(err))
+		}
+
+		input = ""
+		fmt.Fprint(r.out, "> ")
+	}
+
+	return scanner.Err()
+}
+
+// Eval runs a single input. The input may be a meta-command, function
+// declaration, import or one or more statements.
+func (r *REPL) Eval(input string) (errs []error) {
+	// The lexer and parser are not always tolerant of broken code. A panic
+	// should not end the session.
+	defer func() {
+		if p := recover(); p != nil {
+			errs = []error{fmt.Errorf("internal error: %v", p)}
+		}
+	}()
+
+	input = strings.TrimSpace(input)
+
+	switch {
+	case input == "":
+		return nil
+
+	case input == ":reset":
+		r.Reset()
+
+		return nil
+
+	case strings.HasPrefix(input, ":type "):
+		return r.typeOf(strings.TrimPrefix(input, ":type "))
+
+	case strings.HasPrefix(input, ":asm "):
+		return r.asm(strings.TrimPrefix(input, ":asm "))
+
+	case strings.HasPrefix(input, ":"):
+		return []error{fmt.Errorf("unknown command: %s", strings.Fields(input)[0])}
+
+	case isDeclaration(input):
+		return r.declare(input)
+	}
+
+	compiled, errs := r.compile(input)
+	if errs != nil {
+		return errs
+	}
+
+	// The variables are only kept if the input compiles. However, they are
+	// kept even if there is an error at runtime because some of them may have
+	// been set.
+	r.variables = compiled.Variables
+
+	if err := r.vm.Eval(funcName, compiled); err != nil {
+		return []error{err}
+	}
+
+	return nil
+}
+
+// IsComplete returns true if all of the brackets in the input have been closed.
+func IsComplete(input string) (complete bool) {
+	defer func() {
+		if recover() != nil {
+			complete = true
+		}
+	}()
+
+	tokens, _, err := lexer.TokenizeString(input, lexer.Options{}, fileName)
+	if err != nil {
+		// Let the parser report the error.
+		return true
+	}
+
+	depth := 0
+	for _, token := range tokens {
+		switch token.Kind {
+		case lexer.TokenCurlyOpen, lexer.TokenParenOpen, lexer.TokenSquareOpen:
+			depth++
+
+		case lexer.TokenCurlyClose, lexer.TokenParenClose, lexer.TokenSquareClose:
+			depth--
+		}
+	}
+
+	return depth <= 0
+}
+
+// trimPosition removes the position from errors that refer to the input. It is
+// not useful for short inputs, and the column on the first line would not be
+// correct because of the function that wraps the input.
+func trimPosition(err error) string {
+	parts := strings.SplitN(err.Error(), " ", 2)
+	if len(parts) == 2 && lexer.ParsePos(parts[0]).FileName == fileName {
+		return parts[1]
+	}
+
+	return err.Error()
+}
+
+// isDeclaration returns true for a named function or import. These are added
+// to the package rather than being run.
+func isDeclaration(input string) bool {
+	tokens, _, err := lexer.TokenizeString(input, lexer.Options{}, fileName)
+	if err != nil || len(tokens) < 2 {
+		return false
+	}
+
+	return tokens[0].Kind == lexer.TokenImport ||
+		(tokens[0].Kind == lexer.TokenFunc &&
+			tokens[1].Kind == lexer.TokenIdentifier)
+}
+
+func (r *REPL) declare(input string) []error {
+	p := parser.ParseString(input, fileName)
+	if errs := p.Errors(); len(errs) > 0 {
+		return errs
+	}
+
+	for pkg := range p.File.Imports {
+		// Builtin packages are always available. See vm.Packages.
+		if vm.Packages[pkg] {
+			continue
+		}
+
+		if errs := r.importPackage(pkg); errs != nil {
+			return errs
+		}
+	}
+
+	if err := r.load(p, ""); err != nil {
+		return []error{err}
+	}
+
+	return nil
+}
+
+// importPackage loads a package from a directory relative to the current
+// directory.
+func (r *REPL) importPackage(pkg string) []error {
+	fileNames, err := util.GetAllOKFilesInPath(pkg, false)
+	if err != nil {
+		return []error{err}
+	}
+
+	for _, fileName := range fileNames {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return []error{err}
+		}
+
+		p := parser.ParseString(string(data), fileName)
+		if errs := p.Errors(); len(errs) > 0 {
+			return errs
+		}
+
+		if err := r.load(p, path.Base(pkg)+"."); err != nil {
+			return []error{err}
+		}
+	}
+
+	return nil
+}
+
+// load compiles and adds all of the declarations from a parsed file. Function
+// names are prefixed in the same way as compiler.CompilePackage.
+func (r *REPL) load(p *parser.Parser, prefix string) error {
+	for name, i := range p.Interfaces {
+		r.file.Interfaces[name] = i
+	}
+
+	for name, c := range p.Constants {
+		r.file.Constants[name] = c
+	}
+
+	// The definitions must all be known before compiling so that the
+	// functions can call each other.
+	for name, fn := range p.File.Funcs {
+		r.renameFuncLiterals(fn)
+		r.file.FuncDefs[prefix+name] = fn
+	}
+
+	for name, fn := range p.File.Funcs {
+		compiled, err := compiler.CompileFunc(fn, r.file)
+		if err != nil {
+			return err
+		}
+
+		r.file.Funcs[prefix+name] = compiled
+	}
+
+	return nil
+}
+
+// parse wraps the input in a function so that it can be parsed as statements.
+func (r *REPL) parse(input string) ([]ast.Node, []error) {
+	// The input starts on the same line as the function so that line numbers
+	// are correct. See trimPosition.
+	p := parser.ParseString("func "+funcName+"() {"+input+"\n}", fileName)
+	if errs := p.Errors(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	fn := p.File.Funcs[funcName]
+	r.renameFuncLiterals(fn)
+
+	return fn.Statements, nil
+}
+
+// compile translates the input into a function that can be run in the top
+// level scope. Any statement that is an expression will have its value printed.
+func (r *REPL) compile(input string) (*vm.CompiledFunc, []error) {
+	stmts, errs := r.parse(input)
+	if errs != nil {
+		return nil, errs
+	}
+
+	compiled := r.newFunc()
+	for _, stmt := range stmts {
+		if !isExpr(stmt) {
+			err := compiler.CompileStatements(compiled, []ast.Node{stmt}, r.file)
+			if err != nil {
+				return nil, []error{err}
+			}
+
+			continue
+		}
+
+		results, _, err := compiler.CompileExpr(compiled, stmt, r.file)
+		if err != nil {
+			return nil, []error{err}
+		}
+
+		if results = nonEmpty(results); len(results) > 0 {
+			compiled.Append(&vm.Print{Arguments: results})
+		}
+	}
+
+	// The variables are kept in the top level scope, rather than registers,
+	// so they are still there for the next input.
+	compiled.ShareVariables()
+
+	return compiled, nil
+}
+
+func (r *REPL) typeOf(input string) []error {
+	stmts, errs := r.parse(input)
+	if errs != nil {
+		return errs
+	}
+
+	if len(stmts) != 1 || !isExpr(stmts[0]) {
+		return []error{errors.New(":type expects a single expression")}
+	}
+
+	_, types, err := compiler.CompileExpr(r.newFunc(), stmts[0], r.file)
+	if err != nil {
+		return []error{err}
+	}
+
+	fmt.Fprintln(r.out, strings.Join(nonEmptyTypes(types), ", "))
+
+	return nil
+}
+
+func (r *REPL) asm(input string) []error {
+	compiled, errs := r.compile(input)
+	if errs != nil {
+		return errs
+	}
+
+	// This is the same format as "ok asm".
+	for i, ins := range compiled.Instructions {
+		ty := fmt.Sprintf("%T", ins)[4:]
+		fmt.Fprintf(r.out, "  %3d %-22s # %s\n", i+1, ty, ins)
+	}
+
+	for _, scope := range compiled.ErrorScopes {
+		fmt.Fprintf(r.out, "  %s\n", scope)
+	}
+
+	return nil
+}
+
+// newFunc creates a function that already knows about the variables in the top
+// level scope.
+func (r *REPL) newFunc() *vm.CompiledFunc {
+	fn := &vm.CompiledFunc{
+		Variables:  map[string]string{},
+		Interfaces: r.file.Interfaces,
+	}
+
+	for name, ty := range r.variables {
+		fn.NewVariable(name, ty)
+	}
+
+	return fn
+}
+
+// renameFuncLiterals gives function literals (including nested functions) a
+// name that is unique to this input. The parser names them "1", "2", etc which
+// is only unique within a single parse.
+func (r *REPL) renameFuncLiterals(fn *ast.Func) {
+	r.inputs++
+	prefix := fmt.Sprintf("%d_", r.inputs)
+	walkFuncs(reflect.ValueOf(fn.Statements), func(fn *ast.Func) {
+		fn.Name = prefix + fn.Name
+	})
+}
+
+// walkFuncs calls visit for every function found anywhere inside v.
+func walkFuncs(v reflect.Value, visit func(*ast.Func)) {
+	switch v.Kind() {
+	case reflect.Interface:
+		walkFuncs(v.Elem(), visit)
+
+	case reflect.Ptr:
+		if v.IsNil() || !v.CanInterface() {
+			return
+		}
+
+		if fn, ok := v.Interface().(*ast.Func); ok {
+			visit(fn)
+		}
+
+		walkFuncs(v.Elem(), visit)
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			walkFuncs(v.Field(i), visit)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkFuncs(v.Index(i), visit)
+		}
+
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			walkFuncs(v.MapIndex(key), visit)
+		}
+	}
+}
+
+// isExpr returns true if the value of the statement should be printed.
+func isExpr(stmt ast.Node) bool {
+	switch n := stmt.(type) {
+	case *ast.Assign, *ast.Assert, *ast.AssertRaise, *ast.Break, *ast.Continue,
+		*ast.ErrorScope, *ast.For, *ast.If, *ast.Raise, *ast.Return, *ast.Switch:
+		return false
+
+	case *ast.Unary:
+		return n.Op != lexer.TokenIncrement && n.Op != lexer.TokenDecrement
+	}
+
+	return true
+}
+
+// nonEmpty removes the registers of functions that do not return a value,
+// such as print.
+func nonEmpty(registers []vm.Register) []vm.Register {
+	var results []vm.Register
+	for _, register := range registers {
+		if register != vm.NoRegister {
+			results = append(results, register)
+		}
+	}
+
+	return results
+}
+
+func nonEmptyTypes(types []string) []string {
+	var results []string
+	for _, ty := range types {
+		if ty != "" {
+			results = append(results, ty)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,145 @@
+package repl_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/elliotchance/ok/repl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestREPL_Eval(t *testing.T) {
+	for testName, test := range map[string]struct {
+		inputs   []string
+		expected string
+		errs     []string
+	}{
+		"bare-expression": {
+			inputs:   []string{"1 + 2"},
+			expected: "3\n",
+		},
+		"print-is-not-repeated": {
+			inputs:   []string{`print("hi")`},
+			expected: "hi\n",
+		},
+		"variables-persist": {
+			inputs:   []string{"a = 3", "b = a * 2", "a + b"},
+			expected: "9\n",
+		},
+		"multiple-returns": {
+			inputs: []string{
+				"func swap(a, b number) (number, number) {\n    return b, a\n}",
+				"swap(1, 2)",
+			},
+			expected: "2 1\n",
+		},
+		"function-declaration": {
+			inputs: []string{
+				"func double(x number) number {\n    return x * 2\n}",
+				"double(4)",
+			},
+			expected: "8\n",
+		},
+		"function-literals-are-unique": {
+			inputs: []string{
+				"a = 3",
+				"f = func() number { return ^a }",
+				"g = func() number { return 7 }",
+				"f() + g()",
+			},
+			expected: "10\n",
+		},
+		"stdlib": {
+			inputs:   []string{`import "strings"`, `strings.ToUpper("abc")`},
+			expected: "ABC\n",
+		},
+		"block": {
+			inputs:   []string{"for i = 0; i < 3; ++i {\n    print(i)\n}"},
+			expected: "0\n1\n2\n",
+		},
+		"increment-is-not-printed": {
+			inputs:   []string{"a = 1", "++a", "a"},
+			expected: "2\n",
+		},
+		"compile-error-keeps-state": {
+			inputs:   []string{"a = 1", "b = c", "a"},
+			expected: "1\n",
+			errs:     []string{"repl:1:20 undefined variable: c"},
+		},
+		"unhandled-error": {
+			inputs:   []string{`raise Error("boom")`, "1"},
+			expected: "1\n",
+			errs:     []string{"unhandled Error: boom"},
+		},
+		"type": {
+			inputs:   []string{"a = 1", ":type a", `:type "a" + "b"`},
+			expected: "number\nstring\n",
+		},
+		"asm": {
+			inputs: []string{"a = 1", ":asm a + 2"},
+			expected: "    1 Assign                 # $2 = \"2\"\n" +
+				"    2 Add                    # $3 = $1 + $2\n" +
+				"    3 Print                  # print($3)\n",
+		},
+		"asm-does-not-run": {
+			inputs: []string{":asm a = 1", "a"},
+			expected: "    1 Assign                 # $1 = \"1\"\n" +
+				"    2 Assign                 # $2 = $1\n",
+			errs: []string{"repl:1:16 undefined variable: a"},
+		},
+		"reset": {
+			inputs: []string{"a = 1", ":reset", "a"},
+			errs:   []string{"repl:1:16 undefined variable: a"},
+		},
+		"unknown-command": {
+			inputs: []string{":foo bar"},
+			errs:   []string{"unknown command: :foo"},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var out bytes.Buffer
+			r := repl.New(&out)
+
+			var errs []string
+			for _, input := range test.inputs {
+				for _, err := range r.Eval(input) {
+					errs = append(errs, err.Error())
+				}
+			}
+
+			assert.Equal(t, test.expected, out.String())
+			assert.Equal(t, test.errs, errs)
+		})
+	}
+}
+
+func TestREPL_Run(t *testing.T) {
+	var out bytes.Buffer
+	in := strings.NewReader("a = 1\nif a == 1 {\n    print(b)\n}\nfor i = 0; i < 1; ++i {\n    print(a)\n}\n")
+	err := repl.New(&out).Run(in)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "> > ... ... undefined variable: b\n> ... ... 1\n> ",
+		out.String())
+}
+
+func TestIsComplete(t *testing.T) {
+	for input, expected := range map[string]bool{
+		"":                    true,
+		"a = 1":               true,
+		"if a {":              false,
+		"if a {\n}":           true,
+		"foo(1,":              false,
+		"a = [1, 2,\n3]":      true,
+		`a = "\{"`:            true,
+		`a = "{"`:             true,
+		"a = 1 // {":          true,
+		"}":                   true,
+		"if a {\n  b = [1,\n": false,
+	} {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, expected, repl.IsComplete(input))
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Severities for a Diagnostic.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is a problem found in the source code. It is also an error so
+// that it can be returned anywhere an error is expected.
+type Diagnostic struct {
+	// File, Line and Column are the start of the problem. Lines and columns
+	// start at 1. Line will be 0 if the position is not known.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+
+	// EndLine and EndColumn is the position after the last character of the
+	// problem. They will be 0 if the end is not known.
+	EndLine   int `json:"endLine,omitempty"`
+	EndColumn int `json:"endColumn,omitempty"`
+
+	// Severity is SeverityError or SeverityWarning.
+	Severity string `json:"severity"`
+
+	// Code identifies the kind of problem, such as "undefined-variable". It
+	// may be empty.
+	Code string `json:"code,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// Error returns the message with the position in front, like
+// "main.ok:3:5 undefined variable: a".
+func (d *Diagnostic) Error() string {
+	if d.Line == 0 {
+		return d.Message
+	}
+
+	return fmt.Sprintf("%s:%d:%d %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// header is the first line shown for the diagnostic, like
+// "main.ok:3:5: error[undefined-variable]: undefined variable: a".
+func (d *Diagnostic) header() string {
+	s := d.Severity
+	if d.Code != "" {
+		s += "[" + d.Code + "]"
+	}
+	s += ": " + d.Message
+
+	if d.Line == 0 {
+		return s
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, s)
+}
+
+// Locate sets the end of the diagnostic to the end of the token at its start
+// position. source is the contents of File.
+func (d *Diagnostic) Locate(source string) {
+	line := lineOf(source, d.Line)
+	if line == "" {
+		return
+	}
+
+	d.EndLine = d.Line
+	d.EndColumn = d.Column + TokenLength(line, d.Column-1)
+}
+
+// Snippet returns the line of source that the diagnostic refers to with a
+// caret under the problem. It returns an empty string if the line cannot be
+// found in source.
+func (d *Diagnostic) Snippet(source string) string {
+	line := lineOf(source, d.Line)
+	if line == "" {
+		return ""
+	}
+
+	runes := []rune(line)
+	column := d.Column - 1
+	if column < 0 || column > len(runes) {
+		return ""
+	}
+
+	carets := 1
+	if d.EndLine == d.Line && d.EndColumn > d.Column {
+		carets = d.EndColumn - d.Column
+	}
+
+	// Tabs are kept so that the caret lines up with the code.
+	indent := ""
+	for _, r := range runes[:column] {
+		if r == '\t' {
+			indent += "\t"
+		} else {
+			indent += " "
+		}
+	}
+
+	gutter := fmt.Sprintf("%d", d.Line)
+
+	return fmt.Sprintf("%s | %s\n%s | %s%s\n", gutter, line,
+		strings.Repeat(" ", len(gutter)), indent, strings.Repeat("^", carets))
+}
+
+// SortErrors orders diagnostics by their file and position. Any other errors
+// are kept in the same order at the start.
+func SortErrors(errs []error) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, _ := errs[i].(*Diagnostic)
+		b, _ := errs[j].(*Diagnostic)
+
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		case a.File != b.File:
+			return a.File < b.File
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		}
+
+		return a.Column < b.Column
+	})
+}
+
+// lineOf returns the one-based line from source.
+func lineOf(source string, line int) string {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	return strings.TrimRight(lines[line-1], "\r")
+}
+
+// TokenLength returns the number of runes that make up the token that starts
+// at the zero-based rune column. Strings are included up to the closing
+// quote.
+func TokenLength(line string, column int) int {
+	runes := []rune(line)
+	if column < 0 || column >= len(runes) {
+		return 0
+	}
+
+	if quote := runes[column]; quote == '"' || quote == '\'' || quote == '`' {
+		for i := column + 1; i < len(runes); i++ {
+			if runes[i] == '\\' {
+				i++
+				continue
+			}
+
+			if runes[i] == quote {
+				return i - column + 1
+			}
+		}
+
+		return len(runes) - column
+	}
+
+	end := column
+	for end < len(runes) && isWordChar(runes[end]) {
+		end++
+	}
+
+	if end == column {
+		return 1
+	}
+
+	return end - column
+}
+
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnostic_Error(t *testing.T) {
+	for testName, test := range map[string]struct {
+		d        *Diagnostic
+		expected string
+	}{
+		"no-position": {
+			&Diagnostic{Message: "import cycle"},
+			"import cycle",
+		},
+		"position": {
+			&Diagnostic{File: "a.ok", Line: 3, Column: 5, Message: "oops"},
+			"a.ok:3:5 oops",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.d.Error())
+		})
+	}
+}
+
+func TestDiagnostic_Snippet(t *testing.T) {
+	source := "func main() {\n    print(\"foo\")\n\tprint(bar)\n}\n"
+
+	for testName, test := range map[string]struct {
+		line, column int
+		expected     string
+	}{
+		"word":    {3, 8, "3 | \tprint(bar)\n  | \t      ^^^\n"},
+		"string":  {2, 11, "2 |     print(\"foo\")\n  |           ^^^^^\n"},
+		"symbol":  {2, 10, "2 |     print(\"foo\")\n  |          ^\n"},
+		"no-line": {9, 1, ""},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			d := &Diagnostic{File: "a.ok", Line: test.line, Column: test.column}
+			d.Locate(source)
+			assert.Equal(t, test.expected, d.Snippet(source))
+		})
+	}
+}
+
+func TestSortErrors(t *testing.T) {
+	a := &Diagnostic{File: "a.ok", Line: 2, Column: 5}
+	b := &Diagnostic{File: "a.ok", Line: 2, Column: 9}
+	c := &Diagnostic{File: "a.ok", Line: 10, Column: 1}
+	d := &Diagnostic{File: "b.ok", Line: 1, Column: 1}
+	other := errors.New("other")
+
+	errs := []error{d, c, other, b, a}
+	SortErrors(errs)
+
+	assert.Equal(t, []error{other, a, b, c, d}, errs)
+}
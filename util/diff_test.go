@@ -0,0 +1,45 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	for testName, test := range map[string]struct {
+		a, b     string
+		expected string
+	}{
+		"same": {"a\nb\n", "a\nb\n", ""},
+		"change": {
+			"a\nb\nc\n", "a\nB\nc\n",
+			"--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		"add": {
+			"a\n", "a\nb\n",
+			"--- old\n+++ new\n@@ -1,1 +1,2 @@\n a\n+b\n",
+		},
+		"remove": {
+			"a\nb\n", "b\n",
+			"--- old\n+++ new\n@@ -1,2 +1,1 @@\n-a\n b\n",
+		},
+		"empty": {
+			"", "a\n",
+			"--- old\n+++ new\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+		"context": {
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n", "1\n2\n3\n4\nX\n6\n7\n8\n9\n",
+			"--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+X\n 6\n 7\n 8\n",
+		},
+		"two-hunks": {
+			"a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n", "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			"--- old\n+++ new\n@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+				"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, Diff("old", "new", test.a, test.b))
+		})
+	}
+}
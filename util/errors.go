@@ -1,15 +1,54 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 )
 
 // CheckErrorsWithExit is used in several places to bail out after a compilation
-// that contains failures.
+// that contains failures. Each diagnostic is shown with the line of source that
+// it refers to.
 func CheckErrorsWithExit(errs []error) {
+	sources := map[string]string{}
 	for _, err := range errs {
-		fmt.Println(err)
+		d, ok := err.(*Diagnostic)
+		if !ok {
+			fmt.Println(err)
+			continue
+		}
+
+		fmt.Println(d.header())
+
+		if _, ok := sources[d.File]; !ok {
+			data, _ := ioutil.ReadFile(d.File)
+			sources[d.File] = string(data)
+		}
+		fmt.Print(d.Snippet(sources[d.File]))
+	}
+
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
+
+// CheckErrorsWithExitJSON is the same as CheckErrorsWithExit except that each
+// error is printed as a JSON object on its own line. Errors that are not
+// diagnostics only have a severity and message.
+func CheckErrorsWithExitJSON(errs []error) {
+	encoder := json.NewEncoder(os.Stdout)
+	for _, err := range errs {
+		d, ok := err.(*Diagnostic)
+		if !ok {
+			d = &Diagnostic{
+				Severity: SeverityError,
+				Message:  err.Error(),
+			}
+		}
+
+		// Encoding a struct of strings and ints cannot fail.
+		_ = encoder.Encode(d)
 	}
 
 	if len(errs) > 0 {
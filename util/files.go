@@ -2,7 +2,10 @@ package util
 
 import (
 	"io/ioutil"
+	"os"
 	"path"
+	"path/filepath"
+	"strings"
 )
 
 // GetAllOKFilesInPath non-recursively returns a list of OK files.
@@ -26,3 +29,85 @@ func GetAllOKFilesInPath(dir string, includeTests bool) ([]string, error) {
 
 	return files, nil
 }
+
+// ExpandPackages replaces each argument that ends with "..." (such as "./...")
+// with every package in or below that directory. A package is any directory
+// that contains .ok or .okt files. Directories named "testdata" or that start
+// with "." or "_" are skipped.
+//
+// Other arguments are returned unchanged. Each package is only returned once,
+// in the order it was first found.
+func ExpandPackages(args []string) ([]string, error) {
+	var packages []string
+	seen := map[string]bool{}
+	add := func(pkg string) {
+		if key := filepath.Clean(pkg); !seen[key] {
+			seen[key] = true
+			packages = append(packages, pkg)
+		}
+	}
+
+	for _, arg := range args {
+		if !isPackagePattern(arg) {
+			add(arg)
+			continue
+		}
+
+		root := filepath.Clean(strings.TrimSuffix(arg, "..."))
+		err := filepath.Walk(root, func(dir string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !info.IsDir() {
+				return nil
+			}
+
+			if dir != root && isExcludedDir(info.Name()) {
+				return filepath.SkipDir
+			}
+
+			fileNames, err := GetAllOKFilesInPath(dir, true)
+			if err != nil {
+				return err
+			}
+
+			if len(fileNames) > 0 {
+				add(dir)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return packages, nil
+}
+
+// NamedPackages returns the arguments that are not patterns, such as "./...".
+// The keys are cleaned with filepath.Clean.
+//
+// Commands that need a main function use this to tell a package that was
+// named directly from one that was only matched by a pattern.
+func NamedPackages(args []string) map[string]bool {
+	named := map[string]bool{}
+	for _, arg := range args {
+		if !isPackagePattern(arg) {
+			named[filepath.Clean(arg)] = true
+		}
+	}
+
+	return named
+}
+
+func isPackagePattern(arg string) bool {
+	return strings.HasSuffix(arg, "...")
+}
+
+func isExcludedDir(name string) bool {
+	return name == "testdata" ||
+		strings.HasPrefix(name, ".") ||
+		strings.HasPrefix(name, "_")
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPackages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok-util")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, fileName := range []string{
+		"main.ok",
+		"a/a.ok",
+		"a/b/b.okt",
+		"a/empty/README.md",
+		"a/testdata/data.ok",
+		"c/c.ok",
+		".git/hidden.ok",
+		"_build/ignored.ok",
+	} {
+		fileName = filepath.Join(dir, fileName)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+		require.NoError(t, ioutil.WriteFile(fileName, nil, 0644))
+	}
+
+	for testName, test := range map[string]struct {
+		args     []string
+		expected []string
+	}{
+		"no-patterns": {
+			args:     []string{"foo", "bar"},
+			expected: []string{"foo", "bar"},
+		},
+		"all": {
+			args: []string{dir + "/..."},
+			expected: []string{
+				dir,
+				filepath.Join(dir, "a"),
+				filepath.Join(dir, "a/b"),
+				filepath.Join(dir, "c"),
+			},
+		},
+		"sub-directory": {
+			args: []string{filepath.Join(dir, "a") + "/..."},
+			expected: []string{
+				filepath.Join(dir, "a"),
+				filepath.Join(dir, "a/b"),
+			},
+		},
+		"duplicates": {
+			args: []string{
+				filepath.Join(dir, "c"),
+				filepath.Join(dir, "a/b") + "/...",
+				dir + "/...",
+			},
+			expected: []string{
+				filepath.Join(dir, "c"),
+				filepath.Join(dir, "a/b"),
+				dir,
+				filepath.Join(dir, "a"),
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			actual, err := ExpandPackages(test.args)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNamedPackages(t *testing.T) {
+	for testName, test := range map[string]struct {
+		args     []string
+		expected map[string]bool
+	}{
+		"none": {
+			args:     nil,
+			expected: map[string]bool{},
+		},
+		"named": {
+			args:     []string{"./foo/", "bar"},
+			expected: map[string]bool{"foo": true, "bar": true},
+		},
+		"patterns": {
+			args:     []string{"./...", "foo/...", "foo"},
+			expected: map[string]bool{"foo": true},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, NamedPackages(test.args))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadMessage reads the body of a single message that is prefixed with headers
+// in the same format as HTTP. Only Content-Length is required. This is the
+// framing used by both the Language Server Protocol and the Debug Adapter
+// Protocol.
+func ReadMessage(r *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) == 2 &&
+			strings.EqualFold(strings.TrimSpace(parts[0]), "Content-Length") {
+			value := strings.TrimSpace(parts[1])
+			contentLength, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length: %s", value)
+			}
+		}
+	}
+
+	if contentLength < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// WriteMessage encodes v as JSON and writes it with the Content-Length header.
+// See ReadMessage.
+func WriteMessage(w io.Writer, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+
+	return err
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMessage(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMessage(&buf, map[string]int{"seq": 1}))
+	assert.Equal(t, "Content-Length: 9\r\n\r\n{\"seq\":1}", buf.String())
+}
+
+func TestReadMessage(t *testing.T) {
+	tests := map[string]struct {
+		in, body, err string
+	}{
+		"body":          {"Content-Length: 2\r\n\r\n{}", "{}", ""},
+		"extra-headers": {"Content-Type: x\r\ncontent-length: 2\r\n\r\n{}", "{}", ""},
+		"missing":       {"Content-Type: x\r\n\r\n{}", "", "missing Content-Length header"},
+		"invalid":       {"Content-Length: x\r\n\r\n{}", "", "invalid Content-Length: x"},
+		"short":         {"Content-Length: 5\r\n\r\n{}", "", "unexpected EOF"},
+	}
+	for testName, tt := range tests {
+		t.Run(testName, func(t *testing.T) {
+			body, err := ReadMessage(bufio.NewReader(strings.NewReader(tt.in)))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.body, string(body))
+			}
+		})
+	}
+}
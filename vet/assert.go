@@ -0,0 +1,24 @@
+package vet
+
+import (
+	"github.com/elliotchance/ok/ast"
+)
+
+var assertCheck = &Check{
+	Name:        "assert",
+	Description: "report assert used outside of a test",
+	run: func(p *pass, fn *function) {
+		if fn.test {
+			return
+		}
+
+		inspectBody(fn.Func, func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.Assert, *ast.AssertRaise:
+				p.report(n.Position(), "assert can only be used in a test")
+			}
+
+			return true
+		})
+	},
+}
@@ -0,0 +1,7 @@
+// Package vet implements the static analysis used by "ok vet". It finds code
+// that compiles but is very likely to be a mistake.
+//
+// Each Check looks at the ast of every function, function literal and test in
+// a package. The diagnostics are warnings and use the name of the check as the
+// code.
+package vet
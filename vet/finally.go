@@ -0,0 +1,33 @@
+package vet
+
+import (
+	"github.com/elliotchance/ok/ast"
+)
+
+var finallyCheck = &Check{
+	Name:        "finally",
+	Description: "report return inside a finally block",
+	run: func(p *pass, fn *function) {
+		// A return may be inside of more than one finally block.
+		reported := map[*ast.Return]bool{}
+
+		inspectBody(fn.Func, func(n ast.Node) bool {
+			finally, ok := n.(*ast.Finally)
+			if !ok {
+				return true
+			}
+
+			inspectBody(&ast.Func{Statements: finally.Statements}, func(n ast.Node) bool {
+				if n, ok := n.(*ast.Return); ok && !reported[n] {
+					reported[n] = true
+					p.report(n.Position(),
+						"return is not allowed in a finally block")
+				}
+
+				return true
+			})
+
+			return true
+		})
+	},
+}
@@ -0,0 +1,164 @@
+package vet
+
+import (
+	"github.com/elliotchance/ok/ast"
+)
+
+// inspect calls visit for node and then each of the nodes inside of it, in the
+// order they appear in the source. The nodes inside are skipped if visit
+// returns false.
+func inspect(node ast.Node, visit func(ast.Node) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	each := func(nodes []ast.Node) {
+		for _, n := range nodes {
+			inspect(n, visit)
+		}
+	}
+
+	switch n := node.(type) {
+	case *ast.Func:
+		each(n.Statements)
+
+	case *ast.Array:
+		each(n.Elements)
+
+	case *ast.Assert:
+		if n.Expr != nil {
+			inspect(n.Expr, visit)
+		}
+		inspect(n.Message, visit)
+
+	case *ast.AssertRaise:
+		inspect(n.Expr, visit)
+		inspect(n.Message, visit)
+
+	case *ast.Assign:
+		each(n.Lefts)
+		each(n.Rights)
+
+	case *ast.Binary:
+		inspect(n.Left, visit)
+		inspect(n.Right, visit)
+
+	case *ast.Call:
+		each(n.Arguments)
+
+	case *ast.ErrorScope:
+		each(n.Statements)
+		for _, on := range n.On {
+			inspect(on, visit)
+		}
+		if n.Finally != nil {
+			inspect(n.Finally, visit)
+		}
+
+	case *ast.On:
+		each(n.Statements)
+
+	case *ast.Finally:
+		each(n.Statements)
+
+	case *ast.For:
+		inspect(n.Init, visit)
+		inspect(n.Condition, visit)
+		inspect(n.Next, visit)
+		each(n.Statements)
+
+	case *ast.In:
+		inspect(n.Expr, visit)
+
+	case *ast.Group:
+		inspect(n.Expr, visit)
+
+	case *ast.If:
+		inspect(n.Condition, visit)
+		each(n.True)
+		each(n.False)
+
+	case *ast.Interpolate:
+		each(n.Parts)
+
+	case *ast.Key:
+		inspect(n.Expr, visit)
+		inspect(n.Key, visit)
+
+	case *ast.Map:
+		for _, element := range n.Elements {
+			inspect(element, visit)
+		}
+
+	case *ast.KeyValue:
+		inspect(n.Key, visit)
+		inspect(n.Value, visit)
+
+	case *ast.Raise:
+		if n.Err != nil {
+			inspect(n.Err, visit)
+		}
+
+	case *ast.Return:
+		each(n.Exprs)
+
+	case *ast.Switch:
+		inspect(n.Expr, visit)
+		for _, c := range n.Cases {
+			inspect(c, visit)
+		}
+		each(n.Else)
+
+	case *ast.Case:
+		each(n.Conditions)
+		each(n.Statements)
+
+	case *ast.Unary:
+		inspect(n.Expr, visit)
+	}
+}
+
+// inspectBody is the same as inspect for the statements of fn, except that
+// function literals are not entered. They are checked separately.
+func inspectBody(fn *ast.Func, visit func(ast.Node) bool) {
+	for _, stmt := range fn.Statements {
+		inspect(stmt, func(n ast.Node) bool {
+			if _, ok := n.(*ast.Func); ok {
+				return false
+			}
+
+			return visit(n)
+		})
+	}
+}
+
+// blocks returns the lists of statements directly inside of node.
+func blocks(node ast.Node) [][]ast.Node {
+	switch n := node.(type) {
+	case *ast.Func:
+		return [][]ast.Node{n.Statements}
+
+	case *ast.ErrorScope:
+		return [][]ast.Node{n.Statements}
+
+	case *ast.On:
+		return [][]ast.Node{n.Statements}
+
+	case *ast.Finally:
+		return [][]ast.Node{n.Statements}
+
+	case *ast.For:
+		return [][]ast.Node{n.Statements}
+
+	case *ast.If:
+		return [][]ast.Node{n.True, n.False}
+
+	case *ast.Switch:
+		return [][]ast.Node{n.Else}
+
+	case *ast.Case:
+		return [][]ast.Node{n.Statements}
+	}
+
+	return nil
+}
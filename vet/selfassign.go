@@ -0,0 +1,32 @@
+package vet
+
+import (
+	"github.com/elliotchance/ok/ast"
+)
+
+var selfAssignCheck = &Check{
+	Name:        "selfassign",
+	Description: "report a variable assigned to itself",
+	run: func(p *pass, fn *function) {
+		inspectBody(fn.Func, func(n ast.Node) bool {
+			assign, ok := n.(*ast.Assign)
+			if !ok || len(assign.Lefts) != len(assign.Rights) {
+				return true
+			}
+
+			for i, left := range assign.Lefts {
+				left, ok := left.(*ast.Identifier)
+				if !ok {
+					continue
+				}
+
+				right, ok := assign.Rights[i].(*ast.Identifier)
+				if ok && left.Name == right.Name {
+					p.report(left.Position(), "self-assignment of %s", left.Name)
+				}
+			}
+
+			return true
+		})
+	},
+}
@@ -0,0 +1,24 @@
+package vet
+
+import (
+	"github.com/elliotchance/ok/util"
+)
+
+var shadowCheck = &Check{
+	Name:        "shadow",
+	Description: "report a variable with the same name as a function",
+	run: func(p *pass, fn *function) {
+		for _, v := range variables(fn.Func) {
+			// Public variables of an object are its properties, so they can
+			// never be called like a function.
+			if fn.IsConstructor() && util.IsPublic(v.name) {
+				continue
+			}
+
+			if _, ok := p.funcs[v.name]; ok {
+				p.report(v.pos, "variable %s shadows function %s",
+					v.name, v.name)
+			}
+		}
+	},
+}
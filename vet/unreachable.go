@@ -0,0 +1,43 @@
+package vet
+
+import (
+	"github.com/elliotchance/ok/ast"
+)
+
+var unreachableCheck = &Check{
+	Name:        "unreachable",
+	Description: "report code after return, raise, break or continue",
+	run: func(p *pass, fn *function) {
+		check := func(n ast.Node) bool {
+			for _, block := range blocks(n) {
+				for i := 0; i+1 < len(block); i++ {
+					if isTerminator(block[i]) {
+						pos := block[i+1].Position()
+						if pos == "" {
+							pos = block[i].Position()
+						}
+
+						p.report(pos, "unreachable code")
+						break
+					}
+				}
+			}
+
+			return true
+		}
+
+		check(fn.Func)
+		inspectBody(fn.Func, check)
+	},
+}
+
+// isTerminator returns true if the statements that follow stmt in the same
+// block can never run.
+func isTerminator(stmt ast.Node) bool {
+	switch stmt.(type) {
+	case *ast.Return, *ast.Raise, *ast.Break, *ast.Continue:
+		return true
+	}
+
+	return false
+}
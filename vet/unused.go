@@ -0,0 +1,89 @@
+package vet
+
+import (
+	"strings"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/util"
+)
+
+var unusedCheck = &Check{
+	Name:        "unused",
+	Description: "report a variable that is assigned but never used",
+	run: func(p *pass, fn *function) {
+		used := usedVariables(fn.Func)
+
+		for _, v := range variables(fn.Func) {
+			// Public variables of an object are its properties.
+			if v.argument || used[v.name] ||
+				(fn.IsConstructor() && util.IsPublic(v.name)) {
+				continue
+			}
+
+			p.report(v.pos, "%s is assigned but never used", v.name)
+		}
+	},
+}
+
+// usedVariables returns the names of the variables that are read in fn. This
+// includes variables that are read by function literals through "^".
+func usedVariables(fn *ast.Func) map[string]bool {
+	used := map[string]bool{}
+	use := func(name string) {
+		// A method is called on a variable, like "a.foo()".
+		used[strings.Split(name, ".")[0]] = true
+	}
+
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Func:
+			inspect(n, func(n ast.Node) bool {
+				var name string
+				switch n := n.(type) {
+				case *ast.Identifier:
+					name = n.Name
+				case *ast.Call:
+					name = n.FunctionName
+				}
+
+				if strings.HasPrefix(name, "^") {
+					use(name[1:])
+				}
+
+				return true
+			})
+
+			return false
+
+		case *ast.Assign:
+			// Assigning to a variable is not a use, but the expressions inside
+			// of a key on the left are. Such as a[i] = 1.
+			for _, left := range n.Lefts {
+				if _, ok := left.(*ast.Identifier); !ok {
+					inspect(left, visit)
+				}
+			}
+
+			for _, right := range n.Rights {
+				inspect(right, visit)
+			}
+
+			return false
+
+		case *ast.Identifier:
+			use(n.Name)
+
+		case *ast.Call:
+			use(n.FunctionName)
+		}
+
+		return true
+	}
+
+	for _, stmt := range fn.Statements {
+		inspect(stmt, visit)
+	}
+
+	return used
+}
@@ -0,0 +1,63 @@
+package vet
+
+import (
+	"strings"
+
+	"github.com/elliotchance/ok/ast"
+)
+
+// variable is a variable that is assigned in a function.
+type variable struct {
+	name, pos string
+
+	// argument is true for the function arguments.
+	argument bool
+}
+
+// variables returns each variable in fn in the order that they are first
+// assigned. Variables in the parent scope ("^a") and "_", which is used to
+// discard values, are not included.
+func variables(fn *ast.Func) []*variable {
+	var vars []*variable
+	seen := map[string]bool{}
+	add := func(name, pos string, argument bool) {
+		if name != "" && name != "_" && !strings.HasPrefix(name, "^") &&
+			!seen[name] {
+			seen[name] = true
+			vars = append(vars, &variable{name, pos, argument})
+		}
+	}
+
+	for _, arg := range fn.Arguments {
+		add(arg.Name, fn.Pos, true)
+	}
+
+	inspectBody(fn, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Assign:
+			for i, left := range n.Lefts {
+				left, ok := left.(*ast.Identifier)
+				if !ok {
+					continue
+				}
+
+				// Nested functions are assigned to a variable that does not
+				// have a position.
+				pos := left.Position()
+				if pos == "" && i < len(n.Rights) {
+					pos = n.Rights[i].Position()
+				}
+
+				add(left.Name, pos, false)
+			}
+
+		case *ast.In:
+			add(n.Value, n.Pos, false)
+			add(n.Key, n.Pos, false)
+		}
+
+		return true
+	})
+
+	return vars
+}
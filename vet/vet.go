@@ -0,0 +1,194 @@
+package vet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/lexer"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/util"
+)
+
+// Check is a single analysis. Each check can be turned on or off.
+type Check struct {
+	// Name is used for the flag in "ok vet" and as the code of each
+	// diagnostic.
+	Name string
+
+	// Description is shown in "ok vet -help".
+	Description string
+
+	run func(p *pass, fn *function)
+}
+
+// Checks contains all of the available checks, ordered by name.
+var Checks = []*Check{
+	assertCheck,
+	finallyCheck,
+	selfAssignCheck,
+	shadowCheck,
+	unreachableCheck,
+	unusedCheck,
+}
+
+// function is a function, function literal or test to be checked.
+type function struct {
+	*ast.Func
+
+	// test is true for tests and function literals inside of tests.
+	test bool
+}
+
+// pass is a single check being run over a package.
+type pass struct {
+	check *Check
+
+	// funcs are the functions declared at the package level.
+	funcs map[string]*ast.Func
+
+	diagnostics []error
+}
+
+func (p *pass) report(pos, format string, args ...interface{}) {
+	at := lexer.ParsePos(pos)
+	p.diagnostics = append(p.diagnostics, &util.Diagnostic{
+		File:     at.FileName,
+		Line:     at.LineNumber,
+		Column:   at.CharacterNumber,
+		Severity: util.SeverityWarning,
+		Code:     p.check.Name,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Package runs the checks on all of the files (including tests) in dir. If
+// any of the files cannot be parsed only the parser errors are returned.
+func Package(dir string, checks []*Check) []error {
+	fileNames, err := util.GetAllOKFilesInPath(dir, true)
+	if err != nil {
+		return []error{err}
+	}
+
+	sources := map[string]string{}
+	for _, fileName := range fileNames {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return []error{err}
+		}
+
+		sources[fileName] = string(data)
+	}
+
+	return run(sources, checks)
+}
+
+// Source runs the checks on a single file.
+func Source(src, fileName string, checks []*Check) []error {
+	return run(map[string]string{fileName: src}, checks)
+}
+
+func run(sources map[string]string, checks []*Check) []error {
+	var fileNames []string
+	for fileName := range sources {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	var errs []error
+	var files []*parser.File
+	for _, fileName := range fileNames {
+		p := parser.ParseString(sources[fileName], fileName)
+		errs = append(errs, p.Errors()...)
+		files = append(files, p.File)
+	}
+
+	if len(errs) == 0 {
+		errs = runChecks(files, checks)
+	}
+
+	for _, err := range errs {
+		if d, ok := err.(*util.Diagnostic); ok {
+			d.Locate(sources[d.File])
+		}
+	}
+	util.SortErrors(errs)
+
+	return errs
+}
+
+func runChecks(files []*parser.File, checks []*Check) []error {
+	funcs := map[string]*ast.Func{}
+	var fns []*function
+	for _, file := range files {
+		var names []string
+		for name, fn := range file.Funcs {
+			funcs[name] = fn
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			fns = append(fns, functions(file.Funcs[name], false)...)
+		}
+
+		for _, test := range file.Tests {
+			// The value and key of a table-driven test are passed to the test
+			// in the same way as the arguments of a function, as are the
+			// arguments of a fuzz test.
+			arguments := test.Arguments
+			if test.Cases != nil {
+				arguments = append(arguments, &ast.Argument{Name: test.Cases.Value})
+				if test.Cases.Key != "" {
+					arguments = append(arguments, &ast.Argument{Name: test.Cases.Key})
+				}
+			}
+
+			fns = append(fns, functions(&ast.Func{
+				Name:       test.Name,
+				Arguments:  arguments,
+				Statements: test.Statements,
+				Pos:        test.Pos,
+			}, true)...)
+		}
+
+		for _, bench := range file.Benches {
+			fns = append(fns, functions(&ast.Func{
+				Name:       bench.Name,
+				Statements: bench.Statements,
+				Pos:        bench.Pos,
+			}, false)...)
+		}
+	}
+
+	var errs []error
+	for _, check := range checks {
+		p := &pass{
+			check: check,
+			funcs: funcs,
+		}
+
+		for _, fn := range fns {
+			check.run(p, fn)
+		}
+
+		errs = append(errs, p.diagnostics...)
+	}
+
+	return errs
+}
+
+// functions returns fn and all of the function literals inside of it.
+func functions(fn *ast.Func, test bool) []*function {
+	var fns []*function
+	inspect(fn, func(n ast.Node) bool {
+		if fn, ok := n.(*ast.Func); ok {
+			fns = append(fns, &function{Func: fn, test: test})
+		}
+
+		return true
+	})
+
+	return fns
+}
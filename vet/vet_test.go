@@ -0,0 +1,218 @@
+package vet_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/vet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src      string
+		expected []string
+	}{
+		"clean": {
+			src: `func main() {
+    a = 1
+    print(a)
+}`,
+		},
+		"assert-in-func": {
+			src: `func main() {
+    assert(1 == 1)
+}`,
+			expected: []string{"a.ok:2:5 assert can only be used in a test"},
+		},
+		"assert-raise-in-func": {
+			src: `func main() {
+    assert(main() raise Error)
+}`,
+			expected: []string{"a.ok:2:5 assert can only be used in a test"},
+		},
+		"assert-in-test": {
+			src: `test "foo" {
+    assert(1 == 1)
+}`,
+		},
+		"assert-in-func-literal-in-test": {
+			src: `test "foo" {
+    f = func() {
+        assert(1 == 1)
+    }
+    f()
+}`,
+		},
+		"assert-in-bench": {
+			src: `bench "foo" {
+    assert(1 == 1)
+}`,
+			expected: []string{"a.ok:2:5 assert can only be used in a test"},
+		},
+		"return-in-finally": {
+			src: `func main() {
+    try {
+        print(1)
+    } finally {
+        return
+    }
+}`,
+			expected: []string{"a.ok:5:9 return is not allowed in a finally block"},
+		},
+		"return-in-try": {
+			src: `func main() {
+    try {
+        return
+    } finally {
+        print(1)
+    }
+}`,
+		},
+		"self-assign": {
+			src: `func main() {
+    a = 1
+    a = a
+    print(a)
+}`,
+			expected: []string{"a.ok:3:5 self-assignment of a"},
+		},
+		"shadow-function": {
+			src: `func foo() {}
+func main() {
+    foo = 1
+    print(foo)
+}`,
+			expected: []string{"a.ok:3:5 variable foo shadows function foo"},
+		},
+		"shadow-test-case": {
+			src: `func foo() {}
+test "bar" for foo, i in [1, 2] {
+    assert(foo > 0)
+}`,
+			expected: []string{"a.ok:2:1 variable foo shadows function foo"},
+		},
+		"shadow-fuzz-argument": {
+			src: `func foo() {}
+fuzz "bar" (foo number) {
+    assert(foo == foo)
+}`,
+			expected: []string{"a.ok:2:1 variable foo shadows function foo"},
+		},
+		"shadow-property": {
+			src: `func Name() {}
+func Person(Name string) Person {}`,
+		},
+		"unreachable-after-return": {
+			src: `func main() {
+    return
+    print(1)
+}`,
+			expected: []string{"a.ok:3:5 unreachable code"},
+		},
+		"unreachable-after-break": {
+			src: `func main() {
+    for {
+        if true {
+            break
+            print(1)
+        }
+    }
+}`,
+			expected: []string{"a.ok:5:13 unreachable code"},
+		},
+		"unused": {
+			src: `func main() {
+    a = 1
+    b = 2
+    print(b)
+}`,
+			expected: []string{"a.ok:2:5 a is assigned but never used"},
+		},
+		"unused-for-in": {
+			src: `func main() {
+    for v, k in [1, 2] {
+        print(v)
+    }
+}`,
+			expected: []string{"a.ok:2:9 k is assigned but never used"},
+		},
+		"used-by-closure": {
+			src: `func main() {
+    a = 1
+    f = func() {
+        print(^a)
+    }
+    f()
+}`,
+		},
+		"used-as-key": {
+			src: `func main() {
+    a = [1, 2]
+    i = 0
+    a[i] = 3
+}`,
+		},
+		"used-by-method-call": {
+			src: `func Person() Person {
+    func Hi() {}
+}
+func main() {
+    p = Person()
+    p.Hi()
+}`,
+		},
+		"unused-property": {
+			src: `func Person() Person {
+    Name = "Bob"
+    age = 3
+}`,
+			expected: []string{"a.ok:3:5 age is assigned but never used"},
+		},
+		"unused-nested-func": {
+			src: `func main() {
+    func foo() {}
+}`,
+			expected: []string{"a.ok:2:5 foo is assigned but never used"},
+		},
+		"discard": {
+			src: `func foo() (number, number) {
+    return 1, 2
+}
+func main() {
+    a, _ = foo()
+    print(a)
+}`,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			var actual []string
+			for _, err := range vet.Source(test.src, "a.ok", vet.Checks) {
+				actual = append(actual, err.Error())
+			}
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSource_Checks(t *testing.T) {
+	src := `func main() {
+    a = 1
+    return
+    print(1)
+}`
+
+	var checks []*vet.Check
+	for _, check := range vet.Checks {
+		if check.Name == "unreachable" {
+			checks = append(checks, check)
+		}
+	}
+
+	var actual []string
+	for _, err := range vet.Source(src, "a.ok", checks) {
+		actual = append(actual, err.Error())
+	}
+
+	assert.Equal(t, []string{"a.ok:4:5 unreachable code"}, actual)
+}
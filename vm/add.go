@@ -3,7 +3,6 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 )
 
@@ -14,11 +13,8 @@ type Add struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Add) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralNumber(
-		number.Add(
-			number.NewNumber(vm.Get(ins.Left).Value),
-			number.NewNumber(vm.Get(ins.Right).Value),
-		).String(),
+	vm.Set(ins.Result, NewDecimal(
+		number.Add(vm.Get(ins.Left).Number, vm.Get(ins.Right).Number),
 	))
 
 	return nil
@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -18,21 +16,22 @@ func TestAdd_Execute(t *testing.T) {
 		"maintain-precision": {"1.2200", "4.7", "5.9200"},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralNumber(test.left),
-				"1": asttest.NewLiteralNumber(test.right),
+			registers := []*vm.Value{
+				vm.NewNumber(test.left),
+				vm.NewNumber(test.right),
+				nil,
 			}
-			ins := &vm.Add{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Add{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].Number.String())
 		})
 	}
 }
 
 func TestAdd_String(t *testing.T) {
-	ins := &vm.Add{Left: "0", Right: "1", Result: "2"}
+	ins := &vm.Add{Left: 0, Right: 1, Result: 2}
 	assert.Equal(t, "$2 = $0 + $1", ins.String())
 }
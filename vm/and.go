@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
 )
 
 // And is a logical AND between two bools.
@@ -13,9 +11,9 @@ type And struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *And) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralBool(
-		(vm.Get(ins.Left).Value == "true") &&
-			(vm.Get(ins.Right).Value == "true"),
+	vm.Set(ins.Result, NewBool(
+		vm.Get(ins.Left).Bool &&
+			vm.Get(ins.Right).Bool,
 	))
 
 	return nil
@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -21,21 +19,22 @@ func TestAnd_Execute(t *testing.T) {
 		"true-true":   {true, true, "true"},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralBool(test.left),
-				"1": asttest.NewLiteralBool(test.right),
+			registers := []*vm.Value{
+				vm.NewBool(test.left),
+				vm.NewBool(test.right),
+				nil,
 			}
-			ins := &vm.And{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.And{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestAnd_String(t *testing.T) {
-	ins := &vm.And{Left: "0", Right: "1", Result: "2"}
+	ins := &vm.And{Left: 0, Right: 1, Result: 2}
 	assert.Equal(t, "$2 = $0 and $1", ins.String())
 }
@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast"
 )
 
 // Append returns an array by combining two other arrays.
@@ -13,7 +11,7 @@ type Append struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Append) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, &ast.Literal{
+	vm.Set(ins.Result, &Value{
 		Kind:  vm.Get(ins.A).Kind,
 		Array: append(vm.Get(ins.A).Array, vm.Get(ins.B).Array...),
 	})
@@ -8,6 +8,6 @@ import (
 )
 
 func TestAppend_String(t *testing.T) {
-	ins := &vm.Append{A: "0", B: "1", Result: "2"}
+	ins := &vm.Append{A: 0, B: 1, Result: 2}
 	assert.Equal(t, "$2 = append($0, $1)", ins.String())
 }
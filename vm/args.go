@@ -0,0 +1,27 @@
+package vm
+
+import (
+	"fmt"
+)
+
+// Args returns the arguments that were given to the program. See VM.Args.
+type Args struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Args) Execute(_ *int, vm *VM) error {
+	args := &Value{Kind: "[]string"}
+	for _, arg := range vm.Args {
+		args.Array = append(args.Array, NewString(arg))
+	}
+
+	vm.Set(ins.Result, args)
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Args) String() string {
+	return fmt.Sprintf("%s = os.Args()", ins.Result)
+}
@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/number"
 )
 
 // ArrayAlloc allocates an array of fixed size.
@@ -15,11 +12,11 @@ type ArrayAlloc struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *ArrayAlloc) Execute(_ *int, vm *VM) error {
-	size := number.Int64(number.NewNumber(vm.Get(ins.Size).Value))
+	size := vm.Get(ins.Size).Int()
 
-	vm.Set(ins.Result, &ast.Literal{
+	vm.Set(ins.Result, &Value{
 		Kind:  ins.Kind,
-		Array: make([]*ast.Literal, size),
+		Array: make([]*Value, size),
 	})
 
 	return nil
@@ -8,6 +8,6 @@ import (
 )
 
 func TestArrayAlloc_String(t *testing.T) {
-	ins := &vm.ArrayAlloc{Size: "0", Result: "1", Kind: "[]string"}
+	ins := &vm.ArrayAlloc{Size: 0, Result: 1, Kind: "[]string"}
 	assert.Equal(t, "$1 = []string with $0 elements", ins.String())
 }
@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/number"
 )
 
 // ArrayGet gets a value from the array by its index.
@@ -13,7 +11,7 @@ type ArrayGet struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *ArrayGet) Execute(_ *int, vm *VM) error {
-	index := number.Int64(number.NewNumber(vm.Get(ins.Index).Value))
+	index := vm.Get(ins.Index).Int()
 	vm.Set(ins.Result, vm.Get(ins.Array).Array[index])
 
 	return nil
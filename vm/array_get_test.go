@@ -8,6 +8,6 @@ import (
 )
 
 func TestArrayGet_String(t *testing.T) {
-	ins := &vm.ArrayGet{Array: "0", Index: "1", Result: "2"}
+	ins := &vm.ArrayGet{Array: 0, Index: 1, Result: 2}
 	assert.Equal(t, "$2 = $0[$1]", ins.String())
 }
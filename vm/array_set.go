@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/number"
 )
 
 // ArraySet sets a number value to an index.
@@ -13,7 +11,7 @@ type ArraySet struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *ArraySet) Execute(_ *int, vm *VM) error {
-	index := number.Int64(number.NewNumber(vm.Get(ins.Index).Value))
+	index := vm.Get(ins.Index).Int()
 	vm.Get(ins.Array).Array[index] = vm.Get(ins.Value)
 
 	return nil
@@ -2,26 +2,186 @@ package vm
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/elliotchance/ok/compiler/kind"
+	"github.com/elliotchance/ok/util"
 )
 
+// maxInlineValue is the longest that a rendered value can be to be shown in
+// the message of a failed assertion. Longer values are shown in the details.
+const maxInlineValue = 40
+
 // Assert is used in tests.
 type Assert struct {
 	Left, Right, Final Register
 	Op                 string
 	Pos                string
+
+	// Message is NoRegister when there is no message.
+	Message Register
 }
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Assert) Execute(_ *int, vm *VM) error {
-	pass := vm.Get(ins.Final).Value == "true"
-	left := renderLiteral(vm.Get(ins.Left), true)
-	right := renderLiteral(vm.Get(ins.Right), true)
-	vm.assert(pass, left, ins.Op, right, ins.Pos)
+	var failure *AssertionFailure
+	if !vm.Get(ins.Final).Bool {
+		failure = compareFailure(vm.Get(ins.Left), ins.Op, vm.Get(ins.Right))
+		failure.Pos = ins.Pos
+		failure.Message += vm.assertMessage(ins.Message)
+	}
+
+	vm.assert(failure)
 
 	return nil
 }
 
 // String is the human-readable description of the instruction.
 func (ins *Assert) String() string {
-	return fmt.Sprintf("assert(%s %s %s)", ins.Left, ins.Op, ins.Right)
+	s := fmt.Sprintf("assert(%s %s %s", ins.Left, ins.Op, ins.Right)
+	if ins.Message != NoRegister {
+		s += ", " + ins.Message.String()
+	}
+
+	return s + ")"
+}
+
+// AssertRaise is used in tests to check the type of error that was raised.
+type AssertRaise struct {
+	// Err contains the error that was raised. It is NoRegister when nothing
+	// was raised.
+	Err Register
+
+	// Type is the name of the type, or interface, that was expected to be
+	// raised.
+	Type string
+
+	// Message is NoRegister when there is no message.
+	Message Register
+
+	// Expr is the source of the expression that was expected to raise. It is
+	// only used in the message of a failed assertion.
+	Expr string
+
+	Pos string
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *AssertRaise) Execute(_ *int, vm *VM) error {
+	details := "nothing was raised"
+	if ins.Err != NoRegister {
+		raised := vm.Get(ins.Err).Kind
+		if vm.satisfies(raised, ins.Type) {
+			vm.assert(nil)
+
+			return nil
+		}
+
+		details = raised + " was raised"
+	}
+
+	vm.assert(&AssertionFailure{
+		Pos: ins.Pos,
+		Message: fmt.Sprintf("assert(%s raise %s) failed%s", ins.Expr,
+			ins.Type, vm.assertMessage(ins.Message)),
+		Details: details,
+	})
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *AssertRaise) String() string {
+	s := fmt.Sprintf("assert(%s raise %s", ins.Err, ins.Type)
+	if ins.Message != NoRegister {
+		s += ", " + ins.Message.String()
+	}
+
+	return s + ")"
+}
+
+// assertMessage is added to the end of the message for a failed assertion.
+func (vm *VM) assertMessage(message Register) string {
+	if message == NoRegister {
+		return ""
+	}
+
+	return ": " + vm.Get(message).Text
+}
+
+// compareFailure describes why "left op right" was false. Short values are
+// shown in the message. Otherwise, each value is shown in the details or, if
+// they can be compared line by line, the diff between them.
+func compareFailure(left *Value, op string, right *Value) *AssertionFailure {
+	l, r := renderValue(left, true), renderValue(right, true)
+	if isInline(l) && isInline(r) {
+		return &AssertionFailure{
+			Message: fmt.Sprintf("assert(%s %s %s) failed", l, op, r),
+		}
+	}
+
+	failure := &AssertionFailure{
+		Message: fmt.Sprintf("assert(left %s right) failed", op),
+		Details: fmt.Sprintf("left:  %s\nright: %s", l, r),
+	}
+
+	if op == "==" && left.Kind == right.Kind {
+		diff := util.Diff("left", "right", diffValue(left, ""),
+			diffValue(right, ""))
+		if diff != "" {
+			failure.Details = strings.TrimSuffix(diff, "\n")
+		}
+	}
+
+	return failure
+}
+
+func isInline(s string) bool {
+	return len(s) <= maxInlineValue && !strings.Contains(s, "\n")
+}
+
+// diffValue renders a value with each element of an array, map or object on
+// its own line so that a diff will show which elements are different. Strings
+// are not quoted so that each of their lines can be compared.
+func diffValue(v *Value, indent string) string {
+	switch {
+	case v.Kind == "string":
+		return v.Text
+
+	case kind.IsArray(v.Kind):
+		if len(v.Array) == 0 {
+			return "[]"
+		}
+
+		s := "[\n"
+		for _, element := range v.Array {
+			s += indent + "    " + diffElement(element, indent+"    ") + ",\n"
+		}
+
+		return s + indent + "]"
+
+	case v.Map != nil && !kind.IsFunc(v.Kind):
+		if len(v.Map) == 0 {
+			return "{}"
+		}
+
+		s := "{\n"
+		for _, key := range sortedKeys(v.Map) {
+			s += fmt.Sprintf("%s    \"%s\": %s,\n", indent, key,
+				diffElement(v.Map[key], indent+"    "))
+		}
+
+		return s + indent + "}"
+	}
+
+	return renderValue(v, true)
+}
+
+// diffElement is the same as diffValue except that strings are quoted.
+func diffElement(v *Value, indent string) string {
+	if v.Kind == "string" {
+		return renderValue(v, true)
+	}
+
+	return diffValue(v, indent)
 }
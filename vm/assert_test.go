@@ -8,6 +8,33 @@ import (
 )
 
 func TestAssert_String(t *testing.T) {
-	ins := &vm.Assert{Left: "0", Right: "1", Final: "2", Op: "==", Pos: "pos"}
-	assert.Equal(t, "assert($0 == $1)", ins.String())
+	for testName, test := range map[string]struct {
+		ins      vm.Instruction
+		expected string
+	}{
+		"assert": {
+			ins: &vm.Assert{Left: 0, Right: 1, Final: 2, Op: "==", Pos: "pos",
+				Message: vm.NoRegister},
+			expected: "assert($0 == $1)",
+		},
+		"assert-message": {
+			ins: &vm.Assert{Left: 0, Right: 1, Final: 2, Op: "==", Pos: "pos",
+				Message: 3},
+			expected: "assert($0 == $1, $3)",
+		},
+		"raise": {
+			ins: &vm.AssertRaise{Err: 1, Type: "MyError", Pos: "pos",
+				Message: vm.NoRegister},
+			expected: "assert($1 raise MyError)",
+		},
+		"raise-nothing-message": {
+			ins: &vm.AssertRaise{Err: vm.NoRegister, Type: "MyError",
+				Pos: "pos", Message: 2},
+			expected: "assert(_ raise MyError, $2)",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.ins.String())
+		})
+	}
 }
@@ -19,7 +19,7 @@ type Assign struct {
 // Execute implements the Instruction interface for the VM.
 func (ins *Assign) Execute(_ *int, vm *VM) error {
 	if ins.Value != nil {
-		vm.Set(ins.VariableName, ins.Value)
+		vm.Set(ins.VariableName, vm.constant(ins.Value))
 	} else {
 		vm.Set(ins.VariableName, vm.Get(ins.Register))
 	}
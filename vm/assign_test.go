@@ -3,7 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
 	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
@@ -12,24 +11,25 @@ import (
 
 func TestAssign_Execute(t *testing.T) {
 	t.Run("literal", func(t *testing.T) {
-		registers := map[vm.Register]*ast.Literal{}
-		ins := &vm.Assign{VariableName: "1", Value: asttest.NewLiteralNumber("1.5")}
+		registers := make([]*vm.Value, 2)
+		ins := &vm.Assign{VariableName: 1, Value: asttest.NewLiteralNumber("1.5")}
 		vm := &vm.VM{
-			Stack: []map[vm.Register]*ast.Literal{registers},
+			Stack: []*vm.Scope{{Registers: registers}},
 		}
 		assert.NoError(t, ins.Execute(nil, vm))
-		assert.Equal(t, "1.5", registers["1"].Value)
+		assert.Equal(t, "1.5", registers[1].String())
 	})
 
 	t.Run("register", func(t *testing.T) {
-		registers := map[vm.Register]*ast.Literal{
-			"0": asttest.NewLiteralNumber("1.5"),
+		registers := []*vm.Value{
+			vm.NewNumber("1.5"),
+			nil,
 		}
-		ins := &vm.Assign{VariableName: "1", Register: "0"}
+		ins := &vm.Assign{VariableName: 1, Register: 0}
 		vm := &vm.VM{
-			Stack: []map[vm.Register]*ast.Literal{registers},
+			Stack: []*vm.Scope{{Registers: registers}},
 		}
 		assert.NoError(t, ins.Execute(nil, vm))
-		assert.Equal(t, "1.5", registers["1"].Value)
+		assert.Equal(t, "1.5", registers[1].String())
 	})
 }
@@ -0,0 +1,140 @@
+package vm
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/elliotchance/ok/util"
+)
+
+// maxBenchIterations is the most times a benchmark will be run.
+const maxBenchIterations = 1000000000
+
+// CompiledBench is a runnable benchmark.
+type CompiledBench struct {
+	*CompiledFunc
+	BenchName string
+}
+
+// BenchResult is the outcome of running a benchmark.
+type BenchResult struct {
+	Name string
+
+	// N is the number of times the benchmark was run.
+	N int
+
+	// Elapsed is the total time for all N runs.
+	Elapsed time.Duration
+
+	// Instructions is the total number of instructions executed by all N
+	// runs.
+	Instructions int64
+}
+
+// NsPerOp is the average time of a single run in nanoseconds.
+func (r *BenchResult) NsPerOp() int64 {
+	if r.N == 0 {
+		return 0
+	}
+
+	return r.Elapsed.Nanoseconds() / int64(r.N)
+}
+
+// InstructionsPerOp is the average number of instructions executed by a single
+// run.
+func (r *BenchResult) InstructionsPerOp() int64 {
+	if r.N == 0 {
+		return 0
+	}
+
+	return r.Instructions / int64(r.N)
+}
+
+// RunBenchmarks runs each benchmark that matches BenchFilter. Each benchmark is
+// run in its own VM, one at a time so that they do not affect each others
+// timing.
+//
+// A benchmark is run an increasing number of times until it takes at least
+// BenchTime. Anything printed by a benchmark is discarded.
+func (vm *VM) RunBenchmarks(benches []*CompiledBench) error {
+	benchTime := vm.BenchTime
+	if benchTime <= 0 {
+		benchTime = time.Second
+	}
+
+	for _, bench := range benches {
+		if !util.MatchesGlob(bench.BenchName, vm.BenchFilter) {
+			continue
+		}
+
+		m := NewVM(vm.fns, nil, vm.Interfaces, vm.pkg)
+		m.Stdin = strings.NewReader("")
+		m.Stdout = ioutil.Discard
+		m.Stderr = ioutil.Discard
+
+		result, err := m.runBench(bench, benchTime)
+		if err != nil {
+			return err
+		}
+
+		vm.BenchResults = append(vm.BenchResults, result)
+	}
+
+	return nil
+}
+
+// runBench works like "go test -bench". The number of runs is predicted from
+// the previous attempt until the total time reaches benchTime.
+func (vm *VM) runBench(bench *CompiledBench, benchTime time.Duration) (*BenchResult, error) {
+	result, err := vm.runBenchN(bench, 1)
+	for err == nil && result.Elapsed < benchTime && result.N < maxBenchIterations {
+		last := int64(result.N)
+		elapsed := result.Elapsed.Nanoseconds()
+		if elapsed < 1 {
+			elapsed = 1
+		}
+
+		// Aim for 20% more than needed, but do not grow too quickly in case
+		// the first runs were unusually fast.
+		n := benchTime.Nanoseconds() * last / elapsed
+		n += n / 5
+		if n > 100*last {
+			n = 100 * last
+		}
+		if n <= last {
+			n = last + 1
+		}
+		if n > maxBenchIterations {
+			n = maxBenchIterations
+		}
+
+		result, err = vm.runBenchN(bench, int(n))
+	}
+
+	return result, err
+}
+
+func (vm *VM) runBenchN(bench *CompiledBench, n int) (*BenchResult, error) {
+	vm.instructions = 0
+	startTime := time.Now()
+
+	for i := 0; i < n; i++ {
+		vm.appendStack(bench.CompiledFunc, map[string]*Value{}, "any")
+		_, err := vm.runFunc(bench.BenchName, bench.CompiledFunc)
+		vm.Stack = vm.Stack[:len(vm.Stack)-1]
+		if err == nil {
+			err = vm.unhandledError()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BenchResult{
+		Name:         bench.BenchName,
+		N:            n,
+		Elapsed:      time.Since(startTime),
+		Instructions: vm.instructions,
+	}, nil
+}
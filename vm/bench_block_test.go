@@ -0,0 +1,84 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_RunBenchmarks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok-vm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.okt"), []byte(`bench "assign" {
+    a = 1
+    print(a)
+}
+
+bench "loop" {
+    for i = 0; i < 10; ++i {}
+}
+
+bench "raise" {
+    raise Error("oops")
+}
+`), 0644))
+
+	pkg, errs := compiler.CompilePackage(dir, true)
+	require.Nil(t, errs)
+	require.Len(t, pkg.Benches, 3)
+
+	for testName, test := range map[string]struct {
+		filter       string
+		names        []string
+		instructions []int64
+		err          string
+	}{
+		"assign": {
+			filter:       "assign",
+			names:        []string{"assign"},
+			instructions: []int64{int64(len(pkg.Benches[0].Instructions))},
+		},
+		"loop": {
+			filter:       "loop",
+			names:        []string{"loop"},
+			instructions: []int64{65},
+		},
+		"none": {
+			filter: "foo",
+		},
+		"raise": {
+			filter: "raise",
+			err:    "unhandled Error: oops",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+			m.BenchFilter = test.filter
+			m.BenchTime = 10 * time.Millisecond
+			err := m.RunBenchmarks(pkg.Benches)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, m.BenchResults, len(test.names))
+			for i, result := range m.BenchResults {
+				assert.Equal(t, test.names[i], result.Name)
+				assert.True(t, result.N > 1)
+				assert.True(t, result.Elapsed >= m.BenchTime)
+				assert.Equal(t, test.instructions[i], result.InstructionsPerOp())
+			}
+		})
+	}
+}
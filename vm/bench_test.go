@@ -0,0 +1,137 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/require"
+)
+
+// Each benchmark is a complete program. The output is discarded.
+var benchmarks = map[string]string{
+	"Arithmetic": `
+func main() {
+    total = 0
+    for i = 0; i < 10000; ++i {
+        total = total + i * 2 - i / 4
+    }
+    print(total)
+}`,
+	"Recursion": `
+func fib(n number) number {
+    if n < 2 {
+        return n
+    }
+
+    return fib(n - 1) + fib(n - 2)
+}
+
+func main() {
+    print(fib(18))
+}`,
+	"Arrays": `
+func main() {
+    values = []number []
+    for i = 0; i < 1000; ++i {
+        values += [i]
+    }
+
+    total = 0
+    for value in values {
+        total += value
+    }
+    print(total)
+}`,
+	"Strings": `
+func main() {
+    s = ""
+    for i = 0; i < 1000; ++i {
+        s += "a"
+    }
+
+    count = 0
+    for c in s {
+        if string(c) == "a" {
+            ++count
+        }
+    }
+    print(count)
+}`,
+	"Closures": `
+func counter() func() number {
+    count = 0
+
+    return func() number {
+        ^count = ^count + 1
+
+        return ^count
+    }
+}
+
+func main() {
+    next = counter()
+    total = 0
+    for i = 0; i < 1000; ++i {
+        total = total + next()
+    }
+    print(total)
+}`,
+	"Objects": `
+func Point(X, Y number) Point {
+    func Add(p Point) Point {
+        return Point(^X + p.X, ^Y + p.Y)
+    }
+}
+
+func main() {
+    p = Point(0, 0)
+    for i = 0; i < 1000; ++i {
+        p = p.Add(Point(i, 1))
+    }
+    print(p.X, p.Y)
+}`,
+}
+
+func benchmarkProgram(b *testing.B, source string) {
+	p := parser.ParseString(source, "main.ok")
+	require.Nil(b, p.Errors())
+
+	f, errs := compiler.CompileFile(p.File, p.Interfaces, p.Constants)
+	require.Nil(b, errs)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "main")
+		m.Stdout = ioutil.Discard
+		if err := m.Run(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVM_Arithmetic(b *testing.B) {
+	benchmarkProgram(b, benchmarks["Arithmetic"])
+}
+
+func BenchmarkVM_Recursion(b *testing.B) {
+	benchmarkProgram(b, benchmarks["Recursion"])
+}
+
+func BenchmarkVM_Arrays(b *testing.B) {
+	benchmarkProgram(b, benchmarks["Arrays"])
+}
+
+func BenchmarkVM_Strings(b *testing.B) {
+	benchmarkProgram(b, benchmarks["Strings"])
+}
+
+func BenchmarkVM_Closures(b *testing.B) {
+	benchmarkProgram(b, benchmarks["Closures"])
+}
+
+func BenchmarkVM_Objects(b *testing.B) {
+	benchmarkProgram(b, benchmarks["Objects"])
+}
@@ -0,0 +1,664 @@
+package vm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"github.com/elliotchance/ok/ast"
+)
+
+// BytecodeVersion must be incremented whenever the bytecode format changes,
+// including when an instruction is added, removed or has its fields changed.
+// Bytecode from a different version can not be loaded.
+const BytecodeVersion = 10
+
+// bytecodeMagic is at the start of all bytecode.
+const bytecodeMagic = "okc"
+
+// opcodes contains every instruction. The opcode for an instruction is its
+// position in this list. New instructions must be added to the end.
+var opcodes = []Instruction{
+	&Add{}, &And{}, &Append{}, &ArrayAlloc{}, &ArrayGet{}, &ArraySet{},
+	&Assert{}, &Assign{}, &Call{}, &CastChar{}, &CastNumber{},
+	&CastString{}, &Combine{}, &Concat{}, &Divide{}, &DynamicCall{},
+	&Equal{}, &EqualNumber{}, &Finally{}, &Get{}, &GreaterThanEqualNumber{},
+	&GreaterThanEqualString{}, &GreaterThanNumber{}, &GreaterThanString{},
+	&Interface{}, &Interpolate{}, &Jump{}, &JumpUnless{}, &Len{},
+	&LessThanEqualNumber{}, &LessThanEqualString{}, &LessThanNumber{},
+	&LessThanString{}, &Log{}, &MapAlloc{}, &MapGet{}, &MapSet{},
+	&Multiply{}, &NextArray{}, &NextMap{}, &NextString{}, &Not{},
+	&NotEqual{}, &NotEqualNumber{}, &Or{}, &ParentScope{},
+	&Power{}, &Print{}, &Props{}, &Raise{}, &Remainder{}, &Return{},
+	&Set{}, &StringIndex{}, &Subtract{}, &Type{}, &AssertRaise{},
+	&Args{}, &Environ{}, &Exit{}, &Getenv{}, &Getwd{}, &Hostname{}, &Pid{},
+	&Setenv{}, &CastData{}, &FileClose{}, &FileEOF{}, &FileOpen{},
+	&FileRead{}, &FileReadLine{}, &FileWrite{}, &Glob{}, &MkdirAll{},
+	&ReadDir{}, &ReadFile{}, &Remove{}, &Rename{}, &Stat{}, &TempFile{},
+	&WriteFile{}, &EOF{}, &ReadAll{}, &ReadLine{}, &Write{},
+}
+
+var opcodeForType = map[reflect.Type]int{}
+
+func init() {
+	for opcode, ins := range opcodes {
+		opcodeForType[reflect.TypeOf(ins)] = opcode
+	}
+}
+
+var literalType = reflect.TypeOf(&ast.Literal{})
+
+// encoder writes bytecode. The first error is kept and all writes after that
+// are ignored.
+//
+// Strings are only written once. Any repeat of the same string is written as
+// a reference to the first one. This makes a big difference because every
+// instruction has a position, and registers are often reused.
+type encoder struct {
+	w       *bufio.Writer
+	err     error
+	strings map[string]int
+}
+
+func (e *encoder) uint(x uint64) {
+	if e.err != nil {
+		return
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	_, e.err = e.w.Write(buf[:n])
+}
+
+func (e *encoder) int(x int) {
+	if e.err != nil {
+		return
+	}
+
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], int64(x))
+	_, e.err = e.w.Write(buf[:n])
+}
+
+func (e *encoder) bool(b bool) {
+	if b {
+		e.uint(1)
+	} else {
+		e.uint(0)
+	}
+}
+
+// string writes zero followed by the string the first time it is seen.
+// Afterwards it is written as the number of the string, starting at one.
+func (e *encoder) string(s string) {
+	if n, ok := e.strings[s]; ok {
+		e.uint(uint64(n))
+		return
+	}
+
+	e.strings[s] = len(e.strings) + 1
+	e.uint(0)
+	e.uint(uint64(len(s)))
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
+
+func (e *encoder) stringSlice(ss []string) {
+	e.uint(uint64(len(ss)))
+	for _, s := range ss {
+		e.string(s)
+	}
+}
+
+// sortedKeys is used for all maps so that the same program always produces
+// the same bytecode.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (e *encoder) stringMap(m map[string]string) {
+	e.uint(uint64(len(m)))
+	for _, key := range sortedKeys(m) {
+		e.string(key)
+		e.string(m[key])
+	}
+}
+
+func (e *encoder) interfaces(interfaces map[string]map[string]string) {
+	e.uint(uint64(len(interfaces)))
+	for _, key := range sortedKeys(interfaces) {
+		e.string(key)
+		e.stringMap(interfaces[key])
+	}
+}
+
+func (e *encoder) literal(literal *ast.Literal) {
+	e.bool(literal != nil)
+	if literal == nil {
+		return
+	}
+
+	e.string(literal.Kind)
+	e.string(literal.Value)
+	e.string(literal.Pos)
+
+	e.uint(uint64(len(literal.Array)))
+	for _, element := range literal.Array {
+		e.literal(element)
+	}
+
+	e.uint(uint64(len(literal.Map)))
+	for _, key := range sortedKeys(literal.Map) {
+		e.string(key)
+		e.literal(literal.Map[key])
+	}
+}
+
+func (e *encoder) literals(literals map[string]*ast.Literal) {
+	e.uint(uint64(len(literals)))
+	for _, key := range sortedKeys(literals) {
+		e.string(key)
+		e.literal(literals[key])
+	}
+}
+
+// instruction writes the opcode followed by each of the fields in the order
+// they are declared.
+func (e *encoder) instruction(ins Instruction) {
+	opcode, ok := opcodeForType[reflect.TypeOf(ins)]
+	if !ok {
+		if e.err == nil {
+			e.err = fmt.Errorf("cannot encode instruction %T", ins)
+		}
+
+		return
+	}
+
+	e.uint(uint64(opcode))
+
+	v := reflect.ValueOf(ins).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		e.value(v.Field(i))
+	}
+}
+
+func (e *encoder) value(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String:
+		e.string(v.String())
+
+	case v.Kind() == reflect.Int:
+		e.int(int(v.Int()))
+
+	case v.Kind() == reflect.Bool:
+		e.bool(v.Bool())
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		e.uint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			e.string(v.Index(i).String())
+		}
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Int:
+		e.uint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			e.int(int(v.Index(i).Int()))
+		}
+
+	case v.Type() == literalType:
+		e.literal(v.Interface().(*ast.Literal))
+
+	default:
+		if e.err == nil {
+			e.err = fmt.Errorf("cannot encode %s", v.Type())
+		}
+	}
+}
+
+func (e *encoder) instructions(instructions []Instruction) {
+	e.uint(uint64(len(instructions)))
+	for _, ins := range instructions {
+		e.instruction(ins)
+	}
+}
+
+func (e *encoder) fn(fn *CompiledFunc) {
+	e.stringSlice(fn.Arguments)
+	e.instructions(fn.Instructions)
+	e.int(fn.Registers)
+	e.stringMap(fn.Variables)
+
+	e.uint(uint64(len(fn.Locals)))
+	for _, name := range sortedKeys(fn.Locals) {
+		e.string(name)
+		e.int(int(fn.Locals[name]))
+	}
+
+	e.stringSlice(fn.Upvalues)
+
+	e.uint(uint64(len(fn.ErrorScopes)))
+	for _, scope := range fn.ErrorScopes {
+		e.int(scope.Start)
+		e.int(scope.End)
+		e.uint(uint64(len(scope.On)))
+		for _, on := range scope.On {
+			e.string(on.Type)
+			e.int(int(on.Err))
+			e.int(on.To)
+		}
+	}
+
+	e.uint(uint64(len(fn.Finally)))
+	for _, finally := range fn.Finally {
+		e.instructions(finally)
+	}
+
+	e.interfaces(fn.Interfaces)
+	e.stringSlice(fn.Positions)
+
+	e.uint(uint64(len(fn.FinallyPositions)))
+	for _, positions := range fn.FinallyPositions {
+		e.stringSlice(positions)
+	}
+}
+
+// funcDef only writes the signature. The statements are not needed once the
+// function has been compiled.
+func (e *encoder) funcDef(fn *ast.Func) {
+	e.string(fn.Name)
+
+	e.uint(uint64(len(fn.Arguments)))
+	for _, arg := range fn.Arguments {
+		e.string(arg.Name)
+		e.string(arg.Type)
+	}
+
+	e.stringSlice(fn.Returns)
+	e.string(fn.Pos)
+}
+
+func (e *encoder) program(p *Program) {
+	e.string(p.Package)
+
+	e.uint(uint64(len(p.Funcs)))
+	for _, name := range sortedKeys(p.Funcs) {
+		e.string(name)
+		e.fn(p.Funcs[name])
+	}
+
+	e.uint(uint64(len(p.FuncDefs)))
+	for _, name := range sortedKeys(p.FuncDefs) {
+		e.string(name)
+		e.funcDef(p.FuncDefs[name])
+	}
+
+	e.uint(uint64(len(p.Tests)))
+	for _, test := range p.Tests {
+		e.string(test.TestName)
+		e.fn(test.CompiledFunc)
+		e.bool(test.Cases != nil)
+		if test.Cases != nil {
+			e.fn(test.Cases)
+		}
+		e.stringSlice(test.FuzzTypes)
+	}
+
+	e.interfaces(p.Interfaces)
+	e.literals(p.Constants)
+}
+
+// decoder reads bytecode written by encoder. The first error is kept and all
+// values read after that will be zero.
+type decoder struct {
+	r       *bufio.Reader
+	err     error
+	strings []string
+}
+
+func (d *decoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	var x uint64
+	x, d.err = binary.ReadUvarint(d.r)
+
+	return x
+}
+
+// len reads a length. It is limited so that corrupt bytecode cannot cause a
+// huge allocation.
+func (d *decoder) len() int {
+	n := d.uint()
+	if n > 1<<28 {
+		d.fail(errors.New("length is too large"))
+		return 0
+	}
+
+	return int(n)
+}
+
+func (d *decoder) int() int {
+	if d.err != nil {
+		return 0
+	}
+
+	var x int64
+	x, d.err = binary.ReadVarint(d.r)
+
+	return int(x)
+}
+
+func (d *decoder) bool() bool {
+	return d.uint() == 1
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) string() string {
+	n := d.len()
+	if n > 0 {
+		if n > len(d.strings) {
+			d.fail(fmt.Errorf("invalid string reference %d", n))
+			return ""
+		}
+
+		return d.strings[n-1]
+	}
+
+	buf := make([]byte, d.len())
+	if d.err == nil {
+		_, d.err = io.ReadFull(d.r, buf)
+	}
+
+	s := string(buf)
+	d.strings = append(d.strings, s)
+
+	return s
+}
+
+func (d *decoder) stringSlice() []string {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = d.string()
+	}
+
+	return ss
+}
+
+func (d *decoder) stringMap() map[string]string {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+
+	m := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		key := d.string()
+		m[key] = d.string()
+	}
+
+	return m
+}
+
+func (d *decoder) interfaces() map[string]map[string]string {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+
+	interfaces := make(map[string]map[string]string, n)
+	for i := 0; i < n; i++ {
+		key := d.string()
+		interfaces[key] = d.stringMap()
+	}
+
+	return interfaces
+}
+
+func (d *decoder) literal() *ast.Literal {
+	if !d.bool() {
+		return nil
+	}
+
+	literal := &ast.Literal{
+		Kind:  d.string(),
+		Value: d.string(),
+		Pos:   d.string(),
+	}
+
+	if n := d.len(); n > 0 {
+		literal.Array = make([]*ast.Literal, n)
+		for i := range literal.Array {
+			literal.Array[i] = d.literal()
+		}
+	}
+
+	if n := d.len(); n > 0 {
+		literal.Map = make(map[string]*ast.Literal, n)
+		for i := 0; i < n; i++ {
+			key := d.string()
+			literal.Map[key] = d.literal()
+		}
+	}
+
+	return literal
+}
+
+func (d *decoder) literals() map[string]*ast.Literal {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+
+	literals := make(map[string]*ast.Literal, n)
+	for i := 0; i < n; i++ {
+		key := d.string()
+		literals[key] = d.literal()
+	}
+
+	return literals
+}
+
+func (d *decoder) instruction() Instruction {
+	opcode := d.len()
+	if d.err != nil {
+		return nil
+	}
+
+	if opcode >= len(opcodes) {
+		d.fail(fmt.Errorf("invalid opcode %d", opcode))
+		return nil
+	}
+
+	v := reflect.New(reflect.TypeOf(opcodes[opcode]).Elem())
+	for i := 0; i < v.Elem().NumField(); i++ {
+		d.value(v.Elem().Field(i))
+	}
+
+	return v.Interface().(Instruction)
+}
+
+func (d *decoder) value(v reflect.Value) {
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(d.string())
+
+	case v.Kind() == reflect.Int:
+		v.SetInt(int64(d.int()))
+
+	case v.Kind() == reflect.Bool:
+		v.SetBool(d.bool())
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		n := d.len()
+		if n == 0 {
+			return
+		}
+
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			v.Index(i).SetString(d.string())
+		}
+
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Int:
+		n := d.len()
+		if n == 0 {
+			return
+		}
+
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			v.Index(i).SetInt(int64(d.int()))
+		}
+
+	case v.Type() == literalType:
+		v.Set(reflect.ValueOf(d.literal()))
+
+	default:
+		d.fail(fmt.Errorf("cannot decode %s", v.Type()))
+	}
+}
+
+func (d *decoder) instructions() []Instruction {
+	n := d.len()
+	if n == 0 {
+		return nil
+	}
+
+	instructions := make([]Instruction, n)
+	for i := range instructions {
+		instructions[i] = d.instruction()
+	}
+
+	return instructions
+}
+
+func (d *decoder) fn() *CompiledFunc {
+	fn := &CompiledFunc{
+		Arguments:    d.stringSlice(),
+		Instructions: d.instructions(),
+		Registers:    d.int(),
+		Variables:    d.stringMap(),
+	}
+
+	if n := d.len(); n > 0 {
+		fn.Locals = make(map[string]Register, n)
+		for i := 0; i < n; i++ {
+			name := d.string()
+			fn.Locals[name] = Register(d.int())
+		}
+	}
+
+	fn.Upvalues = d.stringSlice()
+
+	if n := d.len(); n > 0 {
+		fn.ErrorScopes = make([]*ErrorScope, n)
+		for i := range fn.ErrorScopes {
+			scope := &ErrorScope{Start: d.int(), End: d.int()}
+			if n := d.len(); n > 0 {
+				scope.On = make([]*On, n)
+				for j := range scope.On {
+					scope.On[j] = &On{
+						Type: d.string(),
+						Err:  Register(d.int()),
+						To:   d.int(),
+					}
+				}
+			}
+			fn.ErrorScopes[i] = scope
+		}
+	}
+
+	if n := d.len(); n > 0 {
+		fn.Finally = make([][]Instruction, n)
+		for i := range fn.Finally {
+			fn.Finally[i] = d.instructions()
+		}
+	}
+
+	fn.Interfaces = d.interfaces()
+	fn.Positions = d.stringSlice()
+
+	if n := d.len(); n > 0 {
+		fn.FinallyPositions = make([][]string, n)
+		for i := range fn.FinallyPositions {
+			fn.FinallyPositions[i] = d.stringSlice()
+		}
+	}
+
+	return fn
+}
+
+func (d *decoder) funcDef() *ast.Func {
+	fn := &ast.Func{Name: d.string()}
+
+	if n := d.len(); n > 0 {
+		fn.Arguments = make([]*ast.Argument, n)
+		for i := range fn.Arguments {
+			fn.Arguments[i] = &ast.Argument{Name: d.string(), Type: d.string()}
+		}
+	}
+
+	fn.Returns = d.stringSlice()
+	fn.Pos = d.string()
+
+	return fn
+}
+
+func (d *decoder) program() *Program {
+	p := &Program{
+		Package: d.string(),
+		Funcs:   map[string]*CompiledFunc{},
+	}
+
+	for i, n := 0, d.len(); i < n && d.err == nil; i++ {
+		name := d.string()
+		p.Funcs[name] = d.fn()
+	}
+
+	if n := d.len(); n > 0 {
+		p.FuncDefs = make(map[string]*ast.Func, n)
+		for i := 0; i < n && d.err == nil; i++ {
+			name := d.string()
+			p.FuncDefs[name] = d.funcDef()
+		}
+	}
+
+	for i, n := 0, d.len(); i < n && d.err == nil; i++ {
+		test := &CompiledTest{
+			TestName:     d.string(),
+			CompiledFunc: d.fn(),
+		}
+		if d.bool() {
+			test.Cases = d.fn()
+		}
+		test.FuzzTypes = d.stringSlice()
+		p.Tests = append(p.Tests, test)
+	}
+
+	p.Interfaces = d.interfaces()
+	p.Constants = d.literals()
+
+	return p
+}
@@ -0,0 +1,133 @@
+package vm_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/elliotchance/ok/ast/asttest"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var allInstructions = []vm.Instruction{
+	&vm.Add{}, &vm.And{}, &vm.Append{}, &vm.Args{}, &vm.ArrayAlloc{},
+	&vm.ArrayGet{}, &vm.ArraySet{}, &vm.Assert{}, &vm.AssertRaise{},
+	&vm.Assign{}, &vm.Call{}, &vm.CastChar{}, &vm.CastData{}, &vm.CastNumber{},
+	&vm.CastString{}, &vm.Combine{}, &vm.Concat{}, &vm.Divide{},
+	&vm.DynamicCall{}, &vm.EOF{}, &vm.Environ{}, &vm.Equal{}, &vm.EqualNumber{},
+	&vm.Exit{}, &vm.FileClose{}, &vm.FileEOF{}, &vm.FileOpen{}, &vm.FileRead{},
+	&vm.FileReadLine{}, &vm.FileWrite{}, &vm.Finally{}, &vm.Get{}, &vm.Getenv{},
+	&vm.Getwd{}, &vm.Glob{}, &vm.GreaterThanEqualNumber{},
+	&vm.GreaterThanEqualString{}, &vm.GreaterThanNumber{},
+	&vm.GreaterThanString{}, &vm.Hostname{}, &vm.Interface{}, &vm.Interpolate{},
+	&vm.Jump{}, &vm.JumpUnless{}, &vm.Len{}, &vm.LessThanEqualNumber{},
+	&vm.LessThanEqualString{}, &vm.LessThanNumber{}, &vm.LessThanString{},
+	&vm.Log{}, &vm.MapAlloc{}, &vm.MapGet{}, &vm.MapSet{}, &vm.MkdirAll{},
+	&vm.Multiply{}, &vm.NextArray{}, &vm.NextMap{}, &vm.NextString{}, &vm.Not{},
+	&vm.NotEqual{}, &vm.NotEqualNumber{}, &vm.Or{}, &vm.ParentScope{},
+	&vm.Pid{}, &vm.Power{}, &vm.Print{}, &vm.Props{}, &vm.Raise{},
+	&vm.ReadAll{}, &vm.ReadDir{}, &vm.ReadFile{}, &vm.ReadLine{},
+	&vm.Remainder{}, &vm.Remove{}, &vm.Rename{}, &vm.Return{}, &vm.Set{},
+	&vm.Setenv{}, &vm.Stat{}, &vm.StringIndex{}, &vm.Subtract{}, &vm.TempFile{},
+	&vm.Type{}, &vm.Write{}, &vm.WriteFile{},
+}
+
+// fill sets every field to a value that is not the zero value.
+func fill(v reflect.Value, i int) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(fmt.Sprintf("%d", i))
+
+	case reflect.Int:
+		v.SetInt(int64(-i))
+
+	case reflect.Bool:
+		v.SetBool(true)
+
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		fill(v.Index(0), i)
+		fill(v.Index(1), i+1)
+
+	case reflect.Ptr:
+		v.Set(reflect.ValueOf(asttest.NewLiteralString(fmt.Sprintf("%d", i))))
+
+	case reflect.Struct:
+		for j := 0; j < v.NumField(); j++ {
+			fill(v.Field(j), i+j)
+		}
+	}
+}
+
+func TestBytecode_AllInstructions(t *testing.T) {
+	// Every instruction in the vm package must be able to be encoded.
+	fileNames, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	var expected []string
+	re := regexp.MustCompile(`func \(ins \*(\w+)\) Execute\(`)
+	for _, fileName := range fileNames {
+		data, err := ioutil.ReadFile(fileName)
+		require.NoError(t, err)
+
+		for _, match := range re.FindAllStringSubmatch(string(data), -1) {
+			expected = append(expected, match[1])
+		}
+	}
+	sort.Strings(expected)
+
+	var actual []string
+	for _, ins := range allInstructions {
+		actual = append(actual, reflect.TypeOf(ins).Elem().Name())
+	}
+	assert.Equal(t, expected, actual)
+
+	for i, ins := range allInstructions {
+		ins := reflect.New(reflect.TypeOf(ins).Elem())
+		fill(ins.Elem(), i)
+
+		t.Run(ins.Elem().Type().Name(), func(t *testing.T) {
+			program := &vm.Program{
+				Package: "pkg",
+				Funcs: map[string]*vm.CompiledFunc{
+					"main": {
+						Instructions: []vm.Instruction{
+							ins.Interface().(vm.Instruction),
+						},
+					},
+				},
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, program.Encode(&buf))
+
+			decoded, err := vm.DecodeProgram(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, program.Funcs, decoded.Funcs)
+		})
+	}
+}
+
+func TestBytecode_IsDeterministic(t *testing.T) {
+	var first []byte
+	for i := 0; i < 5; i++ {
+		var buf bytes.Buffer
+		require.NoError(t, (&vm.Program{
+			Package:    "pkg",
+			Interfaces: vm.Interfaces,
+			Constants:  vm.Constants,
+		}).Encode(&buf))
+
+		if first == nil {
+			first = buf.Bytes()
+		}
+		assert.Equal(t, first, buf.Bytes())
+	}
+}
@@ -2,8 +2,7 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast"
+	"strconv"
 )
 
 // Call tells the VM to jump to another function.
@@ -16,25 +15,35 @@ type Call struct {
 // Execute implements the Instruction interface for the VM.
 func (ins *Call) Execute(_ *int, vm *VM) error {
 	// TODO(elliot): This is a hack that matches up with compiler/call.go.
-	parentScope := map[string]*ast.Literal{}
+	parentScope := map[string]*Value{}
 	funcName := ins.FunctionName
 	if ins.FunctionName[0] == '*' {
-		funcLit := vm.Get(Register(ins.FunctionName[1:]))
-		funcName = funcLit.Value
+		register, err := strconv.Atoi(ins.FunctionName[1:])
+		if err != nil {
+			return err
+		}
+
+		funcLit := vm.Get(Register(register))
+		funcName = funcLit.Text
 		parentScope = funcLit.Map
 	}
 
-	results, err := vm.call(funcName, ins.Arguments, parentScope, funcName)
+	arguments := make([]*Value, len(ins.Arguments))
+	for i, arg := range ins.Arguments {
+		arguments[i] = vm.Get(arg)
+	}
+
+	results, err := vm.call(funcName, arguments, parentScope, funcName)
 	if err != nil {
 		return err
 	}
 
+	vm.Stack = vm.Stack[:len(vm.Stack)-1]
+
 	for i, result := range results {
-		vm.set(ins.Results[i], vm.Get(result), 2)
+		vm.Set(ins.Results[i], result)
 	}
 
-	vm.Stack = vm.Stack[:len(vm.Stack)-1]
-
 	// We cannot rollback the FinallyBlocks stack here because we may need to
 	// run some of them as part of the return. They will be removed in the
 	// parent caller when it's finished with them
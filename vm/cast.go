@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/number"
 )
 
 // CastString returns a string value of a value.
@@ -14,10 +11,7 @@ type CastString struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *CastString) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, &ast.Literal{
-		Kind:  "string",
-		Value: renderLiteral(vm.Get(ins.X), false),
-	})
+	vm.Set(ins.Result, NewString(renderValue(vm.Get(ins.X), false)))
 
 	return nil
 }
@@ -27,6 +21,23 @@ func (ins *CastString) String() string {
 	return fmt.Sprintf("%s = string %s", ins.Result, ins.X)
 }
 
+// CastData returns the bytes of a string, or a data value unchanged.
+type CastData struct {
+	X, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *CastData) Execute(_ *int, vm *VM) error {
+	vm.Set(ins.Result, NewData([]byte(renderValue(vm.Get(ins.X), false))))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *CastData) String() string {
+	return fmt.Sprintf("%s = data %s", ins.Result, ins.X)
+}
+
 // CastNumber returns a number value of a value.
 type CastNumber struct {
 	X, Result Register
@@ -34,10 +45,7 @@ type CastNumber struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *CastNumber) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, &ast.Literal{
-		Kind:  "number",
-		Value: fmt.Sprintf("%d", int([]rune(vm.Get(ins.X).Value)[0])),
-	})
+	vm.Set(ins.Result, NewInt(int(vm.Get(ins.X).Char)))
 
 	return nil
 }
@@ -54,10 +62,7 @@ type CastChar struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *CastChar) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, &ast.Literal{
-		Kind:  "char",
-		Value: fmt.Sprintf("%c", number.Int(number.NewNumber(vm.Get(ins.X).Value))),
-	})
+	vm.Set(ins.Result, NewChar(rune(vm.Get(ins.X).Int())))
 
 	return nil
 }
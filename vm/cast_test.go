@@ -8,16 +8,42 @@ import (
 )
 
 func TestCastString_String(t *testing.T) {
-	ins := &vm.CastString{X: "1", Result: "2"}
+	ins := &vm.CastString{X: 1, Result: 2}
 	assert.Equal(t, "$2 = string $1", ins.String())
 }
 
 func TestCastNumber_String(t *testing.T) {
-	ins := &vm.CastNumber{X: "1", Result: "2"}
+	ins := &vm.CastNumber{X: 1, Result: 2}
 	assert.Equal(t, "$2 = number $1", ins.String())
 }
 
 func TestCastChar_String(t *testing.T) {
-	ins := &vm.CastChar{X: "1", Result: "2"}
+	ins := &vm.CastChar{X: 1, Result: 2}
 	assert.Equal(t, "$2 = char $1", ins.String())
 }
+
+func TestCastData_Execute(t *testing.T) {
+	for testName, test := range map[string]struct {
+		x        *vm.Value
+		expected *vm.Value
+	}{
+		"string": {
+			vm.NewString("foo"),
+			vm.NewData([]byte("foo")),
+		},
+		"data": {
+			vm.NewData([]byte("bar")),
+			vm.NewData([]byte("bar")),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{test.x, nil}
+			ins := &vm.CastData{X: 0, Result: 1}
+			m := &vm.VM{
+				Stack: []*vm.Scope{{Registers: registers}},
+			}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expected, registers[ins.Result])
+		})
+	}
+}
@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
 )
 
 // Combine will create a new data by joining two other datas.
@@ -13,8 +11,11 @@ type Combine struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Combine) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralData(
-		[]byte(vm.Get(ins.Left).Value+vm.Get(ins.Right).Value)))
+	left, right := vm.Get(ins.Left).Data, vm.Get(ins.Right).Data
+	data := make([]byte, len(left)+len(right))
+	copy(data, left)
+	copy(data[len(left):], right)
+	vm.Set(ins.Result, NewData(data))
 
 	return nil
 }
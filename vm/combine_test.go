@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -19,21 +17,23 @@ func TestCombine_Execute(t *testing.T) {
 		"both-non-empty": {[]byte("foo"), []byte("bar"), "foobar"},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralData(test.left),
-				"1": asttest.NewLiteralData(test.right),
+			registers := []*vm.Value{
+				vm.NewData(test.left),
+				vm.NewData(test.right),
+				nil,
+				nil,
 			}
-			ins := &vm.Combine{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Combine{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestCombine_String(t *testing.T) {
-	ins := &vm.Combine{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.Combine{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = combine($1, $2)", ins.String())
 }
@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
 )
 
 // Concat will create a new string by joining two other strings.
@@ -13,8 +11,8 @@ type Concat struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Concat) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralString(
-		vm.Get(ins.Left).Value+vm.Get(ins.Right).Value))
+	vm.Set(ins.Result, NewString(
+		vm.Get(ins.Left).Text+vm.Get(ins.Right).Text))
 
 	return nil
 }
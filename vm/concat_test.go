@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -19,21 +17,23 @@ func TestConcat_Execute(t *testing.T) {
 		"both-non-empty": {"foo", "bar", "foobar"},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralString(test.left),
-				"1": asttest.NewLiteralString(test.right),
+			registers := []*vm.Value{
+				vm.NewString(test.left),
+				vm.NewString(test.right),
+				nil,
+				nil,
 			}
-			ins := &vm.Concat{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Concat{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestConcat_String(t *testing.T) {
-	ins := &vm.Concat{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.Concat{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = concat($1, $2)", ins.String())
 }
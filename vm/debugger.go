@@ -0,0 +1,27 @@
+package vm
+
+// Frame is a function that is currently running.
+type Frame struct {
+	// FuncName is the name of the function, or the name of the test.
+	FuncName string
+
+	// Pos is the source position of the statement being executed. It will be
+	// empty if the function was not compiled from source.
+	Pos string
+
+	// Func is the function, or test, being run.
+	Func *CompiledFunc
+
+	// Scope contains the registers of the function.
+	Scope *Scope
+}
+
+// Debugger can be attached to a VM to inspect and control the execution of a
+// program.
+type Debugger interface {
+	// BeforeStatement is called before the first instruction of each statement
+	// is executed. The current statement is the last element of VM.Frames.
+	//
+	// The program will not continue until BeforeStatement returns.
+	BeforeStatement(vm *VM)
+}
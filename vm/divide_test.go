@@ -4,8 +4,6 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 	"github.com/elliotchance/ok/vm"
 
@@ -22,22 +20,24 @@ func TestDivide_Execute(t *testing.T) {
 		"divide-zero": {"1.2200", "0", "0", errors.New("division by zero")},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralNumber(test.left),
-				"1": asttest.NewLiteralNumber(test.right),
+			registers := []*vm.Value{
+				vm.NewNumber(test.left),
+				vm.NewNumber(test.right),
+				nil,
+				nil,
 			}
-			ins := &vm.Divide{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Divide{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.Equal(t, test.err, ins.Execute(nil, vm))
-			actual := number.NewNumber(registers[ins.Result].Value)
+			actual := registers[ins.Result].Number
 			assert.Equal(t, test.expected, number.Format(actual, -1))
 		})
 	}
 }
 
 func TestDivide_String(t *testing.T) {
-	ins := &vm.Divide{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.Divide{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 / $2", ins.String())
 }
@@ -15,46 +15,24 @@ type DynamicCall struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *DynamicCall) Execute(_ *int, vm *VM) error {
-	var args Registers
-	i := 0
-	for _, arg := range vm.Get(ins.Arguments).Array {
-		// TODO(elliot): This won't work with nested dynamic calls. Also, it's
-		//  really nasty.
-		register := Register(fmt.Sprintf("__%d", i))
-		vm.Set(register, arg)
-		args = append(args, register)
-		i++
-	}
-
-	var results Registers
 	funcLit := vm.Get(ins.Variable)
-	for range ast.NewFuncFromPrototype(funcLit.Kind).Returns {
-		register := Register(fmt.Sprintf("__%d", i))
-		results = append(results, register)
-		i++
-	}
-
-	realCall := &Call{
-		FunctionName: "*" + string(ins.Variable),
-		Arguments:    args,
-		Results:      results,
-	}
-
-	err := realCall.Execute(nil, vm)
+	results, err := vm.call(funcLit.Text, vm.Get(ins.Arguments).Array,
+		funcLit.Map, funcLit.Text)
 	if err != nil {
 		return err
 	}
 
-	resultsAsArray := &ast.Literal{
+	vm.Stack = vm.Stack[:len(vm.Stack)-1]
+	vm.Return = nil
+
+	resultsAsArray := &Value{
 		Kind:  "[]any",
-		Array: make([]*ast.Literal, len(realCall.Results)),
+		Array: make([]*Value, len(ast.NewFuncFromPrototype(funcLit.Kind).Returns)),
 	}
 
-	for i, result := range realCall.Results {
-		// TODO(elliot): It might be unsafe to pass them by reference this way.
-		//  We might need to copy them.
-		resultsAsArray.Array[i] = vm.Get(result)
-	}
+	// TODO(elliot): It might be unsafe to pass them by reference this way. We
+	//  might need to copy them.
+	copy(resultsAsArray.Array, results)
 
 	vm.Set(ins.Results, resultsAsArray)
 
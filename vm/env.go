@@ -0,0 +1,94 @@
+package vm
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// env returns the environment of the VM. See VM.Env.
+func (vm *VM) env() map[string]string {
+	if vm.Env == nil {
+		vm.Env = map[string]string{}
+		for _, s := range os.Environ() {
+			parts := strings.SplitN(s, "=", 2)
+			if len(parts) == 2 {
+				vm.Env[parts[0]] = parts[1]
+			}
+		}
+	}
+
+	return vm.Env
+}
+
+// Getenv returns the value of an environment variable, or an empty string if
+// it is not set.
+type Getenv struct {
+	Name, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Getenv) Execute(_ *int, vm *VM) error {
+	vm.Set(ins.Result, NewString(vm.env()[vm.Get(ins.Name).Text]))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Getenv) String() string {
+	return fmt.Sprintf("%s = os.Getenv(%s)", ins.Result, ins.Name)
+}
+
+// Setenv sets the value of an environment variable for the VM. The environment
+// of the process is not changed.
+type Setenv struct {
+	Name, Value Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Setenv) Execute(_ *int, vm *VM) error {
+	name := vm.Get(ins.Name).Text
+	if name == "" || strings.ContainsAny(name, "=\x00") {
+		vm.Raise(fmt.Sprintf("invalid environment variable name: %q", name))
+
+		return nil
+	}
+
+	vm.env()[name] = vm.Get(ins.Value).Text
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Setenv) String() string {
+	return fmt.Sprintf("os.Setenv(%s, %s)", ins.Name, ins.Value)
+}
+
+// Environ returns all of the environment variables as a map.
+type Environ struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Environ) Execute(_ *int, vm *VM) error {
+	env := &Value{
+		Kind: "{}string",
+		Map:  map[string]*Value{},
+	}
+
+	for name, value := range vm.env() {
+		env.Keys = append(env.Keys, name)
+		env.Map[name] = NewString(value)
+	}
+
+	sort.Strings(env.Keys)
+	vm.Set(ins.Result, env)
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Environ) String() string {
+	return fmt.Sprintf("%s = os.Environ()", ins.Result)
+}
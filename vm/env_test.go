@@ -0,0 +1,121 @@
+package vm_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetenv_Execute(t *testing.T) {
+	require.NoError(t, os.Setenv("OK_TEST_GETENV", "foo"))
+	defer os.Unsetenv("OK_TEST_GETENV")
+
+	for testName, test := range map[string]struct {
+		name     string
+		expected *vm.Value
+	}{
+		"set": {
+			"OK_TEST_GETENV",
+			vm.NewString("foo"),
+		},
+		"not-set": {
+			"OK_TEST_GETENV_MISSING",
+			vm.NewString(""),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{
+				vm.NewString(test.name),
+				nil,
+			}
+			ins := &vm.Getenv{Name: 0, Result: 1}
+			m := &vm.VM{
+				Stack: []*vm.Scope{{Registers: registers}},
+			}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expected, registers[ins.Result])
+		})
+	}
+}
+
+func TestSetenv_Execute(t *testing.T) {
+	for testName, test := range map[string]struct {
+		name     string
+		expected map[string]string
+		err      bool
+	}{
+		"set": {
+			name:     "OK_TEST_SETENV",
+			expected: map[string]string{"OK_TEST_SETENV": "bar"},
+		},
+		"empty-name": {
+			name:     "",
+			expected: map[string]string{},
+			err:      true,
+		},
+		"invalid-name": {
+			name:     "A=B",
+			expected: map[string]string{},
+			err:      true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{
+				vm.NewString(test.name),
+				vm.NewString("bar"),
+			}
+			ins := &vm.Setenv{Name: 0, Value: 1}
+			m := &vm.VM{
+				Stack: []*vm.Scope{{Registers: registers}},
+				Env:   map[string]string{},
+			}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expected, m.Env)
+			assert.Equal(t, test.err, m.ErrType != "")
+			assert.Equal(t, "", os.Getenv("OK_TEST_SETENV"))
+		})
+	}
+}
+
+func TestSetenv_Isolated(t *testing.T) {
+	require.NoError(t, os.Setenv("OK_TEST_ISOLATED", "process"))
+	defer os.Unsetenv("OK_TEST_ISOLATED")
+
+	newVM := func() *vm.VM {
+		return &vm.VM{
+			Stack: []*vm.Scope{{Registers: []*vm.Value{
+				vm.NewString("OK_TEST_ISOLATED"),
+				vm.NewString("changed"),
+				nil,
+			}}},
+		}
+	}
+
+	m1, m2 := newVM(), newVM()
+	assert.NoError(t, (&vm.Setenv{Name: 0, Value: 1}).Execute(nil, m1))
+
+	getenv := &vm.Getenv{Name: 0, Result: 2}
+	assert.NoError(t, getenv.Execute(nil, m1))
+	assert.Equal(t, vm.NewString("changed"), m1.Stack[0].Registers[2])
+	assert.NoError(t, getenv.Execute(nil, m2))
+	assert.Equal(t, vm.NewString("process"), m2.Stack[0].Registers[2])
+	assert.Equal(t, "process", os.Getenv("OK_TEST_ISOLATED"))
+}
+
+func TestEnviron_Execute(t *testing.T) {
+	require.NoError(t, os.Setenv("OK_TEST_ENVIRON", "baz"))
+	defer os.Unsetenv("OK_TEST_ENVIRON")
+
+	registers := []*vm.Value{nil}
+	ins := &vm.Environ{Result: 0}
+	m := &vm.VM{
+		Stack: []*vm.Scope{{Registers: registers}},
+	}
+	assert.NoError(t, ins.Execute(nil, m))
+	assert.Equal(t, "{}string", registers[0].Kind)
+	assert.Equal(t, vm.NewString("baz"), registers[0].Map["OK_TEST_ENVIRON"])
+	assert.Contains(t, registers[0].Keys, "OK_TEST_ENVIRON")
+}
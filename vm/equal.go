@@ -1,10 +1,9 @@
 package vm
 
 import (
+	"bytes"
 	"fmt"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler/kind"
 	"github.com/elliotchance/ok/number"
 )
@@ -16,7 +15,7 @@ type EqualNumber struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *EqualNumber) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralBool(numbersAreEqual(
+	vm.Set(ins.Result, NewBool(numbersAreEqual(
 		vm.Get(ins.Left),
 		vm.Get(ins.Right),
 	)))
@@ -29,23 +28,18 @@ func (ins *EqualNumber) String() string {
 	return fmt.Sprintf("%s = %s == %s", ins.Result, ins.Left, ins.Right)
 }
 
-func numbersAreEqual(a, b *ast.Literal) bool {
-	return number.Cmp(
-		number.NewNumber(a.Value),
-		number.NewNumber(b.Value),
-	) == 0
+func numbersAreEqual(a, b *Value) bool {
+	return number.Cmp(a.Number, b.Number) == 0
 }
 
-// Equal will compare two non-numbers for equality. This works for every other
-// type because every other type is stored as a string. When optimizations are
-// made in the future this will need to be expanded to one instruction per type.
+// Equal will compare two non-numbers for equality.
 type Equal struct {
 	Left, Right, Result Register
 }
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Equal) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralBool(compareValue(
+	vm.Set(ins.Result, NewBool(compareValue(
 		vm.Get(ins.Left), vm.Get(ins.Right))))
 
 	return nil
@@ -56,7 +50,7 @@ func (ins *Equal) String() string {
 	return fmt.Sprintf("%s = %s == %s", ins.Result, ins.Left, ins.Right)
 }
 
-func compareValue(a, b *ast.Literal) bool {
+func compareValue(a, b *Value) bool {
 	switch {
 	case kind.IsArray(a.Kind):
 		if len(a.Array) == len(b.Array) {
@@ -99,7 +93,17 @@ func compareValue(a, b *ast.Literal) bool {
 	case a.Kind == "number":
 		return numbersAreEqual(a, b)
 
+	case a.Kind == "bool":
+		return a.Bool == b.Bool
+
+	case a.Kind == "char":
+		return a.Char == b.Char
+
+	case a.Kind == "data":
+		return bytes.Equal(a.Data, b.Data)
+
 	default:
-		return a.Value == b.Value
+		// Strings and functions. Objects are always considered to be equal.
+		return a.Text == b.Text
 	}
 }
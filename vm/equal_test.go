@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -12,87 +10,90 @@ import (
 
 func TestEqual_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		left, right *ast.Literal
+		left, right *vm.Value
 		expected    string
 	}{
 		"false-false": {
-			asttest.NewLiteralBool(false), asttest.NewLiteralBool(false),
+			vm.NewBool(false), vm.NewBool(false),
 			"true"},
 		"false-true": {
-			asttest.NewLiteralBool(false), asttest.NewLiteralBool(true),
+			vm.NewBool(false), vm.NewBool(true),
 			"false",
 		},
 		"a-a": {
-			asttest.NewLiteralChar('a'), asttest.NewLiteralChar('a'),
+			vm.NewChar('a'), vm.NewChar('a'),
 			"true"},
 		"b-B": {
-			asttest.NewLiteralChar('b'), asttest.NewLiteralChar('B'),
+			vm.NewChar('b'), vm.NewChar('B'),
 			"false",
 		},
 		"d1-d1": {
-			asttest.NewLiteralData([]byte("d1")), asttest.NewLiteralData([]byte("d1")),
+			vm.NewData([]byte("d1")), vm.NewData([]byte("d1")),
 			"true"},
 		"d1-d2": {
-			asttest.NewLiteralData([]byte("d1")), asttest.NewLiteralData([]byte("d2")),
+			vm.NewData([]byte("d1")), vm.NewData([]byte("d2")),
 			"false",
 		},
 		"foo-foo": {
-			asttest.NewLiteralString("foo"), asttest.NewLiteralString("foo"),
+			vm.NewString("foo"), vm.NewString("foo"),
 			"true"},
 		"foo-bar": {
-			asttest.NewLiteralString("foo"), asttest.NewLiteralString("bar"),
+			vm.NewString("foo"), vm.NewString("bar"),
 			"false",
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.left,
-				"1": test.right,
+			registers := []*vm.Value{
+				test.left,
+				test.right,
+				nil,
 			}
-			ins := &vm.Equal{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Equal{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestEqualNumber_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		left, right *ast.Literal
+		left, right *vm.Value
 		expected    string
 	}{
 		"1-1.0": {
-			asttest.NewLiteralNumber("1"), asttest.NewLiteralNumber("1.0"),
+			vm.NewNumber("1"), vm.NewNumber("1.0"),
 			"true"},
 		"1-1.1": {
-			asttest.NewLiteralNumber("1"), asttest.NewLiteralNumber("1.1"),
+			vm.NewNumber("1"), vm.NewNumber("1.1"),
 			"false",
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.left,
-				"1": test.right,
+			registers := []*vm.Value{
+				test.left,
+				test.right,
+				nil,
+				nil,
 			}
-			ins := &vm.EqualNumber{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.EqualNumber{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestEqual_String(t *testing.T) {
-	ins := &vm.Equal{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.Equal{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 == $2", ins.String())
 }
 
 func TestEqualNumber_String(t *testing.T) {
-	ins := &vm.EqualNumber{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.EqualNumber{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 == $2", ins.String())
 }
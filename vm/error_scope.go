@@ -2,25 +2,104 @@ package vm
 
 import (
 	"fmt"
+	"strings"
 )
 
-// On is a pragma for the vm to handle errors. It can also be used to indicate
-// the end of the on's so that the VM can send the error up to the caller.
+// ErrorScope is the handler table for a try block. If an error is raised by
+// any of the instructions in the try block the first On that can handle the
+// error is used. Otherwise, the error is passed to the next ErrorScope that
+// contains the instruction, and finally up to the caller.
+type ErrorScope struct {
+	// Start is the index of the first instruction in the try block. End is the
+	// index after the last instruction in the try block.
+	Start, End int
+
+	// On can have zero or more elements.
+	On []*On
+}
+
+// On is an error handler in an ErrorScope.
 type On struct {
-	// Type will be the name of the error, or it can be empty to signal there
-	// are no more errors to check. If the VM hits an empty Type it will return
-	// and pass the error up to the caller.
+	// Type is the name of the error type, or interface, that will be handled.
+	// A raised error will be handled if its type satisfies the interface of
+	// Type.
 	Type string
+
+	// Err is the register that will receive the error.
+	Err Register
+
+	// To is the index of the first instruction of the handler.
+	To int
 }
 
-// Execute implements the Instruction interface for the VM.
-func (ins *On) Execute(_ *int, vm *VM) error {
-	// Nothing happens here because On is just a pragma for the VM to look
-	// forward to find the error handler.
+// String is the human-readable description of the error scope. The
+// instruction numbers are the same as the ones shown by "ok asm".
+func (scope *ErrorScope) String() string {
+	var handlers []string
+	for _, on := range scope.On {
+		handlers = append(handlers, on.String())
+	}
+
+	s := fmt.Sprintf("try #%d-#%d", scope.Start+1, scope.End)
+	if len(handlers) > 0 {
+		s += ": " + strings.Join(handlers, ", ")
+	}
+
+	return s
+}
+
+// String is the human-readable description of the handler.
+func (on *On) String() string {
+	return fmt.Sprintf("on %s (err %s) jump to #%d", on.Type, on.Err,
+		on.To+1)
+}
+
+// handler returns the innermost handler for the current error that contains
+// the instruction at index i. It returns nil if there is no such handler.
+func (vm *VM) handler(fn *CompiledFunc, i int) *On {
+	// The error scopes are ordered so that nested scopes appear before the
+	// scopes that contain them.
+	for _, scope := range fn.ErrorScopes {
+		if i < scope.Start || i >= scope.End {
+			continue
+		}
+
+		for _, on := range scope.On {
+			if vm.satisfies(vm.ErrType, on.Type) {
+				return on
+			}
+		}
+	}
+
 	return nil
 }
 
-// String is the human-readable description of the instruction.
-func (ins *On) String() string {
-	return fmt.Sprintf("on %s", ins.Type)
+// satisfies returns true if values of type ty can be used as iface. That is,
+// ty has all of the same public properties (including functions) as iface.
+func (vm *VM) satisfies(ty, iface string) bool {
+	if ty == iface {
+		return true
+	}
+
+	want, have := vm.interfaceOf(iface), vm.interfaceOf(ty)
+	if want == nil || have == nil {
+		return false
+	}
+
+	for name, kind := range want {
+		if have[name] != kind {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (vm *VM) interfaceOf(ty string) map[string]string {
+	if i, ok := vm.Interfaces[ty]; ok {
+		return i
+	}
+
+	// Types, like Error, from the standard library.
+	return Interfaces[ty]
 }
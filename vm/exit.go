@@ -0,0 +1,31 @@
+package vm
+
+import (
+	"fmt"
+)
+
+// ExitError is returned when the program asks to stop with Exit. Nothing else,
+// including finally blocks, is run after Exit.
+type ExitError struct {
+	Code int
+}
+
+// Error implements the error interface.
+func (err *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", err.Code)
+}
+
+// Exit stops the program with an exit code.
+type Exit struct {
+	Code Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Exit) Execute(_ *int, vm *VM) error {
+	return &ExitError{Code: vm.Get(ins.Code).Int()}
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Exit) String() string {
+	return fmt.Sprintf("os.Exit(%s)", ins.Code)
+}
@@ -0,0 +1,17 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExit_Execute(t *testing.T) {
+	registers := []*vm.Value{vm.NewNumber("3")}
+	ins := &vm.Exit{Code: 0}
+	m := &vm.VM{
+		Stack: []*vm.Scope{{Registers: registers}},
+	}
+	assert.Equal(t, &vm.ExitError{Code: 3}, ins.Execute(nil, m))
+}
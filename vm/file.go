@@ -0,0 +1,221 @@
+package vm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// openFile is a file that was opened with FileOpen. Each file is referenced
+// by a number so that it can be stored in a value.
+type openFile struct {
+	path   string
+	file   *os.File
+	reader *bufio.Reader
+}
+
+// file returns the open file for the register, or nil after raising an error.
+func (vm *VM) file(fd Register) *openFile {
+	f := vm.files[vm.Get(fd).Int()]
+	if f == nil {
+		vm.Raise("file is not open")
+	}
+
+	return f
+}
+
+// FileOpen opens a file for reading ("r"), writing ("w") or appending ("a").
+// Writing will create the file or replace its contents. The result is the
+// number used by the other File instructions.
+type FileOpen struct {
+	Path, Mode, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *FileOpen) Execute(_ *int, vm *VM) error {
+	var flag int
+	switch mode := vm.Get(ins.Mode).Text; mode {
+	case "r":
+		flag = os.O_RDONLY
+
+	case "w":
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+
+	case "a":
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+
+	default:
+		vm.Raise(fmt.Sprintf("invalid file mode: %s", mode))
+
+		return nil
+	}
+
+	path := vm.Get(ins.Path).Text
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		vm.raiseFileError(err, path)
+
+		return nil
+	}
+
+	if vm.files == nil {
+		vm.files = map[int]*openFile{}
+	}
+
+	vm.lastFile++
+	vm.files[vm.lastFile] = &openFile{
+		path:   path,
+		file:   f,
+		reader: bufio.NewReader(f),
+	}
+	vm.Set(ins.Result, NewInt(vm.lastFile))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *FileOpen) String() string {
+	return fmt.Sprintf("%s = fs.Open(%s, %s)", ins.Result, ins.Path, ins.Mode)
+}
+
+// FileRead reads up to Size bytes. The result is empty at the end of the file.
+type FileRead struct {
+	File, Size, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *FileRead) Execute(_ *int, vm *VM) error {
+	f := vm.file(ins.File)
+	if f == nil {
+		return nil
+	}
+
+	size := vm.Get(ins.Size).Int()
+	if size < 0 {
+		vm.Raise(fmt.Sprintf("invalid read size: %d", size))
+
+		return nil
+	}
+
+	data := make([]byte, size)
+	n, err := io.ReadFull(f.reader, data)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		vm.raiseFileError(err, f.path)
+
+		return nil
+	}
+
+	vm.Set(ins.Result, NewData(data[:n]))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *FileRead) String() string {
+	return fmt.Sprintf("%s = fs.Read(%s, %s)", ins.Result, ins.File, ins.Size)
+}
+
+// FileReadLine reads the next line, without the line ending.
+type FileReadLine struct {
+	File, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *FileReadLine) Execute(_ *int, vm *VM) error {
+	f := vm.file(ins.File)
+	if f == nil {
+		return nil
+	}
+
+	line, err := f.reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		vm.raiseFileError(err, f.path)
+
+		return nil
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	vm.Set(ins.Result, NewString(line))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *FileReadLine) String() string {
+	return fmt.Sprintf("%s = fs.ReadLine(%s)", ins.Result, ins.File)
+}
+
+// FileEOF is true when there is nothing left to read.
+type FileEOF struct {
+	File, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *FileEOF) Execute(_ *int, vm *VM) error {
+	f := vm.file(ins.File)
+	if f == nil {
+		return nil
+	}
+
+	_, err := f.reader.Peek(1)
+	vm.Set(ins.Result, NewBool(err != nil))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *FileEOF) String() string {
+	return fmt.Sprintf("%s = fs.EOF(%s)", ins.Result, ins.File)
+}
+
+// FileWrite writes data to the file.
+type FileWrite struct {
+	File, Data Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *FileWrite) Execute(_ *int, vm *VM) error {
+	f := vm.file(ins.File)
+	if f == nil {
+		return nil
+	}
+
+	if _, err := f.file.Write(vm.Get(ins.Data).Data); err != nil {
+		vm.raiseFileError(err, f.path)
+	}
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *FileWrite) String() string {
+	return fmt.Sprintf("fs.Write(%s, %s)", ins.File, ins.Data)
+}
+
+// FileClose closes the file. The file cannot be used after it is closed.
+type FileClose struct {
+	File Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *FileClose) Execute(_ *int, vm *VM) error {
+	f := vm.file(ins.File)
+	if f == nil {
+		return nil
+	}
+
+	delete(vm.files, vm.Get(ins.File).Int())
+	if err := f.file.Close(); err != nil {
+		vm.raiseFileError(err, f.path)
+	}
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *FileClose) String() string {
+	return fmt.Sprintf("fs.Close(%s)", ins.File)
+}
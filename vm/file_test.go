@@ -0,0 +1,71 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRead_Execute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo"), 0644))
+
+	for testName, test := range map[string]struct {
+		size     int
+		expected *vm.Value
+		err      string
+	}{
+		"partial": {
+			size:     2,
+			expected: vm.NewData([]byte("fo")),
+		},
+		"past-end": {
+			size:     10,
+			expected: vm.NewData([]byte("foo")),
+		},
+		"zero": {
+			size:     0,
+			expected: vm.NewData([]byte{}),
+		},
+		"negative": {
+			size: -1,
+			err:  "invalid read size: -1",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{
+				vm.NewString(path),
+				vm.NewString("r"),
+				nil,
+				vm.NewInt(test.size),
+				nil,
+			}
+			m := &vm.VM{
+				Stack: []*vm.Scope{{Registers: registers}},
+			}
+			open := &vm.FileOpen{Path: 0, Mode: 1, Result: 2}
+			require.NoError(t, open.Execute(nil, m))
+			defer (&vm.FileClose{File: 2}).Execute(nil, m)
+
+			ins := &vm.FileRead{File: 2, Size: 3, Result: 4}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expected, registers[ins.Result])
+
+			if test.err != "" {
+				assert.Equal(t, "Error", m.ErrType)
+				assert.Equal(t, vm.NewString(test.err), m.ErrValue.Map["Error"])
+			} else {
+				assert.Equal(t, "", m.ErrType)
+			}
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package vm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// raiseFileError raises one of the error types from the fs package, or an
+// Error if there is no specific type for err. The properties must be the same
+// as the constructors in lib/fs/errors.ok.
+func (vm *VM) raiseFileError(err error, path string) {
+	ty, marker := "", ""
+	switch {
+	case os.IsNotExist(err):
+		ty, marker = "fs.NotFoundError", "NotFound"
+
+	case os.IsPermission(err):
+		ty, marker = "fs.PermissionError", "Permission"
+
+	case os.IsExist(err):
+		ty, marker = "fs.ExistsError", "Exists"
+
+	default:
+		vm.Raise(err.Error())
+
+		return
+	}
+
+	vm.ErrType = ty
+	vm.ErrValue = &Value{
+		Kind: ty,
+		Map: map[string]*Value{
+			"Error": NewString(err.Error()),
+			"Path":  NewString(path),
+			marker:  NewBool(true),
+		},
+	}
+}
+
+// newFileInfo creates a value for the FileInfo type in the fs package.
+func newFileInfo(info os.FileInfo) *Value {
+	return &Value{
+		Kind: "fs.FileInfo",
+		Map: map[string]*Value{
+			"Name":    NewString(info.Name()),
+			"Size":    NewNumber(strconv.FormatInt(info.Size(), 10)),
+			"Mode":    NewInt(int(info.Mode().Perm())),
+			"ModTime": NewNumber(strconv.FormatInt(info.ModTime().Unix(), 10)),
+			"IsDir":   NewBool(info.IsDir()),
+		},
+	}
+}
+
+// ReadFile returns the contents of a file.
+type ReadFile struct {
+	Path, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *ReadFile) Execute(_ *int, vm *VM) error {
+	path := vm.Get(ins.Path).Text
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		vm.raiseFileError(err, path)
+
+		return nil
+	}
+
+	vm.Set(ins.Result, NewData(data))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *ReadFile) String() string {
+	return fmt.Sprintf("%s = fs.ReadFile(%s)", ins.Result, ins.Path)
+}
+
+// WriteFile replaces the contents of a file, creating it if needed. If Append
+// is true the data is added to the end of the file instead.
+type WriteFile struct {
+	Path, Data Register
+	Append     bool
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *WriteFile) Execute(_ *int, vm *VM) error {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if ins.Append {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+
+	path := vm.Get(ins.Path).Text
+	f, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		vm.raiseFileError(err, path)
+
+		return nil
+	}
+
+	_, err = f.Write(vm.Get(ins.Data).Data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		vm.raiseFileError(err, path)
+	}
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *WriteFile) String() string {
+	if ins.Append {
+		return fmt.Sprintf("fs.AppendFile(%s, %s)", ins.Path, ins.Data)
+	}
+
+	return fmt.Sprintf("fs.WriteFile(%s, %s)", ins.Path, ins.Data)
+}
+
+// Stat returns the FileInfo for a file or directory.
+type Stat struct {
+	Path, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Stat) Execute(_ *int, vm *VM) error {
+	path := vm.Get(ins.Path).Text
+	info, err := os.Stat(path)
+	if err != nil {
+		vm.raiseFileError(err, path)
+
+		return nil
+	}
+
+	vm.Set(ins.Result, newFileInfo(info))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Stat) String() string {
+	return fmt.Sprintf("%s = fs.Stat(%s)", ins.Result, ins.Path)
+}
+
+// ReadDir returns the FileInfo for each entry in a directory, sorted by name.
+type ReadDir struct {
+	Path, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *ReadDir) Execute(_ *int, vm *VM) error {
+	path := vm.Get(ins.Path).Text
+	infos, err := ioutil.ReadDir(path)
+	if err != nil {
+		vm.raiseFileError(err, path)
+
+		return nil
+	}
+
+	entries := &Value{Kind: "[]fs.FileInfo"}
+	for _, info := range infos {
+		entries.Array = append(entries.Array, newFileInfo(info))
+	}
+
+	vm.Set(ins.Result, entries)
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *ReadDir) String() string {
+	return fmt.Sprintf("%s = fs.ReadDir(%s)", ins.Result, ins.Path)
+}
+
+// MkdirAll creates a directory and any parent directories that do not exist.
+type MkdirAll struct {
+	Path Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *MkdirAll) Execute(_ *int, vm *VM) error {
+	path := vm.Get(ins.Path).Text
+	if err := os.MkdirAll(path, 0755); err != nil {
+		vm.raiseFileError(err, path)
+	}
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *MkdirAll) String() string {
+	return fmt.Sprintf("fs.MkdirAll(%s)", ins.Path)
+}
+
+// Remove deletes a file or empty directory. If All is true, a directory is
+// removed with everything it contains.
+type Remove struct {
+	Path Register
+	All  bool
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Remove) Execute(_ *int, vm *VM) error {
+	remove := os.Remove
+	if ins.All {
+		remove = os.RemoveAll
+	}
+
+	path := vm.Get(ins.Path).Text
+	if err := remove(path); err != nil {
+		vm.raiseFileError(err, path)
+	}
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Remove) String() string {
+	if ins.All {
+		return fmt.Sprintf("fs.RemoveAll(%s)", ins.Path)
+	}
+
+	return fmt.Sprintf("fs.Remove(%s)", ins.Path)
+}
+
+// Rename moves a file or directory.
+type Rename struct {
+	From, To Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Rename) Execute(_ *int, vm *VM) error {
+	from := vm.Get(ins.From).Text
+	if err := os.Rename(from, vm.Get(ins.To).Text); err != nil {
+		vm.raiseFileError(err, from)
+	}
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Rename) String() string {
+	return fmt.Sprintf("fs.Rename(%s, %s)", ins.From, ins.To)
+}
+
+// Glob returns the sorted paths that match a pattern.
+type Glob struct {
+	Pattern, Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Glob) Execute(_ *int, vm *VM) error {
+	paths, err := filepath.Glob(vm.Get(ins.Pattern).Text)
+	if err != nil {
+		vm.Raise(err.Error())
+
+		return nil
+	}
+
+	sort.Strings(paths)
+
+	result := &Value{Kind: "[]string"}
+	for _, path := range paths {
+		result.Array = append(result.Array, NewString(path))
+	}
+
+	vm.Set(ins.Result, result)
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Glob) String() string {
+	return fmt.Sprintf("%s = fs.Glob(%s)", ins.Result, ins.Pattern)
+}
+
+// TempFile creates a new empty file in the temporary directory and returns its
+// path. If Dir is true a directory is created instead.
+type TempFile struct {
+	Result Register
+	Dir    bool
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *TempFile) Execute(_ *int, vm *VM) error {
+	var path string
+	var err error
+	if ins.Dir {
+		path, err = ioutil.TempDir("", "ok")
+	} else {
+		var f *os.File
+		f, err = ioutil.TempFile("", "ok")
+		if err == nil {
+			path = f.Name()
+			err = f.Close()
+		}
+	}
+
+	if err != nil {
+		vm.raiseFileError(err, os.TempDir())
+
+		return nil
+	}
+
+	vm.Set(ins.Result, NewString(path))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *TempFile) String() string {
+	if ins.Dir {
+		return fmt.Sprintf("%s = fs.TempDir()", ins.Result)
+	}
+
+	return fmt.Sprintf("%s = fs.TempFile()", ins.Result)
+}
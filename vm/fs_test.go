@@ -0,0 +1,97 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile_Execute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("foo"), 0644))
+
+	for testName, test := range map[string]struct {
+		path     string
+		expected *vm.Value
+		errType  string
+	}{
+		"exists": {
+			path:     path,
+			expected: vm.NewData([]byte("foo")),
+		},
+		"not-found": {
+			path:    filepath.Join(dir, "missing"),
+			errType: "fs.NotFoundError",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{vm.NewString(test.path), nil}
+			ins := &vm.ReadFile{Path: 0, Result: 1}
+			m := &vm.VM{
+				Stack: []*vm.Scope{{Registers: registers}},
+			}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expected, registers[ins.Result])
+			assert.Equal(t, test.errType, m.ErrType)
+
+			if test.errType != "" {
+				assert.Equal(t, test.errType, m.ErrValue.Kind)
+				assert.Equal(t, vm.NewString(test.path), m.ErrValue.Map["Path"])
+				assert.Equal(t, vm.NewBool(true), m.ErrValue.Map["NotFound"])
+			}
+		})
+	}
+}
+
+func TestWriteFile_Execute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.txt")
+	registers := []*vm.Value{
+		vm.NewString(path),
+		vm.NewData([]byte("foo")),
+	}
+	m := &vm.VM{
+		Stack: []*vm.Scope{{Registers: registers}},
+	}
+
+	assert.NoError(t, (&vm.WriteFile{Path: 0, Data: 1}).Execute(nil, m))
+	assert.NoError(t, (&vm.WriteFile{Path: 0, Data: 1, Append: true}).Execute(nil, m))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "foofoo", string(data))
+}
+
+func TestStat_Execute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("hello"), 0644))
+
+	registers := []*vm.Value{vm.NewString(path), nil}
+	ins := &vm.Stat{Path: 0, Result: 1}
+	m := &vm.VM{
+		Stack: []*vm.Scope{{Registers: registers}},
+	}
+	assert.NoError(t, ins.Execute(nil, m))
+
+	info := registers[ins.Result]
+	assert.Equal(t, "fs.FileInfo", info.Kind)
+	assert.Equal(t, vm.NewString("a.txt"), info.Map["Name"])
+	assert.Equal(t, vm.NewNumber("5"), info.Map["Size"])
+	assert.Equal(t, vm.NewBool(false), info.Map["IsDir"])
+}
@@ -1,34 +1,122 @@
 package vm
 
-import (
-	"fmt"
-)
-
 type CompiledFunc struct {
 	Arguments    []string
 	Instructions []Instruction
 	Registers    int
 	Variables    map[string]string // name: type
-	Finally      [][]Instruction
-	Interfaces   map[string]map[string]string
+
+	// Locals contains the register for each variable. The arguments are
+	// always the first registers, starting at 1.
+	Locals map[string]Register
+
+	// Upvalues contains the name of each register that is stored in the scope
+	// (see StateRegister) rather than the register itself. This allows the
+	// variable to be shared with function literals and objects. A name that
+	// starts with "^" is in the parent scope.
+	//
+	// Registers that are not upvalues have no name. Upvalues may be shorter
+	// than the number of registers.
+	Upvalues []string
+
+	// ErrorScopes contains the handlers for each try block. A nested try
+	// block always appears before the try block that contains it.
+	ErrorScopes []*ErrorScope
+
+	Finally    [][]Instruction
+	Interfaces map[string]map[string]string
+
+	// Positions contains the source position of the statement that created
+	// each instruction. It may be shorter than Instructions, or contain empty
+	// positions, when the source is not known.
+	Positions []string
+
+	// FinallyPositions are the Positions for each of the Finally blocks.
+	FinallyPositions [][]string
 }
 
 type FinallyBlock struct {
 	Run          bool
 	Instructions []Instruction
+	Positions    []string
 }
 
 func (c *CompiledFunc) NextRegister() Register {
 	c.Registers++
 
-	return Register(fmt.Sprintf("%d", c.Registers))
+	return Register(c.Registers)
 }
 
 func (c *CompiledFunc) Append(instruction Instruction) {
 	c.Instructions = append(c.Instructions, instruction)
+	c.Positions = append(c.Positions, "")
+}
+
+// SetPositions sets the position for all instructions from start that do not
+// already have a position.
+func (c *CompiledFunc) SetPositions(start int, pos string) {
+	for i := start; i < len(c.Positions); i++ {
+		if c.Positions[i] == "" {
+			c.Positions[i] = pos
+		}
+	}
+}
+
+// Position returns the source position for an instruction, or an empty string
+// if it is not known.
+func (c *CompiledFunc) Position(i int) string {
+	if i < 0 || i >= len(c.Positions) {
+		return ""
+	}
+
+	return c.Positions[i]
 }
 
-func (c *CompiledFunc) NewVariable(variableName string, kind string) {
+// NewVariable returns the register for a variable. The register is allocated
+// the first time the variable is seen.
+func (c *CompiledFunc) NewVariable(variableName string, kind string) Register {
 	// TODO(elliot): Check already registered variables.
+	if c.Variables == nil {
+		c.Variables = map[string]string{}
+	}
 	c.Variables[variableName] = kind
+
+	return c.VariableRegister(variableName)
+}
+
+// VariableRegister returns the register for a variable without changing its
+// type. A variable in the parent scope, like "^a", is always an upvalue.
+func (c *CompiledFunc) VariableRegister(variableName string) Register {
+	if register, ok := c.Locals[variableName]; ok {
+		return register
+	}
+
+	register := c.NextRegister()
+	if c.Locals == nil {
+		c.Locals = map[string]Register{}
+	}
+	c.Locals[variableName] = register
+
+	if variableName[0] == '^' {
+		c.setUpvalue(register, variableName)
+	}
+
+	return register
+}
+
+// ShareVariables makes all variables upvalues. This is needed when the scope
+// is captured by a function literal or is returned as an object. It must be
+// called after all variables have been created.
+func (c *CompiledFunc) ShareVariables() {
+	for name, register := range c.Locals {
+		c.setUpvalue(register, name)
+	}
+}
+
+func (c *CompiledFunc) setUpvalue(register Register, name string) {
+	for len(c.Upvalues) <= int(register) {
+		c.Upvalues = append(c.Upvalues, "")
+	}
+
+	c.Upvalues[register] = name
 }
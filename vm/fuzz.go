@@ -0,0 +1,700 @@
+package vm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elliotchance/ok/compiler/kind"
+	"github.com/elliotchance/ok/number"
+)
+
+const (
+	// DefaultFuzzCount is the number of random inputs for each fuzz test when
+	// FuzzCount is not set.
+	DefaultFuzzCount = 100
+
+	// maxShrinkRuns limits how many times a fuzz test will be run while
+	// looking for a smaller input that still fails.
+	maxShrinkRuns = 1000
+
+	// maxFuzzLength is the most characters, bytes or elements in a generated
+	// value.
+	maxFuzzLength = 16
+)
+
+// fuzzChars are picked from to generate strings and chars. Characters that
+// often cause problems (such as delimiters, whitespace and multibyte
+// characters) are more likely than they would be if picked at random.
+var fuzzChars = []rune("aaabbcxyzABZ0129 _-,.:;!@#/\\\"'\n\té世🙂")
+
+// fuzzRun is the result of running a fuzz test with one set of arguments.
+type fuzzRun struct {
+	arguments []*Value
+	result    *TestResult
+	output    string
+}
+
+// runFuzz runs a fuzz test. Any inputs in the corpus are run first, then
+// FuzzCount random inputs are generated from FuzzSeed. The first failing input
+// is shrunk to the smallest input that still fails and it is saved to the
+// corpus so that it will be run again next time.
+//
+// The fuzz test stops at the first failure, and only the failures from the
+// smallest input are kept in the result.
+func (vm *VM) runFuzz(test *CompiledTest) error {
+	result := vm.TestResults[0]
+	startTime := time.Now()
+	defer func() {
+		result.Elapsed = time.Since(startTime)
+	}()
+
+	paths, corpus, err := vm.loadFuzzCorpus(test)
+	if err != nil {
+		return err
+	}
+
+	for i, arguments := range corpus {
+		if run := vm.fuzzOnce(test, arguments); !run.result.Passed() {
+			vm.fuzzFailed(test, run, "fuzz failed for "+paths[i])
+
+			return nil
+		}
+	}
+
+	count := vm.FuzzCount
+	if count < 1 {
+		count = DefaultFuzzCount
+	}
+
+	rng := rand.New(rand.NewSource(vm.FuzzSeed))
+	for i := 1; i <= count; i++ {
+		arguments := make([]*Value, len(test.FuzzTypes))
+		for j, ty := range test.FuzzTypes {
+			arguments[j] = fuzzValue(rng, ty)
+		}
+
+		run := vm.fuzzOnce(test, arguments)
+		if run.result.Passed() {
+			continue
+		}
+
+		run = vm.shrinkFuzz(test, run)
+		message := fmt.Sprintf("fuzz failed after %d runs with seed %d",
+			i, vm.FuzzSeed)
+
+		path, err := vm.saveFuzzCorpus(test, run.arguments)
+		if err != nil {
+			return err
+		}
+		if path != "" {
+			message += ", saved to " + path
+		}
+
+		vm.fuzzFailed(test, run, message)
+
+		return nil
+	}
+
+	return nil
+}
+
+// fuzzOnce runs the test with the arguments. A runtime error, such as an
+// unhandled error, is added as a failure.
+func (vm *VM) fuzzOnce(test *CompiledTest, arguments []*Value) *fuzzRun {
+	run := &fuzzRun{
+		arguments: arguments,
+		result:    &TestResult{Name: test.TestName},
+	}
+
+	// The asserts are added to the run rather than to the test. A previous run
+	// may have stopped part way through so the stack must also be reset.
+	result, stdout, stderr := vm.TestResults[0], vm.Stdout, vm.Stderr
+	output := new(bytes.Buffer)
+	vm.TestResults[0], vm.Stdout, vm.Stderr = run.result, output, output
+	vm.Stack, vm.Frames, vm.FinallyBlocks, vm.Return = nil, nil, nil, nil
+
+	err := vm.runTestFunc(test, arguments, map[string]*Value{})
+
+	vm.TestResults[0], vm.Stdout, vm.Stderr = result, stdout, stderr
+	result.Assertions += run.result.Assertions
+	run.output = output.String()
+
+	if err != nil {
+		run.result.Failures = append(run.result.Failures, errorFailure(err))
+	}
+
+	return run
+}
+
+// fuzzFailed records the failures of run for the test. The arguments are
+// included so that the failure can be reproduced.
+func (vm *VM) fuzzFailed(test *CompiledTest, run *fuzzRun, message string) {
+	var details []string
+	for i, argument := range run.arguments {
+		details = append(details,
+			fmt.Sprintf("%s = %s", test.Arguments[i], fuzzLiteral(argument)))
+	}
+
+	result := vm.TestResults[0]
+	result.Failures = append(result.Failures, &AssertionFailure{
+		Pos:     run.result.Failures[0].Pos,
+		Message: message,
+		Details: strings.Join(details, "\n"),
+	})
+	result.Failures = append(result.Failures, run.result.Failures...)
+
+	fmt.Fprint(vm.Stdout, run.output)
+}
+
+// fuzzLiteral renders a value the same way as renderValue, except that the
+// result is always valid ok. This allows a failing input to be pasted into a
+// test.
+func fuzzLiteral(v *Value) string {
+	switch {
+	case kind.IsArray(v.Kind):
+		// An empty array needs its type, otherwise it cannot be compiled.
+		if len(v.Array) == 0 {
+			return v.Kind + " []"
+		}
+
+		var elements []string
+		for _, element := range v.Array {
+			elements = append(elements, fuzzLiteral(element))
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+
+	case kind.IsMap(v.Kind):
+		if len(v.Map) == 0 {
+			return v.Kind + " {}"
+		}
+
+		var keys []string
+		for key := range v.Map {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var elements []string
+		for _, key := range keys {
+			elements = append(elements,
+				quoteLiteral(key, '"')+": "+fuzzLiteral(v.Map[key]))
+		}
+
+		return "{" + strings.Join(elements, ", ") + "}"
+	}
+
+	switch v.Kind {
+	case "char":
+		// There is no escape for a single quote in a char literal.
+		if v.Char == '\'' {
+			return fmt.Sprintf("char(%d)", v.Char)
+		}
+
+		return quoteLiteral(string(v.Char), '\'')
+
+	case "string":
+		return quoteLiteral(v.Text, '"')
+
+	case "data":
+		return "data(" + quoteLiteral(string(v.Data), '"') + ")"
+	}
+
+	return renderValue(v, true)
+}
+
+// quoteLiteral is the opposite of the lexer reading a string or char literal.
+func quoteLiteral(s string, quote rune) string {
+	var b strings.Builder
+	b.WriteRune(quote)
+	for _, c := range s {
+		switch c {
+		case '\a':
+			b.WriteString(`\a`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\v':
+			b.WriteString(`\v`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '{':
+			// Otherwise it would be an interpolation.
+			if quote == '"' {
+				b.WriteString(`\{`)
+			} else {
+				b.WriteRune(c)
+			}
+		default:
+			b.WriteRune(c)
+		}
+	}
+	b.WriteRune(quote)
+
+	return b.String()
+}
+
+// shrinkFuzz tries to find smaller arguments that still cause the test to
+// fail. Each argument is made smaller, one at a time, until none of them can
+// be made smaller.
+func (vm *VM) shrinkFuzz(test *CompiledTest, run *fuzzRun) *fuzzRun {
+	runs := 0
+	for shrunk := true; shrunk && runs < maxShrinkRuns; {
+		shrunk = false
+		for i, ty := range test.FuzzTypes {
+			for _, candidate := range shrinkValue(run.arguments[i], ty) {
+				if runs >= maxShrinkRuns {
+					break
+				}
+				runs++
+
+				arguments := append([]*Value{}, run.arguments...)
+				arguments[i] = candidate
+				if next := vm.fuzzOnce(test, arguments); !next.result.Passed() {
+					run = next
+					shrunk = true
+
+					break
+				}
+			}
+		}
+	}
+
+	return run
+}
+
+// fuzzValue generates a random value of a fuzzable type.
+func fuzzValue(rng *rand.Rand, ty string) *Value {
+	switch {
+	case kind.IsArray(ty):
+		value := &Value{Kind: ty}
+		for i := rng.Intn(maxFuzzLength / 2); i > 0; i-- {
+			value.Array = append(value.Array,
+				fuzzValue(rng, kind.ElementType(ty)))
+		}
+
+		return value
+
+	case kind.IsMap(ty):
+		value := &Value{Kind: ty, Map: map[string]*Value{}}
+		for i := rng.Intn(maxFuzzLength / 2); i > 0; i-- {
+			key := fuzzValue(rng, "string").Text
+			if _, ok := value.Map[key]; !ok {
+				value.Keys = append(value.Keys, key)
+			}
+			value.Map[key] = fuzzValue(rng, kind.ElementType(ty))
+		}
+
+		return value
+	}
+
+	switch ty {
+	case "bool":
+		return NewBool(rng.Intn(2) == 0)
+
+	case "char":
+		return NewChar(fuzzChars[rng.Intn(len(fuzzChars))])
+
+	case "data":
+		data := make([]byte, rng.Intn(maxFuzzLength))
+		rng.Read(data)
+
+		return NewData(data)
+
+	case "string":
+		s := make([]rune, rng.Intn(maxFuzzLength))
+		for i := range s {
+			s[i] = fuzzChars[rng.Intn(len(fuzzChars))]
+		}
+
+		return NewString(string(s))
+	}
+
+	// Small numbers are the most likely to find edge cases, but large numbers
+	// and decimals are also needed.
+	switch rng.Intn(4) {
+	case 0:
+		return NewInt(rng.Intn(3) - 1)
+
+	case 1:
+		return NewInt(rng.Intn(201) - 100)
+
+	case 2:
+		return NewNumber(fmt.Sprintf("%d.%02d", rng.Intn(2001)-1000, rng.Intn(100)))
+	}
+
+	return NewInt(rng.Intn(2000001) - 1000000)
+}
+
+// shrinkValue returns values that are simpler than value, starting with the
+// simplest.
+func shrinkValue(value *Value, ty string) []*Value {
+	var candidates []*Value
+	switch {
+	case kind.IsArray(ty):
+		if len(value.Array) == 0 {
+			return nil
+		}
+
+		for _, r := range shrinkRanges(len(value.Array)) {
+			elements := append([]*Value{}, value.Array[:r[0]]...)
+			elements = append(elements, value.Array[r[1]:]...)
+			candidates = append(candidates, &Value{Kind: ty, Array: elements})
+		}
+		for i, element := range value.Array {
+			for _, candidate := range shrinkValue(element, kind.ElementType(ty)) {
+				elements := append([]*Value{}, value.Array...)
+				elements[i] = candidate
+				candidates = append(candidates, &Value{Kind: ty, Array: elements})
+			}
+		}
+
+		return candidates
+
+	case kind.IsMap(ty):
+		if len(value.Keys) == 0 {
+			return nil
+		}
+
+		candidates = append(candidates, &Value{Kind: ty, Map: map[string]*Value{}})
+		for _, key := range value.Keys {
+			candidate := copyMap(value)
+			delete(candidate.Map, key)
+			candidate.Keys = nil
+			for _, k := range value.Keys {
+				if k != key {
+					candidate.Keys = append(candidate.Keys, k)
+				}
+			}
+			candidates = append(candidates, candidate)
+		}
+		for _, key := range value.Keys {
+			for _, v := range shrinkValue(value.Map[key], kind.ElementType(ty)) {
+				candidate := copyMap(value)
+				candidate.Map[key] = v
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		return candidates
+	}
+
+	switch ty {
+	case "bool":
+		if value.Bool {
+			candidates = append(candidates, NewBool(false))
+		}
+
+	case "char":
+		if value.Char != 'a' {
+			candidates = append(candidates, NewChar('a'))
+		}
+
+	case "data":
+		for _, r := range shrinkRanges(len(value.Data)) {
+			data := append([]byte{}, value.Data[:r[0]]...)
+			candidates = append(candidates, NewData(append(data, value.Data[r[1]:]...)))
+		}
+
+	case "string":
+		s := []rune(value.Text)
+		for _, r := range shrinkRanges(len(s)) {
+			runes := append([]rune{}, s[:r[0]]...)
+			candidates = append(candidates, NewString(string(append(runes, s[r[1]:]...))))
+		}
+		for i, r := range s {
+			if r != 'a' {
+				runes := append([]rune{}, s...)
+				runes[i] = 'a'
+				candidates = append(candidates, NewString(string(runes)))
+			}
+		}
+
+	case "number":
+		if number.IsZero(value.Number) {
+			return nil
+		}
+
+		candidates = append(candidates, NewInt(0))
+		i := number.Int64(value.Number)
+		if value.Number.Cmp(NewNumber(fmt.Sprintf("%d", i)).Number) != 0 {
+			candidates = append(candidates, NewNumber(fmt.Sprintf("%d", i)))
+		}
+		if i < 0 {
+			candidates = append(candidates, NewNumber(fmt.Sprintf("%d", -i)))
+		}
+		if i/2 != 0 {
+			candidates = append(candidates, NewNumber(fmt.Sprintf("%d", i/2)))
+		}
+		if i > 1 {
+			candidates = append(candidates, NewNumber(fmt.Sprintf("%d", i-1)))
+		}
+		if i < -1 {
+			candidates = append(candidates, NewNumber(fmt.Sprintf("%d", i+1)))
+		}
+	}
+
+	return candidates
+}
+
+// shrinkRanges returns the ranges that can be removed from a slice of length n
+// to make it shorter. That is, everything, each half and then each element.
+func shrinkRanges(n int) [][2]int {
+	if n == 0 {
+		return nil
+	}
+
+	ranges := [][2]int{{0, n}}
+	if n > 1 {
+		ranges = append(ranges, [2]int{n / 2, n}, [2]int{0, n / 2})
+		for i := 0; i < n; i++ {
+			ranges = append(ranges, [2]int{i, i + 1})
+		}
+	}
+
+	return ranges
+}
+
+func copyMap(value *Value) *Value {
+	m := &Value{
+		Kind: value.Kind,
+		Map:  make(map[string]*Value, len(value.Map)),
+		Keys: append([]string{}, value.Keys...),
+	}
+	for key, v := range value.Map {
+		m.Map[key] = v
+	}
+
+	return m
+}
+
+// fuzzEncode converts a value into something that can be encoded as JSON for
+// the corpus. Numbers are kept as strings so that they are exact.
+func fuzzEncode(value *Value, ty string) interface{} {
+	switch {
+	case kind.IsArray(ty):
+		elements := []interface{}{}
+		for _, element := range value.Array {
+			elements = append(elements, fuzzEncode(element, kind.ElementType(ty)))
+		}
+
+		return elements
+
+	case kind.IsMap(ty):
+		m := map[string]interface{}{}
+		for key, element := range value.Map {
+			m[key] = fuzzEncode(element, kind.ElementType(ty))
+		}
+
+		return m
+	}
+
+	switch ty {
+	case "bool":
+		return value.Bool
+
+	case "char":
+		return string(value.Char)
+
+	case "data":
+		return value.Data
+
+	case "string":
+		return value.Text
+	}
+
+	return json.Number(number.Format(value.Number, -1))
+}
+
+// fuzzDecode is the opposite of fuzzEncode.
+func fuzzDecode(v interface{}, ty string) (*Value, error) {
+	switch {
+	case kind.IsArray(ty):
+		elements, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected %s", ty)
+		}
+
+		value := &Value{Kind: ty}
+		for _, element := range elements {
+			element, err := fuzzDecode(element, kind.ElementType(ty))
+			if err != nil {
+				return nil, err
+			}
+
+			value.Array = append(value.Array, element)
+		}
+
+		return value, nil
+
+	case kind.IsMap(ty):
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected %s", ty)
+		}
+
+		value := &Value{Kind: ty, Map: map[string]*Value{}}
+		for key, element := range m {
+			element, err := fuzzDecode(element, kind.ElementType(ty))
+			if err != nil {
+				return nil, err
+			}
+
+			value.Map[key] = element
+			value.Keys = append(value.Keys, key)
+		}
+		sort.Strings(value.Keys)
+
+		return value, nil
+	}
+
+	switch ty {
+	case "bool":
+		if b, ok := v.(bool); ok {
+			return NewBool(b), nil
+		}
+
+	case "char":
+		if s, ok := v.(string); ok && len([]rune(s)) == 1 {
+			return NewChar([]rune(s)[0]), nil
+		}
+
+	case "data":
+		if s, ok := v.(string); ok {
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewData(data), nil
+		}
+
+	case "string":
+		if s, ok := v.(string); ok {
+			return NewString(s), nil
+		}
+
+	case "number":
+		if n, ok := v.(json.Number); ok {
+			return NewNumber(n.String()), nil
+		}
+	}
+
+	return nil, fmt.Errorf("expected %s", ty)
+}
+
+// fuzzCorpusDir is where the failing inputs for a test are saved. It is empty
+// if there is no corpus.
+func (vm *VM) fuzzCorpusDir(test *CompiledTest) string {
+	if vm.FuzzCorpus == "" {
+		return ""
+	}
+
+	// The test name may contain characters that are not safe for a file name.
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' ||
+			r >= '0' && r <= '9' || r == '-' || r == '.' {
+			return r
+		}
+
+		return '_'
+	}, test.TestName)
+
+	return filepath.Join(vm.FuzzCorpus, name)
+}
+
+// loadFuzzCorpus returns the path and arguments of each file that was saved by
+// saveFuzzCorpus.
+func (vm *VM) loadFuzzCorpus(test *CompiledTest) ([]string, [][]*Value, error) {
+	dir := vm.fuzzCorpusDir(test)
+	if dir == "" {
+		return nil, nil, nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var paths []string
+	var corpus [][]*Value
+	for _, file := range files {
+		path := filepath.Join(dir, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var values []interface{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+		if err == nil && len(values) != len(test.FuzzTypes) {
+			err = fmt.Errorf("expected %d arguments but found %d",
+				len(test.FuzzTypes), len(values))
+		}
+
+		arguments := make([]*Value, len(values))
+		for i := 0; err == nil && i < len(values); i++ {
+			arguments[i], err = fuzzDecode(values[i], test.FuzzTypes[i])
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", path, err)
+		}
+
+		paths = append(paths, path)
+		corpus = append(corpus, arguments)
+	}
+
+	return paths, corpus, nil
+}
+
+// saveFuzzCorpus writes the arguments to a new file in the corpus. The path of
+// the file is returned, or an empty string if there is no corpus.
+func (vm *VM) saveFuzzCorpus(test *CompiledTest, arguments []*Value) (string, error) {
+	dir := vm.fuzzCorpusDir(test)
+	if dir == "" {
+		return "", nil
+	}
+
+	var values []interface{}
+	for i, argument := range arguments {
+		values = append(values, fuzzEncode(argument, test.FuzzTypes[i]))
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%x", sha256.Sum256(data))[:16])
+
+	return path, ioutil.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,155 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_RunTests_Fuzz(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src      string
+		expected []*vm.AssertionFailure
+	}{
+		"pass": {
+			src: `fuzz "foo" (s string, n []number, m {}bool, c char, d data) {
+    assert(len(s) >= 0)
+}`,
+		},
+		"shrink-number": {
+			src: `fuzz "foo" (a number) {
+    assert(a < 10)
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "a = 10"},
+				{Message: "assert(10 < 10) failed"},
+			},
+		},
+		"shrink-string": {
+			src: `fuzz "foo" (a string, b number) {
+    assert(len(a) < 3, "too long")
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "a = \"aaa\"\nb = 0"},
+				{Message: "assert(3 < 3) failed: too long"},
+			},
+		},
+		"shrink-array": {
+			src: `fuzz "foo" (a []bool) {
+    for b in a {
+        assert(b == false)
+    }
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "a = [true]"},
+				{Message: "assert(true == false) failed"},
+			},
+		},
+		"shrink-char": {
+			src: `fuzz "foo" (a string, c char, m {}number) {
+    assert(len(a) < 1 or c != 'a')
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "a = \"a\"\nc = 'a'\nm = {}number {}"},
+				{Message: "assert(false or false) failed"},
+			},
+		},
+		"escape-char": {
+			src: `fuzz "foo" (c char) {
+    assert(c != '\n')
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "c = '\\n'"},
+				{
+					Message: "assert(left != right) failed",
+					Details: "left:  \"\n\"\nright: \"\n\"",
+				},
+			},
+		},
+		"single-quote-char": {
+			src: `fuzz "foo" (a []char) {
+    for c in a {
+        assert(c != char(39))
+    }
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "a = [char(39)]"},
+				{Message: "assert(\"'\" != \"'\") failed"},
+			},
+		},
+		"raise": {
+			src: `fuzz "foo" (a bool) {
+    if a {
+        raise Error("oops")
+    }
+}`,
+			expected: []*vm.AssertionFailure{
+				{Details: "a = true"},
+				{Message: "unhandled Error: oops", Details: "main.okt:3:9 in foo"},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ok-vm")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.okt"),
+				[]byte(test.src+"\n"), 0644))
+
+			pkg, errs := compiler.CompilePackage(dir, true)
+			require.Nil(t, errs)
+
+			corpus := filepath.Join(dir, "testdata", "fuzz")
+			run := func() []*vm.AssertionFailure {
+				m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+				m.FuzzSeed = 1
+				m.FuzzCorpus = corpus
+				require.NoError(t, m.RunTests())
+				require.Len(t, m.TestResults, 1)
+
+				failures := m.TestResults[0].Failures
+				for _, failure := range failures {
+					failure.Pos = ""
+					failure.Details = strings.Replace(failure.Details,
+						dir+string(filepath.Separator), "", -1)
+				}
+
+				return failures
+			}
+
+			failures := run()
+			if test.expected == nil {
+				assert.Nil(t, failures)
+				assert.NoDirExists(t, corpus)
+
+				return
+			}
+
+			// The first failure describes the input. Only the details are
+			// checked because the message contains the path in the corpus.
+			require.Len(t, failures, len(test.expected))
+			assert.Contains(t, failures[0].Message, "with seed 1, saved to ")
+			failures[0].Message = ""
+			assert.Equal(t, test.expected, failures)
+
+			// The failing input is run first next time.
+			files, err := ioutil.ReadDir(filepath.Join(corpus, "foo"))
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+
+			failures = run()
+			path := filepath.Join(corpus, "foo", files[0].Name())
+			assert.Equal(t, "fuzz failed for "+path, failures[0].Message)
+			failures[0].Message = ""
+			assert.Equal(t, test.expected, failures)
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/elliotchance/ok/compiler/kind"
-	"github.com/elliotchance/ok/number"
 	"github.com/elliotchance/ok/util"
 )
 
@@ -26,7 +25,7 @@ func (ins *Get) Execute(_ *int, vm *VM) error {
 			return nil
 		}
 
-		index := number.Int(number.NewNumber(prop.Value))
+		index := prop.Int()
 		if index < 0 || index >= len(object.Array) {
 			vm.Raise(fmt.Sprintf("index out of bounds [%d] with length %d",
 				index, len(object.Array)))
@@ -45,13 +44,13 @@ func (ins *Get) Execute(_ *int, vm *VM) error {
 			return nil
 		}
 
-		if v, ok := object.Map[prop.Value]; ok {
+		if v, ok := object.Map[prop.Text]; ok {
 			vm.Set(ins.Result, v)
 
 			return nil
 		}
 
-		vm.Raise(fmt.Sprintf("no such key in map: %s", prop.Value))
+		vm.Raise(fmt.Sprintf("no such key in map: %s", prop.Text))
 
 		return nil
 
@@ -62,19 +61,19 @@ func (ins *Get) Execute(_ *int, vm *VM) error {
 			return nil
 		}
 
-		if !util.IsPublic(prop.Value) {
-			vm.Raise("cannot access private property: " + prop.Value)
+		if !util.IsPublic(prop.Text) {
+			vm.Raise("cannot access private property: " + prop.Text)
 
 			return nil
 		}
 
-		if v, ok := object.Map[prop.Value]; ok {
+		if v, ok := object.Map[prop.Text]; ok {
 			vm.Set(ins.Result, v)
 
 			return nil
 		}
 
-		vm.Raise(fmt.Sprintf("no such property in object: %s", prop.Value))
+		vm.Raise(fmt.Sprintf("no such property in object: %s", prop.Text))
 
 		return nil
 	}
@@ -3,7 +3,6 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 )
 
@@ -14,9 +13,9 @@ type GreaterThanEqualNumber struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *GreaterThanEqualNumber) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralBool(number.Cmp(
-		number.NewNumber(vm.Get(ins.Left).Value),
-		number.NewNumber(vm.Get(ins.Right).Value),
+	vm.Set(ins.Result, NewBool(number.Cmp(
+		vm.Get(ins.Left).Number,
+		vm.Get(ins.Right).Number,
 	) >= 0))
 
 	return nil
@@ -34,8 +33,8 @@ type GreaterThanEqualString struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *GreaterThanEqualString) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralBool(
-		vm.Get(ins.Left).Value >= vm.Get(ins.Right).Value,
+	vm.Set(ins.Result, NewBool(
+		vm.Get(ins.Left).Text >= vm.Get(ins.Right).Text,
 	))
 
 	return nil
@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -12,66 +10,69 @@ import (
 
 func TestGreaterThanString_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		left, right *ast.Literal
+		left, right *vm.Value
 		expected    string
 	}{
 		"foo-foo": {
-			asttest.NewLiteralString("foo"), asttest.NewLiteralString("foo"),
+			vm.NewString("foo"), vm.NewString("foo"),
 			"false"},
 		"foo-bar": {
-			asttest.NewLiteralString("foo"), asttest.NewLiteralString("bar"),
+			vm.NewString("foo"), vm.NewString("bar"),
 			"true",
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.left,
-				"1": test.right,
+			registers := []*vm.Value{
+				test.left,
+				test.right,
+				nil,
 			}
-			ins := &vm.GreaterThanString{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.GreaterThanString{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestGreaterThanNumber_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		left, right *ast.Literal
+		left, right *vm.Value
 		expected    string
 	}{
 		"1-1.0": {
-			asttest.NewLiteralNumber("1"), asttest.NewLiteralNumber("1.0"),
+			vm.NewNumber("1"), vm.NewNumber("1.0"),
 			"false"},
 		"1-1.1": {
-			asttest.NewLiteralNumber("1"), asttest.NewLiteralNumber("1.1"),
+			vm.NewNumber("1"), vm.NewNumber("1.1"),
 			"false",
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.left,
-				"1": test.right,
+			registers := []*vm.Value{
+				test.left,
+				test.right,
+				nil,
+				nil,
 			}
-			ins := &vm.GreaterThanNumber{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.GreaterThanNumber{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestGreaterThanString_String(t *testing.T) {
-	ins := &vm.GreaterThanString{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.GreaterThanString{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 > $2", ins.String())
 }
 
 func TestGreaterThanNumber_String(t *testing.T) {
-	ins := &vm.GreaterThanNumber{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.GreaterThanNumber{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 > $2", ins.String())
 }
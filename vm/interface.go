@@ -3,7 +3,6 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/util"
 )
 
@@ -15,7 +14,7 @@ type Interface struct {
 // Execute implements the Instruction interface for the VM.
 func (ins *Interface) Execute(_ *int, vm *VM) error {
 	i := util.Interface(vm.Interfaces[vm.Get(ins.Value).Kind])
-	vm.Set(ins.Result, asttest.NewLiteralString(i))
+	vm.Set(ins.Result, NewString(i))
 
 	return nil
 }
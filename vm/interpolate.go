@@ -3,9 +3,8 @@ package vm
 import (
 	"fmt"
 	"sort"
+	"strconv"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler/kind"
 	"github.com/elliotchance/ok/number"
 	"github.com/elliotchance/ok/util"
@@ -22,10 +21,10 @@ func (ins *Interpolate) Execute(_ *int, vm *VM) error {
 	s := ""
 
 	for _, arg := range ins.Args {
-		s += renderLiteral(vm.Get(arg), false)
+		s += renderValue(vm.Get(arg), false)
 	}
 
-	vm.Set(ins.Result, asttest.NewLiteralString(s))
+	vm.Set(ins.Result, NewString(s))
 
 	return nil
 }
@@ -35,9 +34,15 @@ func (ins *Interpolate) String() string {
 	return fmt.Sprintf("%s = interpolate %s", ins.Result, ins.Args)
 }
 
-func renderLiteral(v *ast.Literal, asJSON bool) string {
+// RenderValue returns the value in the same format as print. If asJSON is
+// true, strings will be quoted as they are when inside an array or map.
+func RenderValue(v *Value, asJSON bool) string {
+	return renderValue(v, asJSON)
+}
+
+func renderValue(v *Value, asJSON bool) string {
 	if kind.IsFunc(v.Kind) {
-		return v.String()
+		return "func " + v.Text
 	}
 
 	if kind.IsArray(v.Kind) {
@@ -47,7 +52,7 @@ func renderLiteral(v *ast.Literal, asJSON bool) string {
 				s += ", "
 			}
 
-			s += renderLiteral(element, true)
+			s += renderValue(element, true)
 		}
 
 		return s + "]"
@@ -56,18 +61,27 @@ func renderLiteral(v *ast.Literal, asJSON bool) string {
 	// Literals.
 	switch v.Kind {
 	case "char", "string", "data":
+		s := v.Text
+		switch v.Kind {
+		case "char":
+			s = string(v.Char)
+
+		case "data":
+			s = string(v.Data)
+		}
+
 		if asJSON {
 			// TODO(elliot): This is not escaped correctly.
-			return fmt.Sprintf(`"%s"`, v.Value)
+			return fmt.Sprintf(`"%s"`, s)
 		}
 
-		return v.Value
+		return s
 
 	case "number":
-		return number.Format(number.NewNumber(v.Value), -1)
+		return number.Format(v.Number, -1)
 
 	case "bool":
-		return v.Value
+		return strconv.FormatBool(v.Bool)
 	}
 
 	// Maps or objects are handled the same way. We can recognise maps with:
@@ -104,7 +118,7 @@ func renderLiteral(v *ast.Literal, asJSON bool) string {
 		}
 
 		s += fmt.Sprintf(`"%s": `, key)
-		s += renderLiteral(element, true)
+		s += renderValue(element, true)
 		j++
 	}
 
@@ -12,7 +12,7 @@ type JumpUnless struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *JumpUnless) Execute(i *int, vm *VM) error {
-	if vm.Get(ins.Condition).Value != "true" {
+	if !vm.Get(ins.Condition).Bool {
 		*i = ins.To
 	}
 
@@ -2,8 +2,8 @@ package vm
 
 import (
 	"fmt"
+	"unicode/utf8"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler/kind"
 )
 
@@ -24,13 +24,13 @@ func (ins *Len) Execute(_ *int, vm *VM) error {
 		result = len(r.Map)
 
 	case r.Kind == "string":
-		result = len([]rune(r.Value))
+		result = utf8.RuneCountInString(r.Text)
 
 	default:
-		result = len(r.Value)
+		result = len(r.Data)
 	}
 
-	vm.Set(ins.Result, asttest.NewLiteralNumber(fmt.Sprintf("%d", result)))
+	vm.Set(ins.Result, NewInt(result))
 
 	return nil
 }
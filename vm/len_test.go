@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -12,22 +10,23 @@ import (
 
 func TestLen_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		x        *ast.Literal
-		expected *ast.Literal
+		x        *vm.Value
+		expected *vm.Value
 	}{
 		// TODO(elliot): Tests for array and map.
 		"string": {
-			asttest.NewLiteralString("foo bar"),
-			asttest.NewLiteralNumber("7"),
+			vm.NewString("foo bar"),
+			vm.NewNumber("7"),
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.x,
+			registers := []*vm.Value{
+				test.x,
+				nil,
 			}
-			ins := &vm.Len{Argument: "0", Result: "1"}
+			ins := &vm.Len{Argument: 0, Result: 1}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
 			assert.Equal(t, test.expected, registers[ins.Result])
@@ -1,1308 +1,417 @@
 package vm
 
-import "github.com/elliotchance/ok/ast"
-
 func init() {
 	Packages = map[string]bool{
+		"fs":      true,
+		"io":      true,
 		"math":    true,
+		"os":      true,
 		"reflect": true,
 		"strings": true,
 	}
-	Lib = map[string]*InternalDefinition{
-		"Error": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"Error"},
-				Instructions: []Instruction{
-					&Return{Registers{"0"}},
-				},
-				Registers: 1,
-				Variables: map[string]string{
-					"Error": "string",
-				},
-				Interfaces: map[string]map[string]string{
-					"Error": map[string]string{
-						"Error": "string",
-					},
-				},
-			},
-			FuncDef: &ast.Func{
-				Name: "Error",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"Error", "string"},
-				},
-				Returns: []string{"Error"},
-				Pos:     "lib/lang/error.ok:2:1",
-			},
-		},
-		"math.Abs": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"number", "0", nil, nil, "lib/math/abs.ok:3:12"}, ""},
-					&LessThanNumber{"x", "2", "3"},
-					&JumpUnless{"3", 5},
-					&Assign{"4", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&Subtract{"4", "x", "5"},
-					&Return{Registers{"5"}},
-					&Return{Registers{"x"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"x": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Abs",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/abs.ok:2:1",
-			},
-		},
-		"math.Cbrt": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"number", "1", nil, nil, "lib/math/powers.ok:21:21"}, ""},
-					&Assign{"3", &ast.Literal{"number", "3", nil, nil, "lib/math/powers.ok:21:23"}, ""},
-					&Divide{"2", "3", "4"},
-					&Power{"x", "4", "5"},
-					&Return{Registers{"5"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"x": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Cbrt",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/powers.ok:20:1",
-			},
-		},
-		"math.Ceil": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"number", "1", nil, nil, "lib/math/rounding.ok:3:16"}, ""},
-					&Remainder{"x", "2", "3"},
-					&Assign{"frac", nil, "3"},
-					&Assign{"4", &ast.Literal{"number", "0", nil, nil, "lib/math/rounding.ok:4:16"}, ""},
-					&EqualNumber{"frac", "4", "5"},
-					&JumpUnless{"5", 6},
-					&Return{Registers{"x"}},
-					&Assign{"6", &ast.Literal{"number", "0", nil, nil, "lib/math/rounding.ok:8:12"}, ""},
-					&LessThanNumber{"x", "6", "7"},
-					&JumpUnless{"7", 11},
-					&Subtract{"x", "frac", "8"},
-					&Return{Registers{"8"}},
-					&Assign{"9", &ast.Literal{"number", "1", nil, nil, "lib/math/rounding.ok:12:17"}, ""},
-					&Subtract{"9", "frac", "10"},
-					&Add{"x", "10", "11"},
-					&Return{Registers{"11"}},
-				},
-				Registers: 11,
-				Variables: map[string]string{
-					"frac": "number",
-					"x":    "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Ceil",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/rounding.ok:2:1",
-			},
-		},
-		"math.Exp": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"number", "2.71828182845904523536028747135266249775724709369995", nil, nil, "lib/math/powers.ok:4:9"}, ""},
-					&Assign{"e", nil, "2"},
-					&Power{"e", "x", "3"},
-					&Return{Registers{"3"}},
-				},
-				Registers: 3,
-				Variables: map[string]string{
-					"e": "number",
-					"x": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Exp",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/powers.ok:2:1",
-			},
-		},
-		"math.Floor": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"number", "1", nil, nil, "lib/math/rounding.ok:17:16"}, ""},
-					&Remainder{"x", "2", "3"},
-					&Assign{"frac", nil, "3"},
-					&Assign{"4", &ast.Literal{"number", "0", nil, nil, "lib/math/rounding.ok:18:16"}, ""},
-					&EqualNumber{"frac", "4", "5"},
-					&JumpUnless{"5", 6},
-					&Return{Registers{"x"}},
-					&Assign{"6", &ast.Literal{"number", "0", nil, nil, "lib/math/rounding.ok:22:12"}, ""},
-					&LessThanNumber{"x", "6", "7"},
-					&JumpUnless{"7", 13},
-					&Assign{"8", &ast.Literal{"number", "1", nil, nil, "lib/math/rounding.ok:23:27"}, ""},
-					&Add{"frac", "8", "9"},
-					&Subtract{"x", "9", "10"},
-					&Return{Registers{"10"}},
-					&Subtract{"x", "frac", "11"},
-					&Return{Registers{"11"}},
-				},
-				Registers: 11,
-				Variables: map[string]string{
-					"frac": "number",
-					"x":    "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Floor",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/rounding.ok:16:1",
-			},
-		},
-		"math.Log10": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Log{"x", "2"},
-					&Assign{"3", &ast.Literal{"number", "10", nil, nil, "lib/math/log.ok:8:29"}, ""},
-					&Log{"3", "4"},
-					&Divide{"2", "4", "5"},
-					&Return{Registers{"5"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"x": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Log10",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/log.ok:7:1",
-			},
-		},
-		"math.LogE": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Log{"x", "2"},
-					&Return{Registers{"2"}},
-				},
-				Registers: 2,
-				Variables: map[string]string{
-					"x": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "LogE",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/log.ok:2:1",
-			},
-		},
-		"math.Pow": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"base", "power"},
-				Instructions: []Instruction{
-					&Power{"base", "power", "3"},
-					&Return{Registers{"3"}},
-				},
-				Registers: 3,
-				Variables: map[string]string{
-					"base":  "number",
-					"power": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Pow",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"base", "number"},
-					&ast.Argument{"power", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/powers.ok:10:1",
-			},
-		},
-		"math.Round": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x", "prec"},
-				Instructions: []Instruction{
-					&Assign{"3", &ast.Literal{"number", "10", nil, nil, "lib/math/rounding.ok:32:15"}, ""},
-					&Power{"3", "prec", "4"},
-					&Assign{"p", nil, "4"},
-					&Multiply{"x", "p", "5"},
-					&Assign{"y", nil, "5"},
-					&Assign{"6", &ast.Literal{"number", "1", nil, nil, "lib/math/rounding.ok:35:16"}, ""},
-					&Remainder{"y", "6", "7"},
-					&Assign{"diff", nil, "7"},
-					&Assign{"8", &ast.Literal{"number", "0.5", nil, nil, "lib/math/rounding.ok:36:16"}, ""},
-					&GreaterThanEqualNumber{"diff", "8", "9"},
-					&JumpUnless{"9", 15},
-					&Assign{"10", &ast.Literal{"number", "1", nil, nil, "lib/math/rounding.ok:37:22"}, ""},
-					&Subtract{"10", "diff", "11"},
-					&Add{"y", "11", "12"},
-					&Divide{"12", "p", "13"},
-					&Return{Registers{"13"}},
-					&Subtract{"y", "diff", "14"},
-					&Divide{"14", "p", "15"},
-					&Return{Registers{"15"}},
-				},
-				Registers: 15,
-				Variables: map[string]string{
-					"diff": "number",
-					"p":    "number",
-					"prec": "number",
-					"x":    "number",
-					"y":    "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Round",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-					&ast.Argument{"prec", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/rounding.ok:31:1",
-			},
-		},
-		"math.Sqrt": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"x"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"number", "0.5", nil, nil, "lib/math/powers.ok:16:21"}, ""},
-					&Power{"x", "2", "3"},
-					&Return{Registers{"3"}},
-				},
-				Registers: 3,
-				Variables: map[string]string{
-					"x": "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Sqrt",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"x", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/math/powers.ok:15:1",
-			},
-		},
-		"reflect.Call": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"fn", "args"},
-				Instructions: []Instruction{
-					&DynamicCall{"fn", "args", "3"},
-					&Return{Registers{"3"}},
-				},
-				Registers: 3,
-				Variables: map[string]string{
-					"args": "[]any",
-					"fn":   "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Call",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"fn", "any"},
-					&ast.Argument{"args", "[]any"},
-				},
-				Returns: []string{"[]any"},
-				Pos:     "lib/reflect/call.ok:16:1",
-			},
-		},
-		"reflect.Get": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"obj", "prop"},
-				Instructions: []Instruction{
-					&Get{"obj", "prop", "3"},
-					&Return{Registers{"3"}},
-				},
-				Registers: 3,
-				Variables: map[string]string{
-					"obj":  "any",
-					"prop": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Get",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"obj", "any"},
-					&ast.Argument{"prop", "any"},
-				},
-				Returns: []string{"any"},
-				Pos:     "lib/reflect/get.ok:15:1",
-			},
-		},
-		"reflect.Interface": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"value"},
-				Instructions: []Instruction{
-					&Interface{"value", "2"},
-					&Return{Registers{"2"}},
-				},
-				Registers: 2,
-				Variables: map[string]string{
-					"value": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Interface",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"value", "any"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/reflect/interface.ok:10:1",
-			},
-		},
-		"reflect.Kind": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"value"},
-				Instructions: []Instruction{
-					&Call{"Type", Registers{"value"}, Registers{"2"}},
-					&Assign{"type", nil, "2"},
-					&Assign{"3", &ast.Literal{"string", "[]", nil, nil, "lib/reflect/kind.ok:7:30"}, ""},
-					&Call{"hasPrefix", Registers{"type", "3"}, Registers{"4"}},
-					&JumpUnless{"4", 7},
-					&Assign{"5", &ast.Literal{"string", "array", nil, nil, "lib/reflect/kind.ok:8:20"}, ""},
-					&Return{Registers{"5"}},
-					&Jump{20},
-					&Assign{"7", &ast.Literal{"string", "{}", nil, nil, "lib/reflect/kind.ok:11:31"}, ""},
-					&Interpolate{"6", Registers{"7"}},
-					&Call{"hasPrefix", Registers{"type", "6"}, Registers{"8"}},
-					&JumpUnless{"8", 14},
-					&Assign{"9", &ast.Literal{"string", "map", nil, nil, "lib/reflect/kind.ok:12:20"}, ""},
-					&Return{Registers{"9"}},
-					&Jump{20},
-					&Assign{"10", &ast.Literal{"string", "func(", nil, nil, "lib/reflect/kind.ok:15:30"}, ""},
-					&Call{"hasPrefix", Registers{"type", "10"}, Registers{"11"}},
-					&JumpUnless{"11", 20},
-					&Assign{"12", &ast.Literal{"string", "func", nil, nil, "lib/reflect/kind.ok:16:20"}, ""},
-					&Return{Registers{"12"}},
-					&Jump{20},
-					&Return{Registers{"type"}},
-				},
-				Registers: 12,
-				Variables: map[string]string{
-					"type":  "string",
-					"value": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Kind",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"value", "any"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/reflect/kind.ok:3:1",
-			},
-		},
-		"reflect.Len": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"value"},
-				Instructions: []Instruction{
-					&Len{"value", "2"},
-					&Return{Registers{"2"}},
-				},
-				Registers: 2,
-				Variables: map[string]string{
-					"value": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Len",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"value", "any"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/reflect/len.ok:3:1",
-			},
-		},
-		"reflect.Properties": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"obj"},
-				Instructions: []Instruction{
-					&Props{"obj", "2"},
-					&Return{Registers{"2"}},
-				},
-				Registers: 2,
-				Variables: map[string]string{
-					"obj": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Properties",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"obj", "any"},
-				},
-				Returns: []string{"[]string"},
-				Pos:     "lib/reflect/props.ok:3:1",
-			},
-		},
-		"reflect.Set": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"obj", "prop", "value"},
-				Instructions: []Instruction{
-					&Set{"obj", "prop", "value", "4"},
-					&Return{Registers{"4"}},
-				},
-				Registers: 4,
-				Variables: map[string]string{
-					"obj":   "any",
-					"prop":  "any",
-					"value": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Set",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"obj", "any"},
-					&ast.Argument{"prop", "any"},
-					&ast.Argument{"value", "any"},
-				},
-				Returns: []string{"any"},
-				Pos:     "lib/reflect/set.ok:16:1",
-			},
-		},
-		"reflect.Type": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"value"},
-				Instructions: []Instruction{
-					&Type{"value", "2"},
-					&Return{Registers{"2"}},
-				},
-				Registers: 2,
-				Variables: map[string]string{
-					"value": "any",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Type",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"value", "any"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/reflect/type.ok:8:1",
-			},
-		},
-		"strings.Contains": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "substr"},
-				Instructions: []Instruction{
-					&Call{"Index", Registers{"s", "substr"}, Registers{"3"}},
-					&Assign{"4", &ast.Literal{"number", "-1", nil, nil, "lib/strings/contains.ok:3:32"}, ""},
-					&NotEqualNumber{"3", "4", "5"},
-					&Return{Registers{"5"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"s":      "string",
-					"substr": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Contains",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"substr", "string"},
-				},
-				Returns: []string{"bool"},
-				Pos:     "lib/strings/contains.ok:2:1",
-			},
-		},
-		"strings.HasPrefix": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "prefix"},
-				Instructions: []Instruction{
-					&Len{"s", "3"},
-					&Len{"prefix", "4"},
-					&LessThanNumber{"3", "4", "5"},
-					&JumpUnless{"5", 5},
-					&Assign{"6", &ast.Literal{"bool", "false", nil, nil, "lib/strings/contains.ok:9:16"}, ""},
-					&Return{Registers{"6"}},
-					&Assign{"7", &ast.Literal{"number", "0", nil, nil, "lib/strings/contains.ok:12:13"}, ""},
-					&Assign{"i", nil, "7"},
-					&Len{"prefix", "8"},
-					&LessThanNumber{"i", "8", "9"},
-					&JumpUnless{"9", 19},
-					&StringIndex{"s", "i", "10"},
-					&StringIndex{"prefix", "i", "11"},
-					&NotEqual{"10", "11", "12"},
-					&JumpUnless{"12", 16},
-					&Assign{"13", &ast.Literal{"bool", "false", nil, nil, "lib/strings/contains.ok:14:20"}, ""},
-					&Return{Registers{"13"}},
-					&Assign{"14", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"i", "14", "i"},
-					&Jump{8},
-					&Assign{"15", &ast.Literal{"bool", "true", nil, nil, "lib/strings/contains.ok:18:12"}, ""},
-					&Return{Registers{"15"}},
-				},
-				Registers: 15,
-				Variables: map[string]string{
-					"i":      "number",
-					"prefix": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "HasPrefix",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"prefix", "string"},
-				},
-				Returns: []string{"bool"},
-				Pos:     "lib/strings/contains.ok:7:1",
-			},
-		},
-		"strings.HasSuffix": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "suffix"},
-				Instructions: []Instruction{
-					&Len{"s", "3"},
-					&Len{"suffix", "4"},
-					&LessThanNumber{"3", "4", "5"},
-					&JumpUnless{"5", 5},
-					&Assign{"6", &ast.Literal{"bool", "false", nil, nil, "lib/strings/contains.ok:24:16"}, ""},
-					&Return{Registers{"6"}},
-					&Len{"s", "7"},
-					&Assign{"8", &ast.Literal{"number", "1", nil, nil, "lib/strings/contains.ok:27:18"}, ""},
-					&Subtract{"7", "8", "9"},
-					&Assign{"j", nil, "9"},
-					&Len{"suffix", "10"},
-					&Assign{"11", &ast.Literal{"number", "1", nil, nil, "lib/strings/contains.ok:28:27"}, ""},
-					&Subtract{"10", "11", "12"},
-					&Assign{"i", nil, "12"},
-					&Assign{"13", &ast.Literal{"number", "0", nil, nil, "lib/strings/contains.ok:28:35"}, ""},
-					&GreaterThanEqualNumber{"i", "13", "14"},
-					&JumpUnless{"14", 27},
-					&StringIndex{"s", "j", "15"},
-					&StringIndex{"suffix", "i", "16"},
-					&NotEqual{"15", "16", "17"},
-					&JumpUnless{"17", 22},
-					&Assign{"18", &ast.Literal{"bool", "false", nil, nil, "lib/strings/contains.ok:30:20"}, ""},
-					&Return{Registers{"18"}},
-					&Assign{"19", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Subtract{"j", "19", "j"},
-					&Assign{"20", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Subtract{"i", "20", "i"},
-					&Jump{14},
-					&Assign{"21", &ast.Literal{"bool", "true", nil, nil, "lib/strings/contains.ok:36:12"}, ""},
-					&Return{Registers{"21"}},
-				},
-				Registers: 21,
-				Variables: map[string]string{
-					"i":      "number",
-					"j":      "number",
-					"s":      "string",
-					"suffix": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "HasSuffix",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"suffix", "string"},
-				},
-				Returns: []string{"bool"},
-				Pos:     "lib/strings/contains.ok:22:1",
-			},
-		},
-		"strings.Index": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "substr"},
-				Instructions: []Instruction{
-					&Assign{"3", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:3:34"}, ""},
-					&Call{"IndexAfter", Registers{"s", "substr", "3"}, Registers{"4"}},
-					&Return{Registers{"4"}},
-				},
-				Registers: 4,
-				Variables: map[string]string{
-					"s":      "string",
-					"substr": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Index",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"substr", "string"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/strings/index.ok:2:1",
-			},
-		},
-		"strings.IndexAfter": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "substr", "offset"},
-				Instructions: []Instruction{
-					&Assign{"4", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:18:26"}, ""},
-					&Call{"max", Registers{"offset", "4"}, Registers{"5"}},
-					&Assign{"offset", nil, "5"},
-					&Assign{"6", &ast.Literal{"number", "1", nil, nil, "lib/strings/index.ok:20:22"}, ""},
-					&Add{"offset", "6", "7"},
-					&Assign{"i", nil, "7"},
-					&Len{"s", "8"},
-					&Len{"substr", "9"},
-					&Subtract{"8", "9", "10"},
-					&LessThanEqualNumber{"i", "10", "11"},
-					&JumpUnless{"11", 33},
-					&Assign{"12", &ast.Literal{"bool", "true", nil, nil, "lib/strings/index.ok:21:17"}, ""},
-					&Assign{"found", nil, "12"},
-					&Assign{"13", &ast.Literal{"number", "0", nil, nil, "lib/strings/index.ok:23:17"}, ""},
-					&Assign{"j", nil, "13"},
-					&Len{"substr", "14"},
-					&LessThanNumber{"j", "14", "15"},
-					&JumpUnless{"15", 28},
-					&Add{"i", "j", "16"},
-					&StringIndex{"s", "16", "17"},
-					&StringIndex{"substr", "j", "18"},
-					&NotEqual{"17", "18", "19"},
-					&JumpUnless{"19", 25},
-					&Assign{"20", &ast.Literal{"bool", "false", nil, nil, "lib/strings/index.ok:25:25"}, ""},
-					&Assign{"found", nil, "20"},
-					&Jump{28},
-					&Assign{"21", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"j", "21", "j"},
-					&Jump{15},
-					&JumpUnless{"found", 30},
-					&Return{Registers{"i"}},
-					&Assign{"22", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"i", "22", "i"},
-					&Jump{8},
-					&Assign{"23", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:35:12"}, ""},
-					&Return{Registers{"23"}},
-				},
-				Registers: 23,
-				Variables: map[string]string{
-					"found":  "bool",
-					"i":      "number",
-					"j":      "number",
-					"offset": "number",
-					"s":      "string",
-					"substr": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "IndexAfter",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"substr", "string"},
-					&ast.Argument{"offset", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/strings/index.ok:17:1",
-			},
-		},
-		"strings.Join": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"strings", "glue"},
-				Instructions: []Instruction{
-					&Assign{"3", &ast.Literal{"string", "", nil, nil, "lib/strings/join.ok:5:14"}, ""},
-					&Assign{"result", nil, "3"},
-					&Assign{"4", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&NextArray{"strings", "4", "i", "s", "5"},
-					&JumpUnless{"5", 10},
-					&Assign{"6", &ast.Literal{"number", "0", nil, nil, "lib/strings/join.ok:7:16"}, ""},
-					&GreaterThanNumber{"i", "6", "7"},
-					&JumpUnless{"7", 8},
-					&Concat{"result", "glue", "result"},
-					&Concat{"result", "s", "result"},
-					&Jump{2},
-					&Return{Registers{"result"}},
-				},
-				Registers: 7,
-				Variables: map[string]string{
-					"glue":    "string",
-					"i":       "number",
-					"result":  "string",
-					"s":       "string",
-					"strings": "[]string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Join",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"strings", "[]string"},
-					&ast.Argument{"glue", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/join.ok:4:1",
-			},
-		},
-		"strings.LastIndex": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "substr"},
-				Instructions: []Instruction{
-					&Call{"Reverse", Registers{"s"}, Registers{"3"}},
-					&Call{"Reverse", Registers{"substr"}, Registers{"4"}},
-					&Call{"Index", Registers{"3", "4"}, Registers{"5"}},
-					&Assign{"index", nil, "5"},
-					&Assign{"6", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:59:17"}, ""},
-					&EqualNumber{"index", "6", "7"},
-					&JumpUnless{"7", 8},
-					&Assign{"8", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:60:16"}, ""},
-					&Return{Registers{"8"}},
-					&Len{"s", "9"},
-					&Len{"substr", "10"},
-					&Add{"index", "10", "11"},
-					&Subtract{"9", "11", "12"},
-					&Return{Registers{"12"}},
-				},
-				Registers: 12,
-				Variables: map[string]string{
-					"index":  "number",
-					"s":      "string",
-					"substr": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "LastIndex",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"substr", "string"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/strings/index.ok:57:1",
-			},
-		},
-		"strings.LastIndexBefore": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "substr", "offset"},
-				Instructions: []Instruction{
-					&Len{"s", "4"},
-					&Len{"s", "5"},
-					&Call{"min", Registers{"offset", "5"}, Registers{"6"}},
-					&Assign{"7", &ast.Literal{"number", "1", nil, nil, "lib/strings/index.ok:79:45"}, ""},
-					&Add{"6", "7", "8"},
-					&Subtract{"4", "8", "9"},
-					&Assign{"offset", nil, "9"},
-					&Call{"Reverse", Registers{"s"}, Registers{"10"}},
-					&Call{"Reverse", Registers{"substr"}, Registers{"11"}},
-					&Call{"IndexAfter", Registers{"10", "11", "offset"}, Registers{"12"}},
-					&Assign{"index", nil, "12"},
-					&Assign{"13", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:82:17"}, ""},
-					&EqualNumber{"index", "13", "14"},
-					&JumpUnless{"14", 15},
-					&Assign{"15", &ast.Literal{"number", "-1", nil, nil, "lib/strings/index.ok:83:16"}, ""},
-					&Return{Registers{"15"}},
-					&Len{"s", "16"},
-					&Len{"substr", "17"},
-					&Add{"index", "17", "18"},
-					&Subtract{"16", "18", "19"},
-					&Return{Registers{"19"}},
-				},
-				Registers: 19,
-				Variables: map[string]string{
-					"index":  "number",
-					"offset": "number",
-					"s":      "string",
-					"substr": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "LastIndexBefore",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"substr", "string"},
-					&ast.Argument{"offset", "number"},
-				},
-				Returns: []string{"number"},
-				Pos:     "lib/strings/index.ok:76:1",
-			},
-		},
-		"strings.Repeat": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"str", "times"},
-				Instructions: []Instruction{
-					&Assign{"3", &ast.Literal{"string", "", nil, nil, "lib/strings/repeat.ok:4:14"}, ""},
-					&Assign{"result", nil, "3"},
-					&Assign{"4", &ast.Literal{"number", "0", nil, nil, "lib/strings/repeat.ok:5:13"}, ""},
-					&Assign{"i", nil, "4"},
-					&LessThanNumber{"i", "times", "5"},
-					&JumpUnless{"5", 9},
-					&Concat{"result", "str", "result"},
-					&Assign{"6", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"i", "6", "i"},
-					&Jump{3},
-					&Return{Registers{"result"}},
-				},
-				Registers: 6,
-				Variables: map[string]string{
-					"i":      "number",
-					"result": "string",
-					"str":    "string",
-					"times":  "number",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Repeat",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"str", "string"},
-					&ast.Argument{"times", "number"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/repeat.ok:3:1",
-			},
-		},
-		"strings.ReplaceAll": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "find", "replace"},
-				Instructions: []Instruction{
-					&Call{"Split", Registers{"s", "find"}, Registers{"4"}},
-					&Call{"Join", Registers{"4", "replace"}, Registers{"5"}},
-					&Return{Registers{"5"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"find":    "string",
-					"replace": "string",
-					"s":       "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "ReplaceAll",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"find", "string"},
-					&ast.Argument{"replace", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/replace.ok:5:1",
-			},
-		},
-		"strings.Reverse": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"string", "", nil, nil, "lib/strings/reverse.ok:3:14"}, ""},
-					&Assign{"result", nil, "2"},
-					&Len{"s", "3"},
-					&Assign{"4", &ast.Literal{"number", "1", nil, nil, "lib/strings/reverse.ok:4:22"}, ""},
-					&Subtract{"3", "4", "5"},
-					&Assign{"i", nil, "5"},
-					&Assign{"6", &ast.Literal{"number", "0", nil, nil, "lib/strings/reverse.ok:4:30"}, ""},
-					&GreaterThanEqualNumber{"i", "6", "7"},
-					&JumpUnless{"7", 14},
-					&StringIndex{"s", "i", "8"},
-					&CastString{"8", "9"},
-					&Concat{"result", "9", "result"},
-					&Assign{"10", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Subtract{"i", "10", "i"},
-					&Jump{6},
-					&Return{Registers{"result"}},
-				},
-				Registers: 10,
-				Variables: map[string]string{
-					"i":      "number",
-					"result": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Reverse",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/reverse.ok:2:1",
-			},
-		},
-		"strings.Split": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "delimiter"},
-				Instructions: []Instruction{
-					&Assign{"3", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&ArrayAlloc{"3", "4", "[]string"},
-					&Assign{"elements", nil, "4"},
-					&Assign{"5", &ast.Literal{"string", "", nil, nil, "lib/strings/split.ok:10:21"}, ""},
-					&Equal{"delimiter", "5", "6"},
-					&JumpUnless{"6", 21},
-					&Assign{"7", &ast.Literal{"number", "0", nil, nil, "lib/strings/split.ok:13:17"}, ""},
-					&Assign{"i", nil, "7"},
-					&Len{"s", "8"},
-					&LessThanNumber{"i", "8", "9"},
-					&JumpUnless{"9", 20},
-					&Assign{"10", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&ArrayAlloc{"10", "11", "[]string"},
-					&Assign{"12", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&StringIndex{"s", "i", "13"},
-					&CastString{"13", "14"},
-					&ArraySet{"11", "12", "14"},
-					&Append{"elements", "11", "elements"},
-					&Assign{"15", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"i", "15", "i"},
-					&Jump{8},
-					&Jump{58},
-					&Assign{"16", &ast.Literal{"string", "", nil, nil, "lib/strings/split.ok:17:19"}, ""},
-					&Assign{"element", nil, "16"},
-					&Assign{"17", &ast.Literal{"number", "0", nil, nil, "lib/strings/split.ok:18:17"}, ""},
-					&Assign{"i", nil, "17"},
-					&Len{"s", "18"},
-					&LessThanNumber{"i", "18", "19"},
-					&JumpUnless{"19", 53},
-					&Assign{"20", &ast.Literal{"number", "1", nil, nil, "lib/strings/split.ok:19:45"}, ""},
-					&Subtract{"i", "20", "21"},
-					&Call{"IndexAfter", Registers{"s", "delimiter", "21"}, Registers{"22"}},
-					&Subtract{"22", "i", "23"},
-					&Assign{"24", &ast.Literal{"number", "0", nil, nil, "lib/strings/split.ok:19:55"}, ""},
-					&EqualNumber{"23", "24", "25"},
-					&JumpUnless{"25", 47},
-					&Assign{"26", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&ArrayAlloc{"26", "27", "[]string"},
-					&Assign{"28", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&ArraySet{"27", "28", "element"},
-					&Append{"elements", "27", "elements"},
-					&Assign{"29", &ast.Literal{"string", "", nil, nil, "lib/strings/split.ok:21:27"}, ""},
-					&Assign{"element", nil, "29"},
-					&Len{"delimiter", "30"},
-					&Assign{"31", &ast.Literal{"number", "1", nil, nil, "lib/strings/split.ok:22:39"}, ""},
-					&Subtract{"30", "31", "32"},
-					&Add{"i", "32", "i"},
-					&Jump{50},
-					&StringIndex{"s", "i", "33"},
-					&CastString{"33", "34"},
-					&Concat{"element", "34", "element"},
-					&Assign{"35", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"i", "35", "i"},
-					&Jump{26},
-					&Assign{"36", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&ArrayAlloc{"36", "37", "[]string"},
-					&Assign{"38", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&ArraySet{"37", "38", "element"},
-					&Append{"elements", "37", "elements"},
-					&Return{Registers{"elements"}},
-				},
-				Registers: 38,
-				Variables: map[string]string{
-					"delimiter": "string",
-					"element":   "string",
-					"elements":  "[]string",
-					"i":         "number",
-					"s":         "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Split",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"delimiter", "string"},
-				},
-				Returns: []string{"[]string"},
-				Pos:     "lib/strings/split.ok:7:1",
-			},
-		},
-		"strings.ToLower": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"string", "", nil, nil, "lib/strings/case.ok:5:14"}, ""},
-					&Assign{"result", nil, "2"},
-					&Assign{"3", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&NextString{"s", "3", "", "c", "4"},
-					&JumpUnless{"4", 23},
-					&CastNumber{"c", "5"},
-					&Assign{"n", nil, "5"},
-					&Assign{"6", &ast.Literal{"char", "A", nil, nil, "lib/strings/case.ok:8:24"}, ""},
-					&CastNumber{"6", "7"},
-					&GreaterThanEqualNumber{"n", "7", "8"},
-					&Assign{"9", &ast.Literal{"char", "Z", nil, nil, "lib/strings/case.ok:8:44"}, ""},
-					&CastNumber{"9", "10"},
-					&LessThanEqualNumber{"n", "10", "11"},
-					&And{"8", "11", "12"},
-					&JumpUnless{"12", 20},
-					&Assign{"13", &ast.Literal{"number", "32", nil, nil, "lib/strings/case.ok:9:40"}, ""},
-					&Add{"n", "13", "14"},
-					&CastChar{"14", "15"},
-					&CastString{"15", "16"},
-					&Concat{"result", "16", "result"},
-					&Jump{22},
-					&CastString{"c", "17"},
-					&Concat{"result", "17", "result"},
-					&Jump{2},
-					&Return{Registers{"result"}},
-				},
-				Registers: 17,
-				Variables: map[string]string{
-					"c":      "ring",
-					"n":      "number",
-					"result": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "ToLower",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/case.ok:4:1",
-			},
-		},
-		"strings.ToUpper": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s"},
-				Instructions: []Instruction{
-					&Assign{"2", &ast.Literal{"string", "", nil, nil, "lib/strings/case.ok:22:14"}, ""},
-					&Assign{"result", nil, "2"},
-					&Assign{"3", &ast.Literal{"number", "0", nil, nil, ""}, ""},
-					&NextString{"s", "3", "", "c", "4"},
-					&JumpUnless{"4", 23},
-					&CastNumber{"c", "5"},
-					&Assign{"n", nil, "5"},
-					&Assign{"6", &ast.Literal{"char", "a", nil, nil, "lib/strings/case.ok:25:24"}, ""},
-					&CastNumber{"6", "7"},
-					&GreaterThanEqualNumber{"n", "7", "8"},
-					&Assign{"9", &ast.Literal{"char", "z", nil, nil, "lib/strings/case.ok:25:44"}, ""},
-					&CastNumber{"9", "10"},
-					&LessThanEqualNumber{"n", "10", "11"},
-					&And{"8", "11", "12"},
-					&JumpUnless{"12", 20},
-					&Assign{"13", &ast.Literal{"number", "32", nil, nil, "lib/strings/case.ok:26:40"}, ""},
-					&Subtract{"n", "13", "14"},
-					&CastChar{"14", "15"},
-					&CastString{"15", "16"},
-					&Concat{"result", "16", "result"},
-					&Jump{22},
-					&CastString{"c", "17"},
-					&Concat{"result", "17", "result"},
-					&Jump{2},
-					&Return{Registers{"result"}},
-				},
-				Registers: 17,
-				Variables: map[string]string{
-					"c":      "ring",
-					"n":      "number",
-					"result": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "ToUpper",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/case.ok:21:1",
-			},
-		},
-		"strings.Trim": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "cutset"},
-				Instructions: []Instruction{
-					&Call{"TrimLeft", Registers{"s", "cutset"}, Registers{"3"}},
-					&Call{"TrimRight", Registers{"3", "cutset"}, Registers{"4"}},
-					&Return{Registers{"4"}},
-				},
-				Registers: 4,
-				Variables: map[string]string{
-					"cutset": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "Trim",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"cutset", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/trim.ok:21:1",
-			},
-		},
-		"strings.TrimLeft": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "cutset"},
-				Instructions: []Instruction{
-					&Assign{"3", &ast.Literal{"number", "0", nil, nil, "lib/strings/trim.ok:4:18"}, ""},
-					&Assign{"offset", nil, "3"},
-					&Len{"s", "4"},
-					&LessThanNumber{"offset", "4", "5"},
-					&JumpUnless{"5", 15},
-					&StringIndex{"s", "offset", "6"},
-					&CastString{"6", "7"},
-					&Call{"Index", Registers{"cutset", "7"}, Registers{"8"}},
-					&Assign{"9", &ast.Literal{"number", "-1", nil, nil, "lib/strings/trim.ok:5:47"}, ""},
-					&EqualNumber{"8", "9", "10"},
-					&JumpUnless{"10", 12},
-					&Call{"substrFrom", Registers{"s", "offset"}, Registers{"11"}},
-					&Return{Registers{"11"}},
-					&Assign{"12", &ast.Literal{"number", "1", nil, nil, ""}, ""},
-					&Add{"offset", "12", "offset"},
-					&Jump{2},
-					&Return{Registers{"s"}},
-				},
-				Registers: 12,
-				Variables: map[string]string{
-					"cutset": "string",
-					"offset": "number",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "TrimLeft",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"cutset", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/trim.ok:3:1",
-			},
-		},
-		"strings.TrimPrefix": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "prefix"},
-				Instructions: []Instruction{
-					&Call{"HasPrefix", Registers{"s", "prefix"}, Registers{"3"}},
-					&JumpUnless{"3", 4},
-					&Len{"prefix", "4"},
-					&Call{"substrFrom", Registers{"s", "4"}, Registers{"5"}},
-					&Return{Registers{"5"}},
-					&Return{Registers{"s"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"prefix": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "TrimPrefix",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"prefix", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/trim.ok:32:1",
-			},
-		},
-		"strings.TrimRight": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "cutset"},
-				Instructions: []Instruction{
-					&Call{"Reverse", Registers{"s"}, Registers{"3"}},
-					&Call{"TrimLeft", Registers{"3", "cutset"}, Registers{"4"}},
-					&Call{"Reverse", Registers{"4"}, Registers{"5"}},
-					&Return{Registers{"5"}},
-				},
-				Registers: 5,
-				Variables: map[string]string{
-					"cutset": "string",
-					"s":      "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "TrimRight",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"cutset", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/trim.ok:15:1",
-			},
-		},
-		"strings.TrimSuffix": &InternalDefinition{
-			CompiledFunc: &CompiledFunc{
-				Arguments: []string{"s", "suffix"},
-				Instructions: []Instruction{
-					&Call{"Reverse", Registers{"s"}, Registers{"3"}},
-					&Call{"Reverse", Registers{"suffix"}, Registers{"4"}},
-					&Call{"TrimPrefix", Registers{"3", "4"}, Registers{"5"}},
-					&Call{"Reverse", Registers{"5"}, Registers{"6"}},
-					&Return{Registers{"6"}},
-				},
-				Registers: 6,
-				Variables: map[string]string{
-					"s":      "string",
-					"suffix": "string",
-				},
-				Interfaces: nil,
-			},
-			FuncDef: &ast.Func{
-				Name: "TrimSuffix",
-				Arguments: []*ast.Argument{
-					&ast.Argument{"s", "string"},
-					&ast.Argument{"suffix", "string"},
-				},
-				Returns: []string{"string"},
-				Pos:     "lib/strings/trim.ok:47:1",
-			},
-		},
-	}
-	Constants = map[string]*ast.Literal{
-		"math.E":       &ast.Literal{"number", "2.71828182845904523536028747135266249775724709369995957496696763", nil, nil, "lib/math/constants.ok:1:7"},
-		"math.Ln10":    &ast.Literal{"number", "2.30258509299404568401799145468436420760110148862877297603332790", nil, nil, "lib/math/constants.ok:11:8"},
-		"math.Ln2":     &ast.Literal{"number", "0.693147180559945309417232121458176568075500134360255254120680009", nil, nil, "lib/math/constants.ok:10:8"},
-		"math.Phi":     &ast.Literal{"number", "1.61803398874989484820458683436563811772030917980576286213544862", nil, nil, "lib/math/constants.ok:3:7"},
-		"math.Pi":      &ast.Literal{"number", "3.14159265358979323846264338327950288419716939937510582097494459", nil, nil, "lib/math/constants.ok:2:7"},
-		"math.Sqrt2":   &ast.Literal{"number", "1.41421356237309504880168872420969807856967187537694807317667974", nil, nil, "lib/math/constants.ok:5:11"},
-		"math.SqrtE":   &ast.Literal{"number", "1.64872127070012814684865078781416357165377610071014801157507931", nil, nil, "lib/math/constants.ok:6:11"},
-		"math.SqrtPhi": &ast.Literal{"number", "1.27201964951406896425242246173749149171560804184009624861664038", nil, nil, "lib/math/constants.ok:8:11"},
-		"math.SqrtPi":  &ast.Literal{"number", "1.77245385090551602729816748334114518279754945612238712821380779", nil, nil, "lib/math/constants.ok:7:11"},
-	}
-	Interfaces = map[string]map[string]string{
-		"Error": map[string]string{
-			"Error": "string",
-		},
-	}
+	loadLib(lib)
 }
+
+// lib is the bytecode for all of the packages in lib/.
+const lib = "" +
+	"okc\n\x00\x03libV\x00\x05Error\x01\x02\x013\x01\x00\x02\x01\x02\x00\x06string\x01\x02\x02\x02\x00\x00\x02\x00\x00\x01\x02\x01\x02\x03" +
+	"\x01\x04\x00\x00\x04fs.1\x01\x00\x04size\x02E\x04\x02\x063\x01\x06\x06\x01\x06\x00\x06number\x02\x00\x03^fd\x04\x06\x02\x03\x04\x04\b" +
+	"\x00\x00\x05\x00\x0efs.ExistsError\x03\x02\x03\x00\x06Exists\x00\x04bool\x00\x04Path\x03\x00\afs." +
+	"File\a\x00\x05Close\x00\x06func()\x00\x03EOF\x00\vfunc() bool\f\x03\x00\x04Read\x00\x11" +
+	"func(number) data\x00\bReadLine\x00\rfunc() string\x00\x05Writ" +
+	"e\x00\nfunc(data)\x00\vWriteString\x00\ffunc(string)\x00\vfs.Fil" +
+	"eInfo\x05\x00\x05IsDir\v\x00\aModTime\a\x00\x04Mode\a\x00\x04Name\x03\x00\x04Size\a\x00\x10f" +
+	"s.NotFoundError\x03\x02\x03\x00\bNotFound\v\f\x03\x00\x12fs.PermissionEr" +
+	"ror\x03\x02\x03\f\x03\x00\nPermission\v\x02\x00\x12lib/fs/file.ok:7:9$\x00\x00\x04fs" +
+	".2\x00\x02F\x02\x043\x01\x04\x04\x00\x01\b\x02\x02\x04\b\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b" +
+	"\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x13lib/fs/file.ok:12:9&" +
+	"\x00\x00\x04fs.3\x00\x02C\x02\x043\x01\x04\x04\x00\x01\b\x02\x02\x04\b\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17" +
+	"\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x13lib/fs/file.ok:" +
+	"17:9(\x00\x00\x04fs.4\x01\x00\bcontents\x01G\x04\x02\x04\x01*\x00\x04data\x02\b\x04*\x02\x03\x04\x04\b\x00\x00\x05" +
+	"\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03" +
+	"\f\x03#\v\x01\x00\x13lib/fs/file.ok:22:9\x00\x00\x04fs.5\x01\x00\x01s\x02A\x02\x06G\x04\x06\x06\x01.\x03" +
+	"\x02\b\x04.\x02\x03\x04\x04\b\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a" +
+	" \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x13lib/fs/file.ok:27:9/\x00\x00\x04fs.6\x00\x01" +
+	"B\x02\x02\x00\x01\b\x02\x02\x04\b\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f" +
+	"\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x01\x00\x13lib/fs/file.ok:32:9\x00\x00\rfs.App" +
+	"endFile\x02\x00\x04path*\x01P\x02\x04\x01\x04\x02*+3\x03\x02*\x043\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10" +
+	"\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x01\x00\x14lib/fs" +
+	"/write.ok:15:5\x00\x00\x0ffs.AppendString\x023.\x02A\x04\x06P\x02\x06\x01\x06\x023\x03." +
+	"\x03\x023\x02.\x04\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03" +
+	"\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x14lib/fs/write.ok:20:56\x00\x00\tfs.Crea" +
+	"te\x013\x04\a\x04\x01\x03\x00\x01w\x00\x14lib/fs/file.ok:44:37\x00\x00\x00D\x02\x04\x06\b\r\x02\x02\x06\x01\b" +
+	"3\x01\b\b\x013\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03" +
+	"\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x04\x00\x13lib/fs/file.ok:44:5:::\x00\t\x02\x02\f" +
+	"\x03\a\x06\x01\v\x00\x04true\x00\x16lib/fs/errors.ok:18:14\x00\x00\x00\a\b\x00\x063\x01\x00\b\x03\x02" +
+	"\x03\n\v\f\x03\x03\x02\x02\n\b\f\x04\x05\x04\x02\f\x04\n\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b" +
+	"\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x03\x00\x15lib/fs/errors.ok:18:" +
+	"5=\x04\x00\r\x02\f\x00\x02fd\x13\a\x06\x01\x0f0\x04\x00\x00\x00-\x06\a\b\x00\x06\a\n\x01\x19-\x04\x00\x00\x00-\n\a\f\x00\n\a\x0e\x01\x17)\x04" +
+	"\x00\x00\x00-\x0e\a\x10\x00\x0e\a\x12\x01\x11'\x04\x00\x00\x00-\x12\a\x14\x00\x12\a\x16\x01\x15%\x04\x00\x00\x00-\x16\a\x18\x00\x16\a\x1a\x01\x13\x05\x04\x00\x00\x00" +
+	"-\x1a\a\x1c\x00\x1a3\x01\x00\x1c\b\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19>\a\b\x0e\b\x10\x14\f\x02\x12\x1c\x14\x18\x16\x10\x18\f>\x04\x0f\x04\f>" +
+	"\x04\x0e\x04\x18\x04\x16\x04\x10\x04\x14\x04\x12\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e" +
+	"\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x13\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x04\x00\x1a\x05\x1e\x1f\x1d\x1c\x1b\x01" +
+	"3\x01\x00\n\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a\x05\x1b\n\x1c\b\x1d\x06\x1e\x02\x1f\x04\x06\x04\x1e\x1f\x1d\x1c\x1b\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f" +
+	"\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x01\x04\x00\x00\afs." +
+	"Glob\x01\x00\apattern\x02H\x02\x043\x01\x04\x04\x01@\x03\x01@\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03" +
+	"\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x12lib/fs/di" +
+	"r.ok:27:5A\x00\x00\vfs.MkdirAll\x013\x01I\x02\x02\x013\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03" +
+	"\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x01\x00\x11l" +
+	"ib/fs/dir.ok:4:5\x00 \x02\x02\f\x03\a\x06\x01\v;\x00\x15lib/fs/errors.ok:6:" +
+	"16\x00\x00\x00\a\b\x00\x063\x01\x00\b\x03\x02\x03!\v\f\x03\x03\x02\x02!\b\f\x04\x05\x04\x02\f\x04!\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f" +
+	"\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x03\x00\x14lib/f" +
+	"s/errors.ok:6:5E\x04\x00\x00\afs.Open\x013\x04\a\x04\x01\x03\x00\x01r\x00\x14lib/fs/fi" +
+	"le.ok:38:37\x00\x00\x00D\x02\x04\x06\b\r\x02\x02\x06\x01\b3\x01\b\b\x013\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r" +
+	"\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x04\x00\x13li" +
+	"b/fs/file.ok:38:5III\x00\"\x02\x02\f\x03\a\x06\x01\v;\x00\x16lib/fs/errors.o" +
+	"k:12:18\x00\x00\x00\a\b\x00\x063\x01\x00\b\x03\x02\x03\f\x03#\v\x03\x02\x02\f\x04#\b\x05\x04\x02\f\x04#\x00\x00\x05\t\x03\x02\x03\n\v\f" +
+	"\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x03\x00\x15" +
+	"lib/fs/errors.ok:12:5K\x04\x00\x00\nfs.ReadDir\x013\x02J\x02\x043\x01\x04\x04\x013" +
+	"\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03" +
+	"!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x13lib/fs/info.ok:21:5M\x00\x00\vfs.ReadFil" +
+	"e\x013\x02K\x02\x043\x01\x04\x04\x013\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b" +
+	"\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x12lib/fs/read.ok:3:5O\x00" +
+	"\x00\rfs.ReadString\x013\x03K\x02\x04\v\x04\x063\x01\x06\x06\x013\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a" +
+	"\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x03\x00\x12lib" +
+	"/fs/read.ok:8:5QQ\x00\x00\tfs.Remove\x013\x01L\x02\x00\x02\x013\x03\x013\x02\x00\x00\x00\x05\t\x03" +
+	"\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03" +
+	"#\v\x01\x00\x11lib/fs/dir.ok:9:5\x00\x00\ffs.RemoveAll\x013\x01L\x02\x01\x02\x013\x03\x01" +
+	"3\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v" +
+	"\f\x03\"\x03\x02\x03\f\x03#\v\x01\x00\x12lib/fs/dir.ok:15:5\x00\x00\tfs.Rename\x02\x00\x04fr" +
+	"om\x00\x02to\x01M\x02\x04\x04\x02W\x03X\x03\x02W\x02X\x04\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18" +
+	"\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x01\x00\x12lib/fs/dir.ok:21" +
+	":5\x00\x00\afs.Stat\x013\x02N\x02\x043\x01\x04\x04\x013\x03\x013\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03" +
+	"\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x13lib/fs/in" +
+	"fo.ok:15:5[\x00\x00\nfs.TempDir\x00\x02O\x02\x013\x01\x02\x02\x00\x00\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r" +
+	"\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x12li" +
+	"b/fs/dir.ok:39:5]\x00\x00\vfs.TempFile\x00\x02O\x02\x003\x01\x02\x02\x00\x00\x00\x00\x00\x05\t\x03" +
+	"\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03" +
+	"#\v\x02\x00\x12lib/fs/dir.ok:33:5_\x00\x00\ffs.WriteFile\x023*\x01P\x02\x04\x00\x04" +
+	"\x02*+3\x03\x02*\x043\x02\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03" +
+	"\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x01\x00\x13lib/fs/write.ok:4:5\x00\x00\x0efs.Wr" +
+	"iteString\x023.\x02A\x04\x06P\x02\x06\x00\x06\x023\x03.\x03\x023\x02.\x04\x00\x00\x00\x05\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10" +
+	"\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\x02\x00\x13lib/fs" +
+	"/write.ok:9:5c\x00\x00\x06io.EOF\x00\x02Q\x023\x01\x02\x02\x00\x00\x00\x00\x00\x00\x02\x00\x13lib/io/r" +
+	"ead.ok:14:5e\x00\x00\bio.Lines\x00\x0e\a\x02\x01\a\x00\x010\x04\x00\x00\x00\x03\x02\x04\x00\b[]strin" +
+	"g\a\x06\x00\x04\bd\x00\x01\b)\b\n\x1b\n\x18\a\f\x01\a\x00\x011\x04\x00\x00\x00\x03\f\x0eh\a\x10\x01\ag\x04\x00\x00\x00\b\x00\vio.Re" +
+	"adLine\x00\x01\x12\x05\x0e\x10\x12\x02\x06\x0e\x06\x1a\x043\x01\x06\x12\x01\x00\x05linesh\x01k\x06\x00\x00\x00\x00\x0e\x00\x13lib/io" +
+	"/read.ok:31:5ll\x00\x13lib/io/read.ok:32:5mm\x00\x13lib/io/r" +
+	"ead.ok:33:9nnnnnm\x00\x13lib/io/read.ok:36:5\x00\x00\rio.Prin" +
+	"tError\x01.\x03\a\x04\x01\x03\x00\x01\n\x00\x15lib/io/write.ok:13:22\x00\x00\x00\r\x02\x04\x06T\x06" +
+	"\x01\x06\x01.\x03\x01.\x02\x00\x00\x00\x00\x03\x00\x14lib/io/write.ok:13:5ss\x00\x00\nio.ReadA" +
+	"ll\x00\x02R\x023\x01\x02\x02\x00\x00\x00\x00\x00\x00\x02\x00\x13lib/io/read.ok:19:5u\x00j\x00\x02S\x023\x01\x02" +
+	"\x02\x00\x00\x00\x00\x00\x00\x02\x00\x12lib/io/read.ok:8:5v\x00\x00\rio.ReadString\x00\x03R" +
+	"\x02\v\x02\x043\x01\x04\x04\x00\x00\x00\x00\x00\x00\x03\x00\x13lib/io/read.ok:24:5xx\x00\x00\bio.Writ" +
+	"e\x01.\x01T\x02\x00\x02\x01.\x03\x01.\x02\x00\x00\x00\x00\x01\x00\x13lib/io/write.ok:3:5\x00\x00\rio.Wr" +
+	"iteError\x01.\x01T\x02\x01\x02\x01.\x03\x01.\x02\x00\x00\x00\x00\x01\x00\x13lib/io/write.ok:8:5\x00" +
+	"\x00\bmath.Abs\x01\x00\x01x\a\a\x04\x01\ag\x00\x14lib/math/abs.ok:3:12\x00\x00\x00\x1f\x02\x04" +
+	"\x06\x1b\x06\n\a\b\x01\ag\x04\x00\x00\x006\b\x02\n3\x01\n3\x01\x02\n\x01~\a\x01~\x02\x00\x00\x00\x00\a\x00\x13lib/math/ab" +
+	"s.ok:3:5\x80\x01\x80\x01\x00\x13lib/math/abs.ok:4:9\x81\x01\x81\x01\x00\x13lib/math/" +
+	"abs.ok:7:5\x00\x00\tmath.Cbrt\x01~\x05\a\x04\x01\ai\x00\x18lib/math/powers." +
+	"ok:21:21\x00\x00\x00\a\x06\x01\a\x00\x013\x00\x18lib/math/powers.ok:21:23\x00\x00\x00\x0e" +
+	"\x04\x06\b.\x02\b\n3\x01\n\n\x01~\a\x01~\x02\x00\x00\x00\x00\x05\x00\x17lib/math/powers.ok:21:5\x87" +
+	"\x01\x87\x01\x87\x01\x87\x01\x00\x00\tmath.Ceil\x01~\x10\a\x04\x01\ai\x00\x19lib/math/rounding.o" +
+	"k:3:16\x00\x00\x002\x02\x04\x06\a\b\x00\x06\a\n\x01\ag\x00\x19lib/math/rounding.ok:4:1" +
+	"6\x00\x00\x00\x11\b\n\f\x1b\f\f3\x01\x02\a\x0e\x01\ag\x00\x19lib/math/rounding.ok:8:12\x00\x00" +
+	"\x00\x1f\x02\x0e\x10\x1b\x10\x166\x02\b\x123\x01\x12\a\x14\x01\ai\x00\x1alib/math/rounding.ok:12:17" +
+	"\x00\x00\x006\x14\b\x16\x00\x02\x16\x183\x01\x18\x18\x02\x00\x04frac\a~\a\x02\x8d\x01\b~\x02\x00\x00\x00\x00\x10\x00\x18lib/math/r" +
+	"ounding.ok:3:5\x8e\x01\x8e\x01\x00\x18lib/math/rounding.ok:4:5\x8f\x01\x8f\x01" +
+	"\x00\x18lib/math/rounding.ok:5:9\x00\x18lib/math/rounding.ok" +
+	":8:5\x91\x01\x91\x01\x00\x18lib/math/rounding.ok:9:9\x92\x01\x00\x19lib/math/r" +
+	"ounding.ok:12:5\x93\x01\x93\x01\x93\x01\x00\x00\bmath.Exp\x01~\x04\a\x04\x01\a\x0042.71828" +
+	"182845904523536028747135266249775724709369995\x00\x16l" +
+	"ib/math/powers.ok:4:9\x00\x00\x00\a\x06\x00\x04.\x06\x02\b3\x01\b\b\x02\x00\x01e\a~\a\x02\x97\x01\x06~" +
+	"\x02\x00\x00\x00\x00\x04\x00\x16lib/math/powers.ok:4:5\x98\x01\x00\x16lib/math/power" +
+	"s.ok:6:5\x99\x01\x00\x00\nmath.Floor\x01~\x10\a\x04\x01\ai\x00\x1alib/math/roundi" +
+	"ng.ok:17:16\x00\x00\x002\x02\x04\x06\a\b\x00\x06\a\n\x01\ag\x00\x1alib/math/rounding.o" +
+	"k:18:16\x00\x00\x00\x11\b\n\f\x1b\f\f3\x01\x02\a\x0e\x01\ag\x00\x1alib/math/rounding.ok:" +
+	"22:12\x00\x00\x00\x1f\x02\x0e\x10\x1b\x10\x1a\a\x12\x01\ai\x00\x1alib/math/rounding.ok:23:27" +
+	"\x00\x00\x00\x00\b\x12\x146\x02\x14\x163\x01\x166\x02\b\x183\x01\x18\x18\x02\x8d\x01\a~\a\x02\x8d\x01\b~\x02\x00\x00\x00\x00\x10\x00\x19lib/mat" +
+	"h/rounding.ok:17:5\x9f\x01\x9f\x01\x00\x19lib/math/rounding.ok:18:" +
+	"5\xa0\x01\xa0\x01\x00\x19lib/math/rounding.ok:19:9\x00\x19lib/math/round" +
+	"ing.ok:22:5\xa2\x01\xa2\x01\x00\x19lib/math/rounding.ok:23:9\xa3\x01\xa3\x01\xa3\x01" +
+	"\x00\x19lib/math/rounding.ok:26:5\xa4\x01\x00\x00\nmath.Log10\x01~\x05!\x02\x04" +
+	"\a\x06\x01\a\x00\x0210\x00\x14lib/math/log.ok:8:29\x00\x00\x00!\x06\b\x0e\x04\b\n3\x01\n\n\x01~\a\x01" +
+	"~\x02\x00\x00\x00\x00\x05\x00\x13lib/math/log.ok:8:5\xa8\x01\xa8\x01\xa8\x01\xa8\x01\x00\x00\tmath.LogE" +
+	"\x01~\x02!\x02\x043\x01\x04\x04\x01~\a\x01~\x02\x00\x00\x00\x00\x02\x00\x13lib/math/log.ok:3:5\xaa\x01\x00\x00\bm" +
+	"ath.Pow\x02\x00\x04base\x00\x05power\x02.\x02\x04\x063\x01\x06\x06\x02\xac\x01\a\xad\x01\a\x02\xac\x01\x02\xad\x01\x04\x00\x00\x00\x00" +
+	"\x02\x00\x17lib/math/powers.ok:11:5\xae\x01\x00\x00\nmath.Round\x02~\x00\x04pre" +
+	"c\x13\a\x06\x01\a\xa6\x01\x00\x1alib/math/rounding.ok:32:15\x00\x00\x00.\x06\x04\b\a\n\x00\b%" +
+	"\x02\n\f\a\x0e\x00\f\a\x10\x01\ai\x00\x1alib/math/rounding.ok:35:16\x00\x00\x002\x0e\x10\x12\a" +
+	"\x14\x00\x12\a\x16\x01\a\x00\x030.5\x00\x1alib/math/rounding.ok:36:16\x00\x00\x00\x14\x14\x16\x18\x1b" +
+	"\x18\x1e\a\x1a\x01\ai\x00\x1alib/math/rounding.ok:37:22\x00\x00\x006\x1a\x14\x1c\x00\x0e\x1c\x1e\x0e\x1e" +
+	"\n 3\x01 6\x0e\x14\"\x0e\"\n$3\x01$$\x05\x00\x04diff\a\x00\x01p\a\xb0\x01\a~\a\x00\x01y\a\x05\xb6\x01\x14\xb7\x01\n\xb0\x01\x04" +
+	"~\x02\xb8\x01\x0e\x00\x00\x00\x00\x13\x00\x19lib/math/rounding.ok:32:5\xb9\x01\xb9\x01\x00\x19lib/m" +
+	"ath/rounding.ok:33:5\xba\x01\x00\x19lib/math/rounding.ok:35:" +
+	"5\xbb\x01\xbb\x01\x00\x19lib/math/rounding.ok:36:5\xbc\x01\xbc\x01\x00\x19lib/math/r" +
+	"ounding.ok:37:9\xbd\x01\xbd\x01\xbd\x01\xbd\x01\x00\x19lib/math/rounding.ok:40" +
+	":5\xbe\x01\xbe\x01\x00\x00\tmath.Sqrt\x01~\x03\a\x04\x01\a\xb3\x01\x00\x18lib/math/powers.ok:" +
+	"16:21\x00\x00\x00.\x02\x04\x063\x01\x06\x06\x01~\a\x01~\x02\x00\x00\x00\x00\x03\x00\x17lib/math/powers.ok:" +
+	"16:5\xc1\x01\xc1\x01\x00\x00\aos.Args\x00\x029\x023\x01\x02\x02\x00\x00\x00\x00\x00\x00\x02\x00\x12lib/os/args.o" +
+	"k:7:5\xc3\x01\x00\x00\nos.Environ\x00\x02:\x023\x01\x02\x02\x00\x00\x00\x00\x00\x00\x02\x00\x12lib/os/env." +
+	"ok:16:5\xc5\x01\x00\x00\aos.Exit\x01\x00\x04code\x01;\x02\x02\x01\xc7\x01\a\x01\xc7\x01\x02\x00\x00\x00\x00\x01\x00\x12lib" +
+	"/os/exit.ok:6:5\x00\x00\tos.Getenv\x01\x00\x04name\x02<\x02\x043\x01\x04\x04\x01\xca\x01\x03\x01\xca" +
+	"\x01\x02\x00\x00\x00\x00\x02\x00\x11lib/os/env.ok:4:5\xcb\x01\x00\x00\bos.Getwd\x00\x02=\x023\x01\x02\x02\x00" +
+	"\x00\x00\x00\x00\x00\x02\x00\x15lib/os/process.ok:3:5\xcd\x01\x00\x00\vos.Hostname\x00\x02>" +
+	"\x023\x01\x02\x02\x00\x00\x00\x00\x00\x00\x02\x00\x15lib/os/process.ok:8:5\xcf\x01\x00\x00\x06os.Pid\x00\x02" +
+	"?\x023\x01\x02\x02\x00\x00\x00\x00\x00\x00\x02\x00\x16lib/os/process.ok:13:5\xd1\x01\x00\x00\tos.Set" +
+	"env\x02\xca\x01\x00\x05value\x01@\x02\x04\x04\x02\xca\x01\x03\xd3\x01\x03\x02\xca\x01\x02\xd3\x01\x04\x00\x00\x00\x00\x01\x00\x12lib/os/en" +
+	"v.ok:11:5\x00\x00\freflect.Call\x02\x00\x02fn\x00\x04args\x02\x0f\x02\x04\x063\x01\x06\x06\x02\xd7\x01\x00" +
+	"\x05[]any\xd6\x01\x00\x03any\x02\xd7\x01\x04\xd6\x01\x02\x00\x00\x00\x00\x02\x00\x18lib/reflect/call.ok:1" +
+	"7:5\xda\x01\x00\x00\vreflect.Get\x02\x00\x03obj\x00\x04prop\x02\x13\x02\x04\x063\x01\x06\x06\x02\xdc\x01\xd9\x01\xdd\x01\xd9" +
+	"\x01\x02\xdc\x01\x02\xdd\x01\x04\x00\x00\x00\x00\x02\x00\x17lib/reflect/get.ok:16:5\xde\x01\x00\x00\x11refle" +
+	"ct.Interface\x01\xd3\x01\x02\x18\x02\x043\x01\x04\x04\x01\xd3\x01\xd9\x01\x01\xd3\x01\x02\x00\x00\x00\x00\x02\x00\x1dlib/refle" +
+	"ct/interface.ok:11:5\xe0\x01\x00\x00\freflect.Kind\x01\xd3\x01\x16\b\x00\frefl" +
+	"ect.Type\x01\x02\x01\x04\a\x06\x00\x04\a\b\x01\x03\x00\x02[]\x00\x18lib/reflect/kind.ok:7:" +
+	"30\x00\x00\x00\b\x00\x11reflect.hasPrefix\x02\x06\b\x01\n\x1b\n\x0e\a\f\x01\x03\x00\x05array\x00\x18li" +
+	"b/reflect/kind.ok:8:20\x00\x00\x003\x01\f\x1a(\a\x10\x01\x03\x00\x02{}\x00\x19lib/refl" +
+	"ect/kind.ok:11:31\x00\x00\x00\x19\x0e\x01\x10\b\xe5\x01\x02\x06\x0e\x01\x12\x1b\x12\x1c\a\x14\x01\x03\x00\x03map\x00\x19li" +
+	"b/reflect/kind.ok:12:20\x00\x00\x003\x01\x14\x1a(\a\x16\x01\x03\x00\x05func(\x00\x19lib/" +
+	"reflect/kind.ok:15:30\x00\x00\x00\b\xe5\x01\x02\x06\x16\x01\x18\x1b\x18(\a\x1a\x01\x03\x00\x04func\x00\x19l" +
+	"ib/reflect/kind.ok:16:20\x00\x00\x003\x01\x1a\x1a(3\x01\x06\x1a\x02\x00\x04type\x03\xd3\x01\xd9\x01" +
+	"\x02\xf0\x01\x06\xd3\x01\x02\x00\x00\x00\x00\x16\x00\x17lib/reflect/kind.ok:4:5\xf1\x01\x00\x17lib/ref" +
+	"lect/kind.ok:6:5\xf2\x01\xf2\x01\x00\x18lib/reflect/kind.ok:8:13\xf3\x01" +
+	"\xf2\x01\xf2\x01\xf2\x01\xf2\x01\xf2\x01\x00\x19lib/reflect/kind.ok:12:13\xf4\x01\xf2\x01\xf2\x01\xf2\x01\xf2\x01\x00" +
+	"\x19lib/reflect/kind.ok:16:13\xf5\x01\xf2\x01\x00\x18lib/reflect/kind" +
+	".ok:20:5\x00\x00\vreflect.Len\x01\xd3\x01\x02\x1c\x02\x043\x01\x04\x04\x01\xd3\x01\xd9\x01\x01\xd3\x01\x02\x00\x00\x00\x00\x02\x00" +
+	"\x16lib/reflect/len.ok:4:5\xf8\x01\x00\x00\x12reflect.Properties\x01\xdc" +
+	"\x01\x020\x02\x043\x01\x04\x04\x01\xdc\x01\xd9\x01\x01\xdc\x01\x02\x00\x00\x00\x00\x02\x00\x18lib/reflect/props.ok:4:" +
+	"5\xfa\x01\x00\x00\vreflect.Set\x03\xdc\x01\xdd\x01\xd3\x01\x024\x02\x04\x06\b3\x01\b\b\x03\xdc\x01\xd9\x01\xdd\x01\xd9\x01\xd3\x01\xd9\x01\x03" +
+	"\xdc\x01\x02\xdd\x01\x04\xd3\x01\x06\x00\x00\x00\x00\x02\x00\x17lib/reflect/set.ok:17:5\xfc\x01\x00\xe2\x01\x01\xd3\x01\x02" +
+	"7\x02\x043\x01\x04\x04\x01\xd3\x01\xd9\x01\x01\xd3\x01\x02\x00\x00\x00\x00\x02\x00\x17lib/reflect/type.ok:9:5\xfd\x01" +
+	"\x00\xe5\x01\x02.\x00\x06prefix\x16\x1c\x02\x06\x1c\x04\b\x1f\x06\b\n\x1b\n\n\a\f\x01\v\x00\x05false\x00\x1blib/refl" +
+	"ect/strings.ok:6:16\x00\x00\x003\x01\f\a\x0e\x01\ag\x00\x1blib/reflect/stri" +
+	"ngs.ok:9:13\x00\x00\x00\a\x10\x00\x0e\x1c\x04\x12\x1f\x10\x12\x14\x1b\x14&5\x02\x10\x165\x04\x10\x18*\x16\x18\x1a\x1b\x1a \a\x1c\x01\v\xff" +
+	"\x01\x00\x1clib/reflect/strings.ok:11:20\x00\x00\x003\x01\x1c\a\x1e\x01\ai\x04\x00\x00\x00\x00\x10" +
+	"\x1e\x10\x1a\x0e\a \x01\v;\x00\x1clib/reflect/strings.ok:15:12\x00\x00\x003\x01  \x03\x00" +
+	"\x01i\a\xfe\x01\x03.\x03\x03\x84\x02\x10\xfe\x01\x04.\x02\x00\x00\x00\x00\x16\x00\x1alib/reflect/strings.ok:5" +
+	":5\x85\x02\x85\x02\x85\x02\x00\x1alib/reflect/strings.ok:6:9\x86\x02\x00\x1alib/refl" +
+	"ect/strings.ok:9:5\x87\x02\x87\x02\x87\x02\x87\x02\x00\x1blib/reflect/strings." +
+	"ok:10:9\x88\x02\x88\x02\x88\x02\x00\x1clib/reflect/strings.ok:11:13\x89\x02\x87\x02\x87" +
+	"\x02\x87\x02\x00\x1blib/reflect/strings.ok:15:5\x8a\x02\x00\x00\x10strings.Con" +
+	"tains\x02.\x00\x06substr\x04\b\x00\rstrings.Index\x02\x02\x04\x01\x06\a\b\x01\a\x00\x02-1\x00\x1cl" +
+	"ib/strings/contains.ok:3:32\x00\x00\x00+\x06\b\n3\x01\n\n\x02.\x03\x8c\x02\x03\x02.\x02\x8c" +
+	"\x02\x04\x00\x00\x00\x00\x04\x00\x1blib/strings/contains.ok:3:5\x90\x02\x90\x02\x90\x02\x00\x00\x11str" +
+	"ings.HasPrefix\x02.\xfe\x01\x16\x1c\x02\x06\x1c\x04\b\x1f\x06\b\n\x1b\n\n\a\f\x01\v\xff\x01\x00\x1clib/stri" +
+	"ngs/contains.ok:9:16\x00\x00\x003\x01\f\a\x0e\x01\ag\x00\x1dlib/strings/con" +
+	"tains.ok:12:13\x00\x00\x00\a\x10\x00\x0e\x1c\x04\x12\x1f\x10\x12\x14\x1b\x14&5\x02\x10\x165\x04\x10\x18*\x16\x18\x1a\x1b\x1a \a\x1c" +
+	"\x01\v\xff\x01\x00\x1dlib/strings/contains.ok:14:20\x00\x00\x003\x01\x1c\a\x1e\x01\ai\x04\x00" +
+	"\x00\x00\x00\x10\x1e\x10\x1a\x0e\a \x01\v;\x00\x1dlib/strings/contains.ok:18:12\x00\x00\x003" +
+	"\x01  \x03\x84\x02\a\xfe\x01\x03.\x03\x03\x84\x02\x10\xfe\x01\x04.\x02\x00\x00\x00\x00\x16\x00\x1blib/strings/contains" +
+	".ok:8:5\x96\x02\x96\x02\x96\x02\x00\x1blib/strings/contains.ok:9:9\x97\x02\x00\x1cli" +
+	"b/strings/contains.ok:12:5\x98\x02\x98\x02\x98\x02\x98\x02\x00\x1clib/strings/" +
+	"contains.ok:13:9\x99\x02\x99\x02\x99\x02\x00\x1dlib/strings/contains.ok:" +
+	"14:13\x9a\x02\x98\x02\x98\x02\x98\x02\x00\x1clib/strings/contains.ok:18:5\x9b\x02\x00\x00\x11" +
+	"strings.HasSuffix\x02.\x00\x06suffix\x1e\x1c\x02\x06\x1c\x04\b\x1f\x06\b\n\x1b\n\n\a\f\x01\v\xff\x01\x00" +
+	"\x1dlib/strings/contains.ok:24:16\x00\x00\x003\x01\f\x1c\x02\x0e\a\x10\x01\ai\x00\x1dli" +
+	"b/strings/contains.ok:27:18\x00\x00\x006\x0e\x10\x12\a\x14\x00\x12\x1c\x04\x16\a\x18\x01\ai\x00\x1d" +
+	"lib/strings/contains.ok:28:27\x00\x00\x006\x16\x18\x1a\a\x1c\x00\x1a\a\x1e\x01\ag\x00\x1dl" +
+	"ib/strings/contains.ok:28:35\x00\x00\x00\x14\x1c\x1e \x1b 65\x02\x14\"5\x04\x1c$*\"" +
+	"$&\x1b&,\a(\x01\v\xff\x01\x00\x1dlib/strings/contains.ok:30:20\x00\x00\x003\x01(" +
+	"\a*\x01\ai\x04\x00\x00\x006\x14*\x14\a,\x01\ai\x04\x00\x00\x006\x1c,\x1c\x1a\x1a\a.\x01\v;\x00\x1dlib/strings/c" +
+	"ontains.ok:36:12\x00\x00\x003\x01..\x04\x84\x02\a\x00\x01j\a.\x03\x9d\x02\x03\x04\x84\x02\x1c\xa4\x02\x14.\x02\x9d\x02\x04" +
+	"\x00\x00\x00\x00\x1e\x00\x1clib/strings/contains.ok:23:5\xa5\x02\xa5\x02\xa5\x02\x00\x1clib/s" +
+	"trings/contains.ok:24:9\xa6\x02\x00\x1clib/strings/contains." +
+	"ok:27:5\xa7\x02\xa7\x02\xa7\x02\x00\x1clib/strings/contains.ok:28:5\xa8\x02\xa8\x02\xa8" +
+	"\x02\xa8\x02\xa8\x02\xa8\x02\x00\x1clib/strings/contains.ok:29:9\xa9\x02\xa9\x02\xa9\x02\x00\x1dlib" +
+	"/strings/contains.ok:30:13\xaa\x02\x00\x1clib/strings/contai" +
+	"ns.ok:33:9\xab\x02\xa8\x02\xa8\x02\xa8\x02\x00\x1clib/strings/contains.ok:36:5" +
+	"\xac\x02\x00\x8d\x02\x02.\x8c\x02\x03\a\x06\x01\a\x8e\x02\x00\x19lib/strings/index.ok:3:34\x00\x00\x00\b\x00" +
+	"\x12strings.IndexAfter\x03\x02\x04\x06\x01\b3\x01\b\b\x02.\x03\x8c\x02\x03\x02.\x02\x8c\x02\x04\x00\x00\x00\x00\x03\x00\x18" +
+	"lib/strings/index.ok:3:5\xaf\x02\xaf\x02\x00\xae\x02\x03.\x8c\x02\x00\x06offset$\a\b\x01\a" +
+	"\x8e\x02\x00\x1alib/strings/index.ok:18:26\x00\x00\x00\b\x00\vstrings.max\x02" +
+	"\x06\b\x01\n\a\x06\x00\n\a\f\x01\ai\x00\x1alib/strings/index.ok:20:22\x00\x00\x00\x00\x06\f\x0e" +
+	"\a\x10\x00\x0e\x1c\x02\x12\x1c\x04\x146\x12\x14\x16\x1d\x10\x16\x18\x1b\x18B\a\x1a\x01\v;\x00\x1alib/strings/index.ok" +
+	":21:17\x00\x00\x00\a\x1c\x00\x1a\a\x1e\x01\ag\x00\x1alib/strings/index.ok:23:17\x00\x00" +
+	"\x00\a \x00\x1e\x1c\x04\"\x1f \"$\x1b$8\x00\x10 &5\x02&(5\x04 **(*,\x1b,2\a.\x01\v\xff\x01\x00\x1alib/st" +
+	"rings/index.ok:25:25\x00\x00\x00\a\x1c\x00.\x1a8\a0\x01\ai\x04\x00\x00\x00\x00 0 \x1a\x1c\x1b\x1c<3" +
+	"\x01\x10\a2\x01\ai\x04\x00\x00\x00\x00\x102\x10\x1a\n\a4\x01\a\x8e\x02\x00\x1alib/strings/index.ok:35" +
+	":12\x00\x00\x003\x0144\x06\x00\x05found\v\x84\x02\a\xa4\x02\a\xb0\x02\a.\x03\x8c\x02\x03\x06\xb8\x02\x1c\x84\x02\x10\xa4\x02 \xb0\x02\x06.\x02" +
+	"\x8c\x02\x04\x00\x00\x00\x00$\x00\x19lib/strings/index.ok:18:5\xb9\x02\xb9\x02\x00\x19lib/str" +
+	"ings/index.ok:20:5\xba\x02\xba\x02\xba\x02\xba\x02\xba\x02\xba\x02\xba\x02\x00\x19lib/strings/in" +
+	"dex.ok:21:9\xbb\x02\x00\x19lib/strings/index.ok:23:9\xbc\x02\xbc\x02\xbc\x02\xbc\x02" +
+	"\x00\x1alib/strings/index.ok:24:13\xbd\x02\xbd\x02\xbd\x02\xbd\x02\x00\x1alib/string" +
+	"s/index.ok:25:17\xbe\x02\x00\x1alib/strings/index.ok:26:17\xbc\x02" +
+	"\xbc\x02\xbc\x02\x00\x19lib/strings/index.ok:30:9\x00\x1alib/strings/ind" +
+	"ex.ok:31:13\xba\x02\xba\x02\xba\x02\x00\x19lib/strings/index.ok:35:5\xc2\x02\x00\x00" +
+	"\fstrings.Join\x02\x00\astrings\x00\x04glue\f\a\x06\x01\x03\x04\x00\x18lib/strings" +
+	"/join.ok:5:14\x00\x00\x00\a\b\x00\x06\a\x0e\x01\ag\x04\x00\x00\x00&\x02\x0e\f\n\x10\x1b\x10\x14\a\x12\x01\ag\x00\x18lib" +
+	"/strings/join.ok:7:16\x00\x00\x00\x16\f\x12\x14\x1b\x14\x10\r\b\x04\b\r\b\n\b\x1a\x043\x01\b\x14\x05\xc5\x02" +
+	"\x03\x84\x02\a\x00\x06result\x03.\x03\xc4\x02h\x05\xc5\x02\x04\x84\x02\f\xc8\x02\b.\n\xc4\x02\x02\x00\x00\x00\x00\f\x00\x17lib/stri" +
+	"ngs/join.ok:5:5\xc9\x02\x00\x17lib/strings/join.ok:6:5\xca\x02\xca\x02\x00\x17" +
+	"lib/strings/join.ok:7:9\xcb\x02\xcb\x02\x00\x18lib/strings/join.ok" +
+	":8:13\x00\x18lib/strings/join.ok:11:9\xca\x02\x00\x18lib/strings/j" +
+	"oin.ok:14:5\x00\x00\x11strings.LastIndex\x02.\x8c\x02\x0e\b\x00\x0fstrings.R" +
+	"everse\x01\x02\x01\x06\b\xd0\x02\x01\x04\x01\b\b\x8d\x02\x02\x06\b\x01\n\a\f\x00\n\a\x0e\x01\a\x8e\x02\x00\x1alib/strings" +
+	"/index.ok:59:17\x00\x00\x00\x11\f\x0e\x10\x1b\x10\x10\a\x12\x01\a\x8e\x02\x00\x1alib/strings/ind" +
+	"ex.ok:60:16\x00\x00\x003\x01\x12\x1c\x02\x14\x1c\x04\x16\x00\f\x16\x186\x14\x18\x1a3\x01\x1a\x1a\x03\x00\x05index\a.\x03\x8c\x02" +
+	"\x03\x03\xd3\x02\f.\x02\x8c\x02\x04\x00\x00\x00\x00\x0e\x00\x19lib/strings/index.ok:58:5\xd4\x02\xd4\x02\xd4\x02" +
+	"\x00\x19lib/strings/index.ok:59:5\xd5\x02\xd5\x02\x00\x19lib/strings/ind" +
+	"ex.ok:60:9\xd6\x02\x00\x19lib/strings/index.ok:63:5\xd7\x02\xd7\x02\xd7\x02\xd7\x02\x00" +
+	"\x00\x17strings.LastIndexBefore\x03.\x8c\x02\xb0\x02\x15\x1c\x02\b\x1c\x02\n\b\x00\vstrings" +
+	".min\x02\x06\n\x01\f\a\x0e\x01\ai\x00\x1alib/strings/index.ok:79:45\x00\x00\x00\x00\f\x0e" +
+	"\x106\b\x10\x12\a\x06\x00\x12\b\xd0\x02\x01\x02\x01\x14\b\xd0\x02\x01\x04\x01\x16\b\xae\x02\x03\x14\x16\x06\x01\x18\a\x1a\x00\x18\a\x1c\x01\a\x8e\x02\x00\x1alib/" +
+	"strings/index.ok:82:17\x00\x00\x00\x11\x1a\x1c\x1e\x1b\x1e\x1e\a \x01\a\x8e\x02\x00\x1alib/stri" +
+	"ngs/index.ok:83:16\x00\x00\x003\x01 \x1c\x02\"\x1c\x04$\x00\x1a$&6\"&(3\x01((\x04\xd3\x02\a\xb0\x02" +
+	"\a.\x03\x8c\x02\x03\x04\xd3\x02\x1a\xb0\x02\x06.\x02\x8c\x02\x04\x00\x00\x00\x00\x15\x00\x19lib/strings/index.ok:79" +
+	":5\xdd\x02\xdd\x02\xdd\x02\xdd\x02\xdd\x02\xdd\x02\x00\x19lib/strings/index.ok:81:5\xde\x02\xde\x02\xde\x02\x00" +
+	"\x19lib/strings/index.ok:82:5\xdf\x02\xdf\x02\x00\x19lib/strings/inde" +
+	"x.ok:83:9\xe0\x02\x00\x19lib/strings/index.ok:86:5\xe1\x02\xe1\x02\xe1\x02\xe1\x02\x00\x00" +
+	"\x0estrings.Repeat\x02\x00\x03str\x00\x05times\v\a\x06\x01\x03\x04\x00\x1alib/strings/" +
+	"repeat.ok:4:14\x00\x00\x00\a\b\x00\x06\a\n\x01\ag\x00\x1alib/strings/repeat.o" +
+	"k:5:13\x00\x00\x00\a\f\x00\n\x1f\f\x04\x0e\x1b\x0e\x12\r\b\x02\b\a\x10\x01\ai\x04\x00\x00\x00\x00\f\x10\f\x1a\x063\x01\b\x10\x04\x84\x02\a\xc8" +
+	"\x02\x03\xe3\x02\x03\xe4\x02\a\x04\x84\x02\f\xc8\x02\b\xe3\x02\x02\xe4\x02\x04\x00\x00\x00\x00\v\x00\x19lib/strings/repeat.o" +
+	"k:4:5\xe7\x02\x00\x19lib/strings/repeat.ok:5:5\xe8\x02\xe8\x02\xe8\x02\x00\x19lib/st" +
+	"rings/repeat.ok:6:9\xe8\x02\xe8\x02\xe8\x02\x00\x19lib/strings/repeat.ok" +
+	":9:5\x00\x00\x12strings.ReplaceAll\x03.\x00\x04find\x00\areplace\x03\b\x00\rst" +
+	"rings.Split\x02\x02\x04\x01\b\b\xc3\x02\x02\b\x06\x01\n3\x01\n\n\x03\xec\x02\x03\xed\x02\x03.\x03\x03\xec\x02\x04\xed\x02\x06.\x02\x00\x00" +
+	"\x00\x00\x03\x00\x1alib/strings/replace.ok:6:5\xef\x02\xef\x02\x00\xd0\x02\x01.\x10\a\x04\x01\x03\x04\x00\x1b" +
+	"lib/strings/reverse.ok:3:14\x00\x00\x00\a\x06\x00\x04\x1c\x02\b\a\n\x01\ai\x00\x1blib/" +
+	"strings/reverse.ok:4:22\x00\x00\x006\b\n\f\a\x0e\x00\f\a\x10\x01\ag\x00\x1blib/str" +
+	"ings/reverse.ok:4:30\x00\x00\x00\x14\x0e\x10\x12\x1b\x12\x1c5\x02\x0e\x14\v\x14\x16\r\x06\x16\x06\a\x18\x01\ai\x04\x00" +
+	"\x00\x006\x0e\x18\x0e\x1a\n3\x01\x06\x18\x03\x84\x02\a\xc8\x02\x03.\x03\x03\x84\x02\x0e\xc8\x02\x06.\x02\x00\x00\x00\x00\x10\x00\x1alib/strings" +
+	"/reverse.ok:3:5\xf3\x02\x00\x1alib/strings/reverse.ok:4:5\xf4\x02\xf4" +
+	"\x02\xf4\x02\xf4\x02\xf4\x02\xf4\x02\x00\x1alib/strings/reverse.ok:5:9\xf5\x02\xf5\x02\xf4\x02\xf4\x02\xf4\x02\x00" +
+	"\x1alib/strings/reverse.ok:8:5\x00\xee\x02\x02.\x00\tdelimiter<\a\x06\x01\a" +
+	"g\x04\x00\x00\x00\x03\x06\bh\a\n\x00\b\a\f\x01\x03\x04\x00\x1alib/strings/split.ok:10:21\x00\x00" +
+	"\x00\x10\x04\f\x0e\x1b\x0e*\a\x10\x01\ag\x00\x1alib/strings/split.ok:13:17\x00\x00\x00\a\x12\x00\x10" +
+	"\x1c\x02\x14\x1f\x12\x14\x16\x1b\x16(\a\x18\x01\ai\x04\x00\x00\x00\x03\x18\x1ah\a\x1c\x01\ag\x04\x00\x00\x005\x02\x12\x1e\v\x1e \x05\x1a\x1c \x02\n\x1a\n\a" +
+	"\"\x01\ai\x04\x00\x00\x00\x00\x12\"\x12\x1a\x0e\x1at\a$\x01\x03\x04\x00\x1alib/strings/split.ok:17:1" +
+	"9\x00\x00\x00\a&\x00$\a(\x01\ag\x00\x1alib/strings/split.ok:18:17\x00\x00\x00\a\x12\x00(" +
+	"\x1c\x02*\x1f\x12*,\x1b,j\a.\x01\ai\x00\x1alib/strings/split.ok:19:45\x00\x00\x006\x12" +
+	".0\b\xae\x02\x03\x02\x040\x01262\x124\a6\x01\ag\x00\x1alib/strings/split.ok:19:55" +
+	"\x00\x00\x00\x11468\x1b8^\a:\x01\ai\x04\x00\x00\x00\x03:<h\a>\x01\ag\x04\x00\x00\x00\x05<>&\x02\n<\n\a@\x01\x03\x04\x00\x1al" +
+	"ib/strings/split.ok:21:27\x00\x00\x00\a&\x00@\x1c\x04B\aD\x01\ai\x00\x1alib/st" +
+	"rings/split.ok:22:39\x00\x00\x006BDF\x00\x12F\x12\x1ad5\x02\x12H\vHJ\r&J&\aL\x01\a" +
+	"i\x04\x00\x00\x00\x00\x12L\x12\x1a2\aN\x01\ai\x04\x00\x00\x00\x03NPh\aR\x01\ag\x04\x00\x00\x00\x05PR&\x02\nP\n3\x01\nR\x05\xf7\x02" +
+	"\x03\x00\aelement\x03\x00\belementsh\x84\x02\a.\x03\x05\xf7\x02\x04\x80\x03&\x81\x03\n\x84\x02\x12.\x02\x00\x00\x00\x00<\x00" +
+	"\x18lib/strings/split.ok:8:5\x82\x03\x82\x03\x00\x19lib/strings/split" +
+	".ok:10:5\x83\x03\x83\x03\x00\x19lib/strings/split.ok:13:9\x84\x03\x84\x03\x84\x03\x84\x03\x00" +
+	"\x1alib/strings/split.ok:14:13\x85\x03\x85\x03\x85\x03\x85\x03\x85\x03\x85\x03\x84\x03\x84\x03\x84\x03\x83\x03\x00" +
+	"\x19lib/strings/split.ok:17:9\x86\x03\x00\x19lib/strings/split." +
+	"ok:18:9\x87\x03\x87\x03\x87\x03\x87\x03\x00\x1alib/strings/split.ok:19:13\x88\x03\x88\x03\x88" +
+	"\x03\x88\x03\x88\x03\x88\x03\x00\x1alib/strings/split.ok:20:17\x89\x03\x89\x03\x89\x03\x89\x03\x00\x1alib" +
+	"/strings/split.ok:21:17\x8a\x03\x00\x1alib/strings/split.ok:" +
+	"22:17\x8b\x03\x8b\x03\x8b\x03\x88\x03\x00\x1alib/strings/split.ok:25:17\x8c\x03\x8c\x03\x87\x03\x87" +
+	"\x03\x87\x03\x00\x19lib/strings/split.ok:29:9\x8d\x03\x8d\x03\x8d\x03\x8d\x03\x00\x19lib/stri" +
+	"ngs/split.ok:32:5\x00\x00\x0fstrings.ToLower\x01.\x19\a\x04\x01\x03\x04\x00\x18lib" +
+	"/strings/case.ok:5:14\x00\x00\x00\a\x06\x00\x04\a\n\x01\ag\x04\x00\x00\x00(\x02\n\x01\b\f\x1b\f.\n\b" +
+	"\x0e\a\x10\x00\x0e\a\x12\x01\x00\x04char\x00\x01A\x00\x18lib/strings/case.ok:8:24\x00\x00\x00\n\x12" +
+	"\x14\x14\x10\x14\x16\a\x18\x01\x91\x03\x00\x01Z\x00\x18lib/strings/case.ok:8:44\x00\x00\x00\n\x18\x1a\x1d\x10\x1a" +
+	"\x1c\x01\x16\x1c\x1e\x1b\x1e(\a \x01\a\x00\x0232\x00\x18lib/strings/case.ok:9:40\x00\x00\x00\x00\x10 " +
+	"\"\t\"$\v$&\r\x06&\x06\x1a,\v\b(\r\x06(\x06\x1a\x043\x01\x06(\x04\x00\x01c\x91\x03\x00\x01n\a\xc8\x02\x03.\x03\x04\x98\x03\b\x99\x03\x10" +
+	"\xc8\x02\x06.\x02\x00\x00\x00\x00\x19\x00\x17lib/strings/case.ok:5:5\x9a\x03\x00\x17lib/strin" +
+	"gs/case.ok:6:5\x9b\x03\x9b\x03\x00\x17lib/strings/case.ok:7:9\x9c\x03\x00\x17l" +
+	"ib/strings/case.ok:8:9\x9d\x03\x9d\x03\x9d\x03\x9d\x03\x9d\x03\x9d\x03\x9d\x03\x00\x18lib/string" +
+	"s/case.ok:9:13\x9e\x03\x9e\x03\x9e\x03\x9e\x03\x9d\x03\x00\x19lib/strings/case.ok:11" +
+	":13\x9f\x03\x9b\x03\x00\x18lib/strings/case.ok:15:5\x00\x00\x0fstrings.ToUp" +
+	"per\x01.\x19\a\x04\x01\x03\x04\x00\x19lib/strings/case.ok:22:14\x00\x00\x00\a\x06\x00\x04\a\n\x01" +
+	"\ag\x04\x00\x00\x00(\x02\n\x01\b\f\x1b\f.\n\b\x0e\a\x10\x00\x0e\a\x12\x01\x91\x03\x00\x01a\x00\x19lib/strings/case" +
+	".ok:25:24\x00\x00\x00\n\x12\x14\x14\x10\x14\x16\a\x18\x01\x91\x03\x00\x01z\x00\x19lib/strings/case.ok" +
+	":25:44\x00\x00\x00\n\x18\x1a\x1d\x10\x1a\x1c\x01\x16\x1c\x1e\x1b\x1e(\a \x01\a\x96\x03\x00\x19lib/strings/case." +
+	"ok:26:40\x00\x00\x006\x10 \"\t\"$\v$&\r\x06&\x06\x1a,\v\b(\r\x06(\x06\x1a\x043\x01\x06(\x04\x98\x03\x91\x03\x99\x03\a" +
+	"\xc8\x02\x03.\x03\x04\x98\x03\b\x99\x03\x10\xc8\x02\x06.\x02\x00\x00\x00\x00\x19\x00\x18lib/strings/case.ok:22:5" +
+	"\xa8\x03\x00\x18lib/strings/case.ok:23:5\xa9\x03\xa9\x03\x00\x18lib/strings/ca" +
+	"se.ok:24:9\xaa\x03\x00\x18lib/strings/case.ok:25:9\xab\x03\xab\x03\xab\x03\xab\x03\xab\x03" +
+	"\xab\x03\xab\x03\x00\x19lib/strings/case.ok:26:13\xac\x03\xac\x03\xac\x03\xac\x03\xab\x03\x00\x19lib/s" +
+	"trings/case.ok:28:13\xad\x03\xa9\x03\x00\x18lib/strings/case.ok:32" +
+	":5\x00\x00\fstrings.Trim\x02.\x00\x06cutset\x03\b\x00\x10strings.TrimLeft\x02" +
+	"\x02\x04\x01\x06\b\x00\x11strings.TrimRight\x02\x06\x04\x01\b3\x01\b\b\x02\xb0\x03\x03.\x03\x02\xb0\x03\x04.\x02\x00\x00\x00" +
+	"\x00\x03\x00\x18lib/strings/trim.ok:22:5\xb3\x03\xb3\x03\x00\xb1\x03\x02.\xb0\x03\x11\a\x06\x01\ag\x00\x18l" +
+	"ib/strings/trim.ok:4:18\x00\x00\x00\a\b\x00\x06\x1c\x02\n\x1f\b\n\f\x1b\f\x1e5\x02\b\x0e\v\x0e\x10\b" +
+	"\x8d\x02\x02\x04\x10\x01\x12\a\x14\x01\a\x8e\x02\x00\x18lib/strings/trim.ok:5:47\x00\x00\x00\x11\x12\x14\x16\x1b\x16" +
+	"\x18\b\x00\x12strings.substrFrom\x02\x02\b\x01\x183\x01\x18\a\x1a\x01\ai\x04\x00\x00\x00\x00\b\x1a\b\x1a\x023\x01\x02" +
+	"\x1a\x03\xb0\x03\x03\xb0\x02\a.\x03\x03\xb0\x03\x04\xb0\x02\b.\x02\x00\x00\x00\x00\x11\x00\x17lib/strings/trim.ok:4:" +
+	"5\xb7\x03\xb7\x03\xb7\x03\xb7\x03\x00\x17lib/strings/trim.ok:5:9\xb8\x03\xb8\x03\xb8\x03\xb8\x03\xb8\x03\x00\x18li" +
+	"b/strings/trim.ok:6:13\xb9\x03\xb7\x03\xb7\x03\xb7\x03\x00\x18lib/strings/trim" +
+	".ok:10:5\x00\x00\x12strings.TrimPrefix\x02.\xfe\x01\x06\b\x91\x02\x02\x02\x04\x01\x06\x1b\x06\b\x1c\x04\b" +
+	"\b\xb6\x03\x02\x02\b\x01\n3\x01\n3\x01\x02\n\x02\xfe\x01\x03.\x03\x02\xfe\x01\x04.\x02\x00\x00\x00\x00\x06\x00\x18lib/strings/tr" +
+	"im.ok:33:5\xbc\x03\x00\x18lib/strings/trim.ok:34:9\xbd\x03\xbd\x03\x00\x18lib/" +
+	"strings/trim.ok:37:5\x00\xb2\x03\x02.\xb0\x03\x04\b\xd0\x02\x01\x02\x01\x06\b\xb1\x03\x02\x06\x04\x01\b\b\xd0\x02\x01\b" +
+	"\x01\n3\x01\n\n\x02\xb0\x03\x03.\x03\x02\xb0\x03\x04.\x02\x00\x00\x00\x00\x04\x00\x18lib/strings/trim.ok:16:" +
+	"5\xbf\x03\xbf\x03\xbf\x03\x00\x00\x12strings.TrimSuffix\x02.\x9d\x02\x05\b\xd0\x02\x01\x02\x01\x06\b\xd0\x02\x01\x04\x01\b\b" +
+	"\xbb\x03\x02\x06\b\x01\n\b\xd0\x02\x01\n\x01\f3\x01\f\f\x02.\x03\x9d\x02\x03\x02.\x02\x9d\x02\x04\x00\x00\x00\x00\x05\x00\x18lib/strings" +
+	"/trim.ok:48:5\xc1\x03\xc1\x03\xc1\x03\xc1\x03\x00\xb2\x02\x02\xa3\x03\x00\x01b\x04\x16\x02\x04\x06\x1b\x06\x043\x01\x023\x01\x04\x06\x02\xa3\x03" +
+	"\a\xc2\x03\a\x02\xa3\x03\x02\xc2\x03\x04\x00\x00\x00\x00\x04\x00\x19lib/strings/index.ok:49:5\xc3\x03\x00\x19l" +
+	"ib/strings/index.ok:50:9\x00\x19lib/strings/index.ok:5" +
+	"3:5\x00\xd9\x02\x02\xa3\x03\xc2\x03\x04\x1f\x02\x04\x06\x1b\x06\x043\x01\x023\x01\x04\x06\x02\xa3\x03\a\xc2\x03\a\x02\xa3\x03\x02\xc2\x03\x04\x00\x00\x00\x00\x04\x00\x19l" +
+	"ib/strings/index.ok:40:5\xc6\x03\x00\x19lib/strings/index.ok" +
+	":41:9\x00\x19lib/strings/index.ok:44:5\x00\xb6\x03\x02.\xd3\x02\f\a\x06\x01\x03\x04\x00\x19l" +
+	"ib/strings/trim.ok:53:14\x00\x00\x00\a\b\x00\x06\x1c\x02\n\x1f\x04\n\f\x1b\f\x145\x02\x04\x0e\v\x0e\x10" +
+	"\r\b\x10\b\a\x12\x01\ai\x04\x00\x00\x00\x00\x04\x12\x04\x1a\x023\x01\b\x12\x03\xd3\x02\a\xc8\x02\x03.\x03\x03\xd3\x02\x04\xc8\x02\b.\x02\x00\x00\x00\x00\f\x00\x18" +
+	"lib/strings/trim.ok:53:5\xca\x03\x00\x18lib/strings/trim.ok:" +
+	"54:5\xcb\x03\xcb\x03\x00\x18lib/strings/trim.ok:55:9\xcc\x03\xcc\x03\x00\x18lib/stri" +
+	"ngs/trim.ok:56:9\xcd\x03\xcb\x03\x00\x18lib/strings/trim.ok:59:5\x00L" +
+	"\x02\x02\x01\x02\x03\x01\x02\x00\x15lib/lang/error.ok:2:122\x023\x03*+\x00\x00\x14lib/fs/w" +
+	"rite.ok:14:155\x023\x03.\x03\x00\x00\x14lib/fs/write.ok:19:177\x013\x03\x01" +
+	"\r\x00\x13lib/fs/file.ok:43:1\t\t\x02\x02\x03\f\x03\x01\t\x00\x15lib/fs/errors.o" +
+	"k:16:1\r\r\x02\f\x03>\a\x01\r\x00\x12lib/fs/file.ok:3:1\x1a\x1a\x05\x1e\x03\x1f\a\x1d\a\x1c\a\x1b\v" +
+	"\x01\x1a\x00\x12lib/fs/info.ok:5:1??\x01@\x03\x01h\x00\x12lib/fs/dir.ok:26:" +
+	"1BB\x013\x03\x00\x00\x11lib/fs/dir.ok:3:1  \x02\x02\x03\f\x03\x01 \x00\x14lib/fs/erro" +
+	"rs.ok:2:1FF\x013\x03\x01\r\x00\x13lib/fs/file.ok:37:1\"\"\x02\x02\x03\f\x03\x01\"\x00\x15" +
+	"lib/fs/errors.ok:10:1LL\x013\x03\x01\x00\r[]fs.FileInfo\x00\x13lib/" +
+	"fs/info.ok:20:1NN\x013\x03\x01+\x00\x12lib/fs/read.ok:2:1PP\x013\x03\x01" +
+	"\x03\x00\x12lib/fs/read.ok:7:1RR\x013\x03\x00\x00\x11lib/fs/dir.ok:8:1TT" +
+	"\x013\x03\x00\x00\x12lib/fs/dir.ok:14:1VV\x02W\x03X\x03\x00\x00\x12lib/fs/dir.ok:" +
+	"20:1ZZ\x013\x03\x01\x1a\x00\x13lib/fs/info.ok:14:1\\\\\x00\x01\x03\x00\x12lib/fs/di" +
+	"r.ok:38:1^^\x00\x01\x03\x00\x12lib/fs/dir.ok:32:1``\x023\x03*+\x00\x00\x13lib/" +
+	"fs/write.ok:3:1bb\x023\x03.\x03\x00\x00\x13lib/fs/write.ok:8:1dd\x00\x01" +
+	"\v\x00\x13lib/io/read.ok:13:1ff\x00\x01h\x00\x13lib/io/read.ok:30:1" +
+	"pp\x01.\x03\x00\x00\x14lib/io/write.ok:12:1tt\x00\x01+\x00\x13lib/io/read.o" +
+	"k:18:1jj\x00\x01\x03\x00\x12lib/io/read.ok:7:1ww\x00\x01\x03\x00\x13lib/io/rea" +
+	"d.ok:23:1yy\x01.\x03\x00\x00\x13lib/io/write.ok:2:1{{\x01.\x03\x00\x00\x13lib/" +
+	"io/write.ok:7:1}}\x01~\a\x01\a\x00\x13lib/math/abs.ok:2:1\x83\x01\x83\x01\x01" +
+	"~\a\x01\a\x00\x17lib/math/powers.ok:20:1\x88\x01\x88\x01\x01~\a\x01\a\x00\x18lib/math" +
+	"/rounding.ok:2:1\x94\x01\x94\x01\x01~\a\x01\a\x00\x16lib/math/powers.ok:2:" +
+	"1\x9a\x01\x9a\x01\x01~\a\x01\a\x00\x19lib/math/rounding.ok:16:1\xa5\x01\xa5\x01\x01~\a\x01\a\x00\x13" +
+	"lib/math/log.ok:7:1\xa9\x01\xa9\x01\x01~\a\x01\a\x00\x13lib/math/log.ok:2:" +
+	"1\xab\x01\xab\x01\x02\xac\x01\a\xad\x01\a\x01\a\x00\x17lib/math/powers.ok:10:1\xaf\x01\xaf\x01\x02~\a\xb0\x01" +
+	"\a\x01\a\x00\x19lib/math/rounding.ok:31:1\xbf\x01\xbf\x01\x01~\a\x01\a\x00\x17lib/mat" +
+	"h/powers.ok:15:1\xc2\x01\xc2\x01\x00\x01h\x00\x12lib/os/args.ok:6:1\xc4\x01\xc4\x01\x00" +
+	"\x01\x00\b{}string\x00\x12lib/os/env.ok:15:1\xc6\x01\xc6\x01\x01\xc7\x01\a\x00\x00\x12lib/os" +
+	"/exit.ok:5:1\xc9\x01\xc9\x01\x01\xca\x01\x03\x01\x03\x00\x11lib/os/env.ok:3:1\xcc\x01\xcc\x01\x00\x01\x03" +
+	"\x00\x15lib/os/process.ok:2:1\xce\x01\xce\x01\x00\x01\x03\x00\x15lib/os/process.o" +
+	"k:7:1\xd0\x01\xd0\x01\x00\x01\a\x00\x16lib/os/process.ok:12:1\xd2\x01\xd2\x01\x02\xca\x01\x03\xd3\x01\x03\x00" +
+	"\x00\x12lib/os/env.ok:10:1\xd5\x01\xd5\x01\x02\xd6\x01\xd9\x01\xd7\x01\xd8\x01\x01\xd8\x01\x00\x18lib/reflec" +
+	"t/call.ok:16:1\xdb\x01\xdb\x01\x02\xdc\x01\xd9\x01\xdd\x01\xd9\x01\x01\xd9\x01\x00\x17lib/reflect/get." +
+	"ok:15:1\xdf\x01\xdf\x01\x01\xd3\x01\xd9\x01\x01\x03\x00\x1dlib/reflect/interface.ok:10:" +
+	"1\xe1\x01\xe1\x01\x01\xd3\x01\xd9\x01\x01\x03\x00\x17lib/reflect/kind.ok:3:1\xf7\x01\xf7\x01\x01\xd3\x01\xd9\x01\x01\a" +
+	"\x00\x16lib/reflect/len.ok:3:1\xf9\x01\xf9\x01\x01\xdc\x01\xd9\x01\x01h\x00\x18lib/reflect" +
+	"/props.ok:3:1\xfb\x01\xfb\x01\x03\xdc\x01\xd9\x01\xdd\x01\xd9\x01\xd3\x01\xd9\x01\x01\xd9\x01\x00\x17lib/reflect/s" +
+	"et.ok:16:1\xe2\x01\xe2\x01\x01\xd3\x01\xd9\x01\x01\x03\x00\x17lib/reflect/type.ok:8:1\x8b\x02" +
+	"\x8b\x02\x02.\x03\x8c\x02\x03\x01\v\x00\x1blib/strings/contains.ok:2:1\x91\x02\x91\x02\x02.\x03\xfe\x01" +
+	"\x03\x01\v\x00\x1blib/strings/contains.ok:7:1\x9c\x02\x9c\x02\x02.\x03\x9d\x02\x03\x01\v\x00\x1cli" +
+	"b/strings/contains.ok:22:1\x8d\x02\x8d\x02\x02.\x03\x8c\x02\x03\x01\a\x00\x18lib/stri" +
+	"ngs/index.ok:2:1\xae\x02\xae\x02\x03.\x03\x8c\x02\x03\xb0\x02\a\x01\a\x00\x19lib/strings/ind" +
+	"ex.ok:17:1\xc3\x02\xc3\x02\x02\xc4\x02h\xc5\x02\x03\x01\x03\x00\x17lib/strings/join.ok:4:1" +
+	"\xcf\x02\xcf\x02\x02.\x03\x8c\x02\x03\x01\a\x00\x19lib/strings/index.ok:57:1\xd8\x02\xd8\x02\x03.\x03\x8c\x02" +
+	"\x03\xb0\x02\a\x01\a\x00\x19lib/strings/index.ok:76:1\xe2\x02\xe2\x02\x02\xe3\x02\x03\xe4\x02\a\x01\x03\x00\x19" +
+	"lib/strings/repeat.ok:3:1\xeb\x02\xeb\x02\x03.\x03\xec\x02\x03\xed\x02\x03\x01\x03\x00\x1alib/st" +
+	"rings/replace.ok:5:1\xd0\x02\xd0\x02\x01.\x03\x01\x03\x00\x1alib/strings/rever" +
+	"se.ok:2:1\xee\x02\xee\x02\x02.\x03\xf7\x02\x03\x01h\x00\x18lib/strings/split.ok:7:1\x8f" +
+	"\x03\x8f\x03\x01.\x03\x01\x03\x00\x17lib/strings/case.ok:4:1\xa1\x03\xa1\x03\x01.\x03\x01\x03\x00\x18lib/" +
+	"strings/case.ok:21:1\xaf\x03\xaf\x03\x02.\x03\xb0\x03\x03\x01\x03\x00\x18lib/strings/tr" +
+	"im.ok:21:1\xb1\x03\xb1\x03\x02.\x03\xb0\x03\x03\x01\x03\x00\x17lib/strings/trim.ok:3:1\xbb" +
+	"\x03\xbb\x03\x02.\x03\xfe\x01\x03\x01\x03\x00\x18lib/strings/trim.ok:32:1\xb2\x03\xb2\x03\x02.\x03\xb0\x03\x03\x01" +
+	"\x03\x00\x18lib/strings/trim.ok:15:1\xc0\x03\xc0\x03\x02.\x03\x9d\x02\x03\x01\x03\x00\x18lib/str" +
+	"ings/trim.ok:47:1\x00\x06\x02\x01\x02\x03\t\x03\x02\x03\n\v\f\x03\r\a\x0e\x0f\x10\x11\f\x03\x12\x13\x14\x15\x16\x17\x18\x19\x1a" +
+	"\x05\x1b\v\x1c\a\x1d\a\x1e\x03\x1f\a \x03\x02\x03!\v\f\x03\"\x03\x02\x03\f\x03#\v\t\x00\x06math.E\x01\a\x00@2.718281" +
+	"828459045235360287471352662497757247093699959574" +
+	"96696763\x00\x19lib/math/constants.ok:1:7\x00\x00\x00\tmath.Ln10" +
+	"\x01\a\x00@2.302585092994045684017991454684364207601101" +
+	"48862877297603332790\x00\x1alib/math/constants.ok:11:8" +
+	"\x00\x00\x00\bmath.Ln2\x01\a\x00A0.693147180559945309417232121458" +
+	"176568075500134360255254120680009\x00\x1alib/math/cons" +
+	"tants.ok:10:8\x00\x00\x00\bmath.Phi\x01\a\x00@1.61803398874989484" +
+	"820458683436563811772030917980576286213544862\x00\x19l" +
+	"ib/math/constants.ok:3:7\x00\x00\x00\amath.Pi\x01\a\x00@3.1415926" +
+	"535897932384626433832795028841971693993751058209" +
+	"7494459\x00\x19lib/math/constants.ok:2:7\x00\x00\x00\nmath.Sqrt2" +
+	"\x01\a\x00@1.414213562373095048801688724209698078569671" +
+	"87537694807317667974\x00\x1alib/math/constants.ok:5:11" +
+	"\x00\x00\x00\nmath.SqrtE\x01\a\x00@1.6487212707001281468486507878" +
+	"1416357165377610071014801157507931\x00\x1alib/math/con" +
+	"stants.ok:6:11\x00\x00\x00\fmath.SqrtPhi\x01\a\x00@1.272019649514" +
+	"068964252422461737491491715608041840096248616640" +
+	"38\x00\x1alib/math/constants.ok:8:11\x00\x00\x00\vmath.SqrtPi\x01\a\x00" +
+	"@1.772453850905516027298167483341145182797549456" +
+	"12238712821380779\x00\x1alib/math/constants.ok:7:11\x00\x00"
@@ -3,7 +3,6 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 )
 
@@ -14,11 +13,7 @@ type Log struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Log) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralNumber(
-		number.Log(
-			number.NewNumber(vm.Get(ins.X).Value),
-		).String(),
-	))
+	vm.Set(ins.Result, NewDecimal(number.Log(vm.Get(ins.X).Number)))
 
 	return nil
 }
@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/number"
 )
 
 // MapAlloc allocates a map of fixed size.
@@ -15,16 +12,12 @@ type MapAlloc struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *MapAlloc) Execute(_ *int, vm *VM) error {
-	size := number.Int64(number.NewNumber(vm.Get(ins.Size).Value))
+	size := vm.Get(ins.Size).Int()
 
-	vm.Set(ins.Result, &ast.Literal{
+	vm.Set(ins.Result, &Value{
 		Kind: ins.Kind,
-
-		// Array must also be allocated because it will contain the keys for the
-		// map.
-		Array: make([]*ast.Literal, 0, size),
-
-		Map: make(map[string]*ast.Literal, size),
+		Map:  make(map[string]*Value, size),
+		Keys: make([]string, 0, size),
 	})
 
 	return nil
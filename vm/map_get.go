@@ -11,7 +11,7 @@ type MapGet struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *MapGet) Execute(_ *int, vm *VM) error {
-	key := vm.Get(ins.Key).Value
+	key := vm.Get(ins.Key).Text
 	vm.Set(ins.Result, vm.Get(ins.Map).Map[key])
 
 	return nil
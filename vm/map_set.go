@@ -11,9 +11,10 @@ type MapSet struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *MapSet) Execute(_ *int, vm *VM) error {
-	key := vm.Get(ins.Key).Value
-	vm.Get(ins.Map).Map[key] = vm.Get(ins.Value)
-	vm.Get(ins.Map).Array = append(vm.Get(ins.Map).Array, vm.Get(ins.Key))
+	key := vm.Get(ins.Key).Text
+	m := vm.Get(ins.Map)
+	m.Map[key] = vm.Get(ins.Value)
+	m.Keys = append(m.Keys, key)
 
 	return nil
 }
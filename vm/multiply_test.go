@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 	"github.com/elliotchance/ok/vm"
 
@@ -19,16 +17,17 @@ func TestMultiply_Execute(t *testing.T) {
 		"success": {"1.2200", "4.7", "5.734"},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralNumber(test.left),
-				"1": asttest.NewLiteralNumber(test.right),
+			registers := []*vm.Value{
+				vm.NewNumber(test.left),
+				vm.NewNumber(test.right),
+				nil,
 			}
-			ins := &vm.Multiply{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Multiply{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			actual := number.NewNumber(registers[ins.Result].Value)
+			actual := registers[ins.Result].Number
 			assert.Equal(t, test.expected, number.Format(actual, -1))
 		})
 	}
@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
-	"github.com/elliotchance/ok/number"
 )
 
 // NextArray is used to tick an array iterator forward.
@@ -19,17 +16,20 @@ type NextArray struct {
 // Execute implements the Instruction interface for the VM.
 func (ins *NextArray) Execute(_ *int, vm *VM) error {
 	array := vm.Get(ins.Array).Array
-	pos := number.Int(number.NewNumber(vm.Get(ins.Cursor).Value))
+	pos := vm.Get(ins.Cursor).Int()
 	hasMore := pos < len(array)
-	vm.Set(ins.Result, asttest.NewLiteralBool(hasMore))
+	vm.Set(ins.Result, NewBool(hasMore))
 	if hasMore {
-		// Key may be optionally set.
-		if ins.KeyResult != "" {
-			vm.Set(ins.KeyResult, asttest.NewLiteralNumber(fmt.Sprintf("%d", pos)))
+		// Key and value may be optionally set.
+		if ins.KeyResult != NoRegister {
+			vm.Set(ins.KeyResult, NewInt(pos))
+		}
+
+		if ins.ValueResult != NoRegister {
+			vm.Set(ins.ValueResult, array[pos])
 		}
 
-		vm.Set(ins.ValueResult, array[pos])
-		vm.Set(ins.Cursor, asttest.NewLiteralNumber(fmt.Sprintf("%d", pos+1)))
+		vm.Set(ins.Cursor, NewInt(pos+1))
 	}
 
 	return nil
@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
-	"github.com/elliotchance/ok/number"
 )
 
 // NextMap is used to tick a map iterator forward.
@@ -18,21 +15,22 @@ type NextMap struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *NextMap) Execute(_ *int, vm *VM) error {
-	array := vm.Get(ins.Map).Array
-	pos := number.Int(number.NewNumber(vm.Get(ins.Cursor).Value))
+	m := vm.Get(ins.Map)
+	pos := vm.Get(ins.Cursor).Int()
 
-	hasMore := pos < len(array)
-	vm.Set(ins.Result, asttest.NewLiteralBool(hasMore))
+	hasMore := pos < len(m.Keys)
+	vm.Set(ins.Result, NewBool(hasMore))
 	if hasMore {
-		m := vm.Get(ins.Map).Map
+		// Key and value may be optionally set.
+		if ins.KeyResult != NoRegister {
+			vm.Set(ins.KeyResult, NewString(m.Keys[pos]))
+		}
 
-		// Key may be optionally set.
-		if ins.KeyResult != "" {
-			vm.Set(ins.KeyResult, array[pos])
+		if ins.ValueResult != NoRegister {
+			vm.Set(ins.ValueResult, m.Map[m.Keys[pos]])
 		}
 
-		vm.Set(ins.ValueResult, m[array[pos].Value])
-		vm.Set(ins.Cursor, asttest.NewLiteralNumber(fmt.Sprintf("%d", pos+1)))
+		vm.Set(ins.Cursor, NewInt(pos+1))
 	}
 
 	return nil
@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
-	"github.com/elliotchance/ok/number"
 )
 
 // NextString is used to tick a string iterator forward.
@@ -18,18 +15,21 @@ type NextString struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *NextString) Execute(_ *int, vm *VM) error {
-	str := []rune(vm.Get(ins.Str).Value)
-	pos := number.Int(number.NewNumber(vm.Get(ins.Cursor).Value))
+	str := []rune(vm.Get(ins.Str).Text)
+	pos := vm.Get(ins.Cursor).Int()
 	hasMore := pos < len(str)
-	vm.Set(ins.Result, asttest.NewLiteralBool(hasMore))
+	vm.Set(ins.Result, NewBool(hasMore))
 	if hasMore {
-		// Key may be optionally set.
-		if ins.KeyResult != "" {
-			vm.Set(ins.KeyResult, asttest.NewLiteralNumber(fmt.Sprintf("%d", pos)))
+		// Key and value may be optionally set.
+		if ins.KeyResult != NoRegister {
+			vm.Set(ins.KeyResult, NewInt(pos))
+		}
+
+		if ins.ValueResult != NoRegister {
+			vm.Set(ins.ValueResult, NewChar(str[pos]))
 		}
 
-		vm.Set(ins.ValueResult, asttest.NewLiteralChar(str[pos]))
-		vm.Set(ins.Cursor, asttest.NewLiteralNumber(fmt.Sprintf("%d", pos+1)))
+		vm.Set(ins.Cursor, NewInt(pos+1))
 	}
 
 	return nil
@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
 )
 
 // Not is a logical NOT of a bool.
@@ -13,8 +11,8 @@ type Not struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Not) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralBool(
-		!(vm.Get(ins.Left).Value == "true"),
+	vm.Set(ins.Result, NewBool(
+		!vm.Get(ins.Left).Bool,
 	))
 
 	return nil
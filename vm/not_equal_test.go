@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -12,87 +10,90 @@ import (
 
 func TestNotEqual_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		left, right *ast.Literal
+		left, right *vm.Value
 		expected    string
 	}{
 		"false-false": {
-			asttest.NewLiteralBool(false), asttest.NewLiteralBool(false),
+			vm.NewBool(false), vm.NewBool(false),
 			"false"},
 		"false-true": {
-			asttest.NewLiteralBool(false), asttest.NewLiteralBool(true),
+			vm.NewBool(false), vm.NewBool(true),
 			"true",
 		},
 		"a-a": {
-			asttest.NewLiteralChar('a'), asttest.NewLiteralChar('a'),
+			vm.NewChar('a'), vm.NewChar('a'),
 			"false"},
 		"b-B": {
-			asttest.NewLiteralChar('b'), asttest.NewLiteralChar('B'),
+			vm.NewChar('b'), vm.NewChar('B'),
 			"true",
 		},
 		"d1-d1": {
-			asttest.NewLiteralData([]byte("d1")), asttest.NewLiteralData([]byte("d1")),
+			vm.NewData([]byte("d1")), vm.NewData([]byte("d1")),
 			"false"},
 		"d1-d2": {
-			asttest.NewLiteralData([]byte("d1")), asttest.NewLiteralData([]byte("d2")),
+			vm.NewData([]byte("d1")), vm.NewData([]byte("d2")),
 			"true",
 		},
 		"foo-foo": {
-			asttest.NewLiteralString("foo"), asttest.NewLiteralString("foo"),
+			vm.NewString("foo"), vm.NewString("foo"),
 			"false"},
 		"foo-bar": {
-			asttest.NewLiteralString("foo"), asttest.NewLiteralString("bar"),
+			vm.NewString("foo"), vm.NewString("bar"),
 			"true",
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.left,
-				"1": test.right,
+			registers := []*vm.Value{
+				test.left,
+				test.right,
+				nil,
 			}
-			ins := &vm.NotEqual{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.NotEqual{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestNotEqualNumber_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		left, right *ast.Literal
+		left, right *vm.Value
 		expected    string
 	}{
 		"1-1.0": {
-			asttest.NewLiteralNumber("1"), asttest.NewLiteralNumber("1.0"),
+			vm.NewNumber("1"), vm.NewNumber("1.0"),
 			"false"},
 		"1-1.1": {
-			asttest.NewLiteralNumber("1"), asttest.NewLiteralNumber("1.1"),
+			vm.NewNumber("1"), vm.NewNumber("1.1"),
 			"true",
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": test.left,
-				"1": test.right,
+			registers := []*vm.Value{
+				test.left,
+				test.right,
+				nil,
+				nil,
 			}
-			ins := &vm.NotEqualNumber{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.NotEqualNumber{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].String())
 		})
 	}
 }
 
 func TestNotEqual_String(t *testing.T) {
-	ins := &vm.NotEqual{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.NotEqual{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 != $2", ins.String())
 }
 
 func TestNotEqualNumber_String(t *testing.T) {
-	ins := &vm.NotEqualNumber{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.NotEqualNumber{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 != $2", ins.String())
 }
@@ -11,7 +11,7 @@ type ParentScope struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *ParentScope) Execute(_ *int, vm *VM) error {
-	vm.Stack[len(vm.Stack)-1][ins.X].Map = vm.Stack[len(vm.Stack)-1][StateRegister].Map
+	vm.Get(ins.X).Map = vm.Get(StateRegister).Map
 
 	return nil
 }
@@ -3,7 +3,6 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 )
 
@@ -14,11 +13,8 @@ type Power struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Power) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralNumber(
-		number.Pow(
-			number.NewNumber(vm.Get(ins.Base).Value),
-			number.NewNumber(vm.Get(ins.Power).Value),
-		).String(),
+	vm.Set(ins.Result, NewDecimal(
+		number.Pow(vm.Get(ins.Base).Number, vm.Get(ins.Power).Number),
 	))
 
 	return nil
@@ -16,7 +16,7 @@ func (ins *Print) Execute(_ *int, vm *VM) error {
 			fmt.Fprint(vm.Stdout, " ")
 		}
 
-		fmt.Fprint(vm.Stdout, renderLiteral(vm.Get(register), false))
+		fmt.Fprint(vm.Stdout, renderValue(vm.Get(register), false))
 	}
 
 	fmt.Fprint(vm.Stdout, "\n")
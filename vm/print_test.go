@@ -2,11 +2,8 @@ package vm_test
 
 import (
 	"bytes"
-	"fmt"
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -14,104 +11,104 @@ import (
 
 func TestPrint_Execute(t *testing.T) {
 	for testName, test := range map[string]struct {
-		values         []*ast.Literal
+		values         []*vm.Value
 		expectedStdout string
 	}{
 		"no-args": {nil, "\n"},
 		"true": {
-			[]*ast.Literal{{Kind: "bool", Value: "true"}},
+			[]*vm.Value{vm.NewBool(true)},
 			"true\n",
 		},
 		"false": {
-			[]*ast.Literal{{Kind: "bool", Value: "false"}},
+			[]*vm.Value{vm.NewBool(false)},
 			"false\n",
 		},
 		"char": {
-			[]*ast.Literal{{Kind: "char", Value: "#"}},
+			[]*vm.Value{vm.NewChar('#')},
 			"#\n",
 		},
 		"data": {
-			[]*ast.Literal{{Kind: "data", Value: "abc"}},
+			[]*vm.Value{vm.NewData([]byte("abc"))},
 			"abc\n",
 		},
 		"number": {
-			[]*ast.Literal{{Kind: "number", Value: "1.23"}},
+			[]*vm.Value{vm.NewNumber("1.23")},
 			"1.23\n",
 		},
 		"string": {
-			[]*ast.Literal{{Kind: "string", Value: "foo bar"}},
+			[]*vm.Value{vm.NewString("foo bar")},
 			"foo bar\n",
 		},
 		"multiple-args": {
-			[]*ast.Literal{
-				{Kind: "string", Value: "foo"},
-				{Kind: "number", Value: "123"},
+			[]*vm.Value{
+				vm.NewString("foo"),
+				vm.NewNumber("123"),
 			},
 			"foo 123\n",
 		},
 		"number-array": {
-			[]*ast.Literal{
+			[]*vm.Value{
 				{
 					Kind: "[]number",
-					Array: []*ast.Literal{
-						asttest.NewLiteralNumber("123"),
-						asttest.NewLiteralNumber("456"),
-						asttest.NewLiteralNumber("789"),
+					Array: []*vm.Value{
+						vm.NewNumber("123"),
+						vm.NewNumber("456"),
+						vm.NewNumber("789"),
 					},
 				},
 			},
 			"[123, 456, 789]\n",
 		},
 		"any-array": {
-			[]*ast.Literal{
+			[]*vm.Value{
 				{
 					Kind: "[]any",
-					Array: []*ast.Literal{
-						asttest.NewLiteralBool(true),
-						asttest.NewLiteralChar('a'),
-						asttest.NewLiteralData([]byte("data")),
-						asttest.NewLiteralNumber("123"),
-						asttest.NewLiteralString("789"),
+					Array: []*vm.Value{
+						vm.NewBool(true),
+						vm.NewChar('a'),
+						vm.NewData([]byte("data")),
+						vm.NewNumber("123"),
+						vm.NewString("789"),
 					},
 				},
 			},
 			"[true, \"a\", \"data\", 123, \"789\"]\n",
 		},
 		"number-map": {
-			[]*ast.Literal{
+			[]*vm.Value{
 				{
 					Kind: "{}number",
-					Map: map[string]*ast.Literal{
-						"a": asttest.NewLiteralNumber("123"),
-						"b": asttest.NewLiteralNumber("456"),
-						"c": asttest.NewLiteralNumber("789"),
+					Map: map[string]*vm.Value{
+						"a": vm.NewNumber("123"),
+						"b": vm.NewNumber("456"),
+						"c": vm.NewNumber("789"),
 					},
 				},
 			},
 			"{\"a\": 123, \"b\": 456, \"c\": 789}\n",
 		},
 		"any-map": {
-			[]*ast.Literal{
+			[]*vm.Value{
 				{
 					Kind: "{}any",
-					Map: map[string]*ast.Literal{
-						"a": asttest.NewLiteralBool(true),
-						"b": asttest.NewLiteralChar('a'),
-						"c": asttest.NewLiteralData([]byte("data")),
-						"d": asttest.NewLiteralNumber("123"),
-						"e": asttest.NewLiteralString("789"),
+					Map: map[string]*vm.Value{
+						"a": vm.NewBool(true),
+						"b": vm.NewChar('a'),
+						"c": vm.NewData([]byte("data")),
+						"d": vm.NewNumber("123"),
+						"e": vm.NewString("789"),
 					},
 				},
 			},
 			"{\"a\": true, \"b\": \"a\", \"c\": \"data\", \"d\": 123, \"e\": \"789\"}\n",
 		},
 		"Person": {
-			[]*ast.Literal{
+			[]*vm.Value{
 				{
 					Kind: "Person",
-					Map: map[string]*ast.Literal{
-						"Foo": asttest.NewLiteralNumber("123"),
-						"bar": asttest.NewLiteralNumber("456"),
+					Map: map[string]*vm.Value{
+						"Foo": vm.NewNumber("123"),
+						"bar": vm.NewNumber("456"),
 					},
 				},
 			},
@@ -119,12 +116,10 @@ func TestPrint_Execute(t *testing.T) {
 		},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{}
+			registers := test.values
 			var arguments []vm.Register
-			for i, value := range test.values {
-				register := vm.Register(fmt.Sprintf("%d", i))
-				registers[register] = value
-				arguments = append(arguments, register)
+			for i := range test.values {
+				arguments = append(arguments, vm.Register(i))
 			}
 
 			buf := bytes.NewBuffer(nil)
@@ -132,7 +127,7 @@ func TestPrint_Execute(t *testing.T) {
 				Arguments: arguments,
 			}
 			vm := &vm.VM{
-				Stack:  []map[vm.Register]*ast.Literal{registers},
+				Stack:  []*vm.Scope{{Registers: registers}},
 				Stdout: buf,
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
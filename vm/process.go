@@ -0,0 +1,71 @@
+package vm
+
+import (
+	"fmt"
+	"os"
+)
+
+// Getwd returns the current working directory.
+type Getwd struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Getwd) Execute(_ *int, vm *VM) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		vm.Raise(err.Error())
+
+		return nil
+	}
+
+	vm.Set(ins.Result, NewString(dir))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Getwd) String() string {
+	return fmt.Sprintf("%s = os.Getwd()", ins.Result)
+}
+
+// Hostname returns the host name of the machine.
+type Hostname struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Hostname) Execute(_ *int, vm *VM) error {
+	name, err := os.Hostname()
+	if err != nil {
+		vm.Raise(err.Error())
+
+		return nil
+	}
+
+	vm.Set(ins.Result, NewString(name))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Hostname) String() string {
+	return fmt.Sprintf("%s = os.Hostname()", ins.Result)
+}
+
+// Pid returns the process ID of the program.
+type Pid struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Pid) Execute(_ *int, vm *VM) error {
+	vm.Set(ins.Result, NewInt(os.Getpid()))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Pid) String() string {
+	return fmt.Sprintf("%s = os.Pid()", ins.Result)
+}
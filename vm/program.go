@@ -0,0 +1,81 @@
+package vm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/elliotchance/ok/ast"
+)
+
+// Program contains everything needed to run a compiled package without its
+// source code. It mirrors compiler.Compiled.
+type Program struct {
+	// Package is the name of the package.
+	Package string
+
+	Funcs map[string]*CompiledFunc
+
+	// FuncDefs only contain the signature of each function. The statements
+	// are not retained.
+	FuncDefs map[string]*ast.Func
+
+	Tests      []*CompiledTest
+	Interfaces map[string]map[string]string
+	Constants  map[string]*ast.Literal
+}
+
+// Encode writes the program as bytecode. It can be read back with
+// DecodeProgram.
+func (p *Program) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(bytecodeMagic); err != nil {
+		return err
+	}
+
+	e := &encoder{w: bw, strings: map[string]int{}}
+	e.uint(BytecodeVersion)
+	e.program(p)
+	if e.err != nil {
+		return e.err
+	}
+
+	return bw.Flush()
+}
+
+// DecodeProgram reads bytecode that was written with Program.Encode. The
+// bytecode must be from the same BytecodeVersion.
+func DecodeProgram(r io.Reader) (*Program, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(bytecodeMagic))
+	if _, err := io.ReadFull(br, magic); err != nil ||
+		string(magic) != bytecodeMagic {
+		return nil, fmt.Errorf("not ok bytecode")
+	}
+
+	d := &decoder{r: br}
+	if version := d.uint(); d.err == nil && version != BytecodeVersion {
+		return nil, fmt.Errorf(
+			"bytecode version %d is not supported, expected version %d",
+			version, BytecodeVersion)
+	}
+
+	p := d.program()
+	if d.err != nil {
+		return nil, fmt.Errorf("corrupt bytecode: %v", d.err)
+	}
+
+	if p.FuncDefs == nil {
+		p.FuncDefs = map[string]*ast.Func{}
+	}
+
+	if p.Interfaces == nil {
+		p.Interfaces = map[string]map[string]string{}
+	}
+
+	if p.Constants == nil {
+		p.Constants = map[string]*ast.Literal{}
+	}
+
+	return p, nil
+}
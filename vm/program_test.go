@@ -0,0 +1,157 @@
+package vm_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/ast/asttest"
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/parser"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgram_Encode(t *testing.T) {
+	t.Run("lib", func(t *testing.T) {
+		// The standard library uses most of the instructions.
+		funcs := map[string]*vm.CompiledFunc{}
+		funcDefs := map[string]*ast.Func{}
+		for name, def := range vm.Lib {
+			funcs[name] = def.CompiledFunc
+
+			// Private functions do not have a definition.
+			if def.FuncDef != nil {
+				funcDefs[name] = def.FuncDef
+			}
+		}
+
+		program := &vm.Program{
+			Package:    "lib",
+			Funcs:      funcs,
+			FuncDefs:   funcDefs,
+			Interfaces: vm.Interfaces,
+			Constants:  vm.Constants,
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, program.Encode(&buf))
+		encoded := buf.String()
+
+		decoded, err := vm.DecodeProgram(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, funcDefs, decoded.FuncDefs)
+		assert.Equal(t, vm.Interfaces, decoded.Interfaces)
+		assert.Equal(t, vm.Constants, decoded.Constants)
+
+		// Empty slices and maps are decoded as nil so the functions cannot
+		// be compared directly.
+		buf.Reset()
+		require.NoError(t, decoded.Encode(&buf))
+		assert.Equal(t, encoded, buf.String())
+	})
+
+	t.Run("tests", func(t *testing.T) {
+		program := &vm.Program{
+			Funcs: map[string]*vm.CompiledFunc{},
+			Tests: []*vm.CompiledTest{
+				{
+					TestName: "a test",
+					CompiledFunc: &vm.CompiledFunc{
+						Instructions: []vm.Instruction{
+							&vm.Assign{
+								VariableName: 1,
+								Value:        asttest.NewLiteralNumber("1.5"),
+							},
+						},
+						Registers: 1,
+						ErrorScopes: []*vm.ErrorScope{
+							{
+								Start: 0,
+								End:   1,
+								On: []*vm.On{
+									{Type: "Error", Err: 1, To: 1},
+								},
+							},
+						},
+						Positions: []string{"a.ok:1:2"},
+					},
+				},
+			},
+			FuncDefs:   map[string]*ast.Func{},
+			Interfaces: map[string]map[string]string{},
+			Constants:  map[string]*ast.Literal{},
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, program.Encode(&buf))
+
+		decoded, err := vm.DecodeProgram(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, program, decoded)
+	})
+
+	t.Run("run", func(t *testing.T) {
+		p := parser.ParseString(`
+func Rectangle(width, height number) Rectangle {
+    func Area() number {
+        return ^width * ^height
+    }
+}
+
+func main() {
+    try {
+        raise Error("caught")
+    } on Error {
+        r = Rectangle(10, 5)
+        print("area: { r.Area() }")
+    }
+    for i in [1, 2] {
+        print(i + 0.5)
+    }
+}
+`, "a.ok")
+		require.Nil(t, p.Errors())
+		f, errs := compiler.CompileFile(p.File, p.Interfaces, p.Constants)
+		require.Nil(t, errs)
+
+		var buf bytes.Buffer
+		require.NoError(t, (&vm.Program{
+			Package:    "pkg",
+			Funcs:      f.Funcs,
+			Interfaces: f.Interfaces,
+			Constants:  f.Constants,
+		}).Encode(&buf))
+
+		decoded, err := vm.DecodeProgram(&buf)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		m := vm.NewVM(decoded.Funcs, nil, decoded.Interfaces, decoded.Package)
+		m.Stdout = &out
+		require.NoError(t, m.Run())
+		assert.Equal(t, "area: 50\n1.5\n2.5\n", out.String())
+	})
+
+	t.Run("not-bytecode", func(t *testing.T) {
+		_, err := vm.DecodeProgram(bytes.NewBufferString("foo"))
+		assert.EqualError(t, err, "not ok bytecode")
+	})
+
+	t.Run("version", func(t *testing.T) {
+		_, err := vm.DecodeProgram(bytes.NewBufferString("okc\x7f"))
+		assert.EqualError(t, err, fmt.Sprintf(
+			"bytecode version 127 is not supported, expected version %d",
+			vm.BytecodeVersion))
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&vm.Program{Package: "pkg"}).Encode(&buf))
+
+		_, err := vm.DecodeProgram(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+		assert.EqualError(t, err, "corrupt bytecode: EOF")
+	})
+}
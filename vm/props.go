@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"sort"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler/kind"
 	"github.com/elliotchance/ok/util"
 )
@@ -20,18 +18,18 @@ func (ins *Props) Execute(_ *int, vm *VM) error {
 	object := vm.Get(ins.Object)
 
 	if kind.IsObject(object.Kind) {
-		var props []*ast.Literal
+		var props []*Value
 		for prop := range object.Map {
 			if util.IsPublic(prop) {
-				props = append(props, asttest.NewLiteralString(prop))
+				props = append(props, NewString(prop))
 			}
 		}
 
 		sort.Slice(props, func(i, j int) bool {
-			return props[i].Value < props[j].Value
+			return props[i].Text < props[j].Text
 		})
 
-		vm.Set(ins.Result, &ast.Literal{
+		vm.Set(ins.Result, &Value{
 			Kind:  "[]string",
 			Array: props,
 		})
@@ -3,28 +3,24 @@ package vm
 import (
 	"fmt"
 	"strings"
-	"unicode"
 )
 
-// Register is the name of a register. At the moment variable names can also be
-// used as registers, but that will be removed in the future. When that happens
-// these can be refactored into an int.
-type Register string
+// Register is the position of a value in the registers of a function call.
+// Temporary values and variables are both stored in registers. See
+// CompiledFunc.Upvalues for variables that are shared with other functions.
+type Register int
 
-// String returns either "rX" or the name of the variable. See Register for
-// details.
+// NoRegister is used when there is no register needed, like how KeyResult is
+// optional in NextArray.
+const NoRegister Register = -1
+
+// String returns the register as "$X".
 func (r Register) String() string {
-	// An empty register is sometimes used when there is no register need, like
-	// how Key is optional in NextArray.
-	if r == "" {
+	if r == NoRegister {
 		return "_"
 	}
 
-	if unicode.IsDigit([]rune(r)[0]) {
-		return "$" + string(r)
-	}
-
-	return string(r)
+	return fmt.Sprintf("$%d", int(r))
 }
 
 // Registers is multiple sequential registers. It might represents function
@@ -3,7 +3,6 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/number"
 )
 
@@ -16,16 +15,16 @@ type Remainder struct {
 // Execute implements the Instruction interface for the VM.
 func (ins *Remainder) Execute(_ *int, vm *VM) error {
 	divide, err := number.Remainder(
-		number.NewNumber(vm.Get(ins.Left).Value),
-		number.NewNumber(vm.Get(ins.Right).Value),
+		vm.Get(ins.Left).Number,
+		vm.Get(ins.Right).Number,
 	)
 	if err != nil {
 		// TODO(elliot): This needs to be the same precision of zero.
-		vm.Set(ins.Result, asttest.NewLiteralNumber("0"))
+		vm.Set(ins.Result, NewInt(0))
 		return err
 	}
 
-	vm.Set(ins.Result, asttest.NewLiteralNumber(divide.String()))
+	vm.Set(ins.Result, NewDecimal(divide))
 
 	return nil
 }
@@ -4,8 +4,6 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -21,16 +19,17 @@ func TestRemainder_Execute(t *testing.T) {
 		"divide-zero":        {"1.2200", "0", "0", errors.New("division by zero")},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralNumber(test.left),
-				"1": asttest.NewLiteralNumber(test.right),
+			registers := []*vm.Value{
+				vm.NewNumber(test.left),
+				vm.NewNumber(test.right),
+				nil,
 			}
-			ins := &vm.Remainder{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Remainder{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.Equal(t, test.err, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].Number.String())
 		})
 	}
 }
@@ -11,7 +11,14 @@ type Return struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Return) Execute(_ *int, vm *VM) error {
-	vm.Return = ins.Results
+	// The values are copied now because the registers will be gone by the
+	// time the caller receives them.
+	results := make([]*Value, len(ins.Results))
+	for i, result := range ins.Results {
+		results[i] = vm.Get(result)
+	}
+
+	vm.Return = results
 
 	return nil
 }
@@ -0,0 +1,131 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuntimeError is returned when the program stops because of an unhandled
+// error, or because the VM panicked.
+type RuntimeError struct {
+	// Message does not include the stack trace. For example,
+	// "unhandled Error: something went wrong".
+	Message string
+
+	// Stack contains each function that was running when the error happened.
+	// The first element is the innermost function. Only FuncName and Pos are
+	// set.
+	Stack []Frame
+}
+
+// Error implements the error interface.
+func (err *RuntimeError) Error() string {
+	return err.Message
+}
+
+// StackTrace returns the message followed by the position of each function on
+// its own line. For example:
+//
+//	unhandled Error: something went wrong
+//	    main.ok:12:5 in f2
+//	    main.ok:30:9 in main
+func (err *RuntimeError) StackTrace() string {
+	s := err.Message + "\n"
+	for _, frame := range err.Stack {
+		s += "    " + strings.TrimSpace(frame.Pos+" in "+frame.FuncName) + "\n"
+	}
+
+	return s
+}
+
+// stackTrace returns a copy of the current frames without their registers.
+func (vm *VM) stackTrace() []Frame {
+	var stack []Frame
+	for i := len(vm.Frames) - 1; i >= 0; i-- {
+		stack = append(stack, Frame{
+			FuncName: vm.Frames[i].FuncName,
+			Pos:      vm.Frames[i].Pos,
+		})
+	}
+
+	return stack
+}
+
+// unhandledError returns an error if the VM is in an error state that has not
+// been handled. The error state is reset.
+func (vm *VM) unhandledError() error {
+	if vm.ErrType == "" {
+		return nil
+	}
+
+	message := fmt.Sprintf("unhandled %s", vm.ErrType)
+	if vm.ErrValue != nil {
+		if value := vm.ErrValue.Map["Error"]; value != nil {
+			message += ": " + renderValue(value, false)
+		}
+	}
+
+	err := &RuntimeError{
+		Message: message,
+		Stack:   vm.errStack,
+	}
+
+	vm.ErrType = ""
+	vm.ErrValue = nil
+	vm.errStack = nil
+
+	return err
+}
+
+// panicError is used when an instruction panics. It must be called while the
+// frame of the instruction is still on the stack.
+func (vm *VM) panicError(i int, ins []Instruction, r interface{}) error {
+	message := fmt.Sprintf("VM panicked: %v", r)
+
+	// The instruction may have changed i before it panicked.
+	if i >= 0 && i < len(ins) {
+		// i+1 because the first instruction shown in "ok asm" is #1.
+		message = fmt.Sprintf("VM panicked at instruction #%d (%s): %v",
+			i+1, ins[i], r)
+	}
+
+	return &RuntimeError{
+		Message: message,
+		Stack:   vm.stackTrace(),
+	}
+}
+
+// instructionError adds the stack trace to an error returned by an
+// instruction, such as a division by zero. It must be called while the frame of
+// the instruction is still on the stack.
+func (vm *VM) instructionError(err error) error {
+	switch err.(type) {
+	case *RuntimeError, *ExitError:
+		return err
+	}
+
+	return &RuntimeError{
+		Message: err.Error(),
+		Stack:   vm.stackTrace(),
+	}
+}
+
+// errorFailure describes an error that stopped a test. The stack trace of a
+// RuntimeError is included in the details.
+func errorFailure(err error) *AssertionFailure {
+	failure := &AssertionFailure{Message: err.Error()}
+	if err, ok := err.(*RuntimeError); ok {
+		var lines []string
+		for _, frame := range err.Stack {
+			lines = append(lines,
+				strings.TrimSpace(frame.Pos+" in "+frame.FuncName))
+		}
+		failure.Details = strings.Join(lines, "\n")
+
+		if len(err.Stack) > 0 {
+			failure.Pos = err.Stack[0].Pos
+		}
+	}
+
+	return failure
+}
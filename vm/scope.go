@@ -0,0 +1,52 @@
+package vm
+
+// Scope contains the registers for a single function call.
+type Scope struct {
+	Registers []*Value
+
+	// Upvalues is from the CompiledFunc being run.
+	Upvalues []string
+}
+
+func newScope(fn *CompiledFunc) *Scope {
+	return &Scope{
+		Registers: make([]*Value, fn.Registers+1),
+		Upvalues:  fn.Upvalues,
+	}
+}
+
+// Get returns the value of a register, or nil if the register has not been
+// set.
+func (scope *Scope) Get(register Register) *Value {
+	if int(register) < len(scope.Upvalues) && scope.Upvalues[register] != "" {
+		scope, name := scope.upvalue(register)
+
+		return scope[name]
+	}
+
+	return scope.Registers[register]
+}
+
+// Set will set a register.
+func (scope *Scope) Set(register Register, val *Value) {
+	if int(register) < len(scope.Upvalues) && scope.Upvalues[register] != "" {
+		scope, name := scope.upvalue(register)
+		scope[name] = val
+
+		return
+	}
+
+	scope.Registers[register] = val
+}
+
+// upvalue returns the map that holds the upvalue and the name of the
+// variable.
+func (scope *Scope) upvalue(register Register) (map[string]*Value, string) {
+	name := scope.Upvalues[register]
+	state := scope.Registers[StateRegister].Map
+	if name[0] == '^' {
+		return state[StateKey].Map, name[1:]
+	}
+
+	return state, name
+}
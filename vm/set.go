@@ -3,9 +3,7 @@ package vm
 import (
 	"fmt"
 
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/compiler/kind"
-	"github.com/elliotchance/ok/number"
 	"github.com/elliotchance/ok/util"
 )
 
@@ -34,7 +32,7 @@ func (ins *Set) Execute(_ *int, vm *VM) error {
 			return nil
 		}
 
-		index := number.Int(number.NewNumber(prop.Value))
+		index := prop.Int()
 		if index < 0 || index >= len(object.Array) {
 			vm.Raise(fmt.Sprintf("index out of bounds [%d] with length %d",
 				index, len(object.Array)))
@@ -43,7 +41,7 @@ func (ins *Set) Execute(_ *int, vm *VM) error {
 		}
 
 		object.Array[index] = value
-		vm.Set(ins.Result, asttest.NewLiteralBool(true))
+		vm.Set(ins.Result, NewBool(true))
 
 		return nil
 
@@ -54,8 +52,8 @@ func (ins *Set) Execute(_ *int, vm *VM) error {
 			return nil
 		}
 
-		object.Map[prop.Value] = value
-		vm.Set(ins.Result, asttest.NewLiteralBool(true))
+		object.Map[prop.Text] = value
+		vm.Set(ins.Result, NewBool(true))
 
 		return nil
 
@@ -66,14 +64,14 @@ func (ins *Set) Execute(_ *int, vm *VM) error {
 			return nil
 		}
 
-		if !util.IsPublic(prop.Value) {
-			vm.Raise("cannot mutate private property: " + prop.Value)
+		if !util.IsPublic(prop.Text) {
+			vm.Raise("cannot mutate private property: " + prop.Text)
 
 			return nil
 		}
 
-		object.Map[prop.Value] = value
-		vm.Set(ins.Result, asttest.NewLiteralBool(true))
+		object.Map[prop.Text] = value
+		vm.Set(ins.Result, NewBool(true))
 
 		return nil
 	}
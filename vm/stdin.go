@@ -0,0 +1,117 @@
+package vm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// stdinReader returns the buffered reader for Stdin. It is created again if
+// Stdin has been replaced.
+func (vm *VM) stdinReader() *bufio.Reader {
+	if vm.stdin == nil || vm.stdinSource != vm.Stdin {
+		vm.stdin = bufio.NewReader(vm.Stdin)
+		vm.stdinSource = vm.Stdin
+	}
+
+	return vm.stdin
+}
+
+// ReadLine reads the next line from stdin, without the line ending. The result
+// is empty at the end of the input.
+type ReadLine struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *ReadLine) Execute(_ *int, vm *VM) error {
+	line, err := vm.stdinReader().ReadString('\n')
+	if err != nil && err != io.EOF {
+		vm.Raise(err.Error())
+
+		return nil
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	vm.Set(ins.Result, NewString(line))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *ReadLine) String() string {
+	return fmt.Sprintf("%s = io.ReadLine()", ins.Result)
+}
+
+// ReadAll reads everything that is left on stdin.
+type ReadAll struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *ReadAll) Execute(_ *int, vm *VM) error {
+	data, err := ioutil.ReadAll(vm.stdinReader())
+	if err != nil {
+		vm.Raise(err.Error())
+
+		return nil
+	}
+
+	vm.Set(ins.Result, NewData(data))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *ReadAll) String() string {
+	return fmt.Sprintf("%s = io.ReadAll()", ins.Result)
+}
+
+// EOF is true when there is nothing left to read from stdin.
+type EOF struct {
+	Result Register
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *EOF) Execute(_ *int, vm *VM) error {
+	_, err := vm.stdinReader().Peek(1)
+	vm.Set(ins.Result, NewBool(err != nil))
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *EOF) String() string {
+	return fmt.Sprintf("%s = io.EOF()", ins.Result)
+}
+
+// Write outputs a string to stdout, or stderr if Stderr is true. Unlike Print,
+// a new line is not added.
+type Write struct {
+	Text   Register
+	Stderr bool
+}
+
+// Execute implements the Instruction interface for the VM.
+func (ins *Write) Execute(_ *int, vm *VM) error {
+	w := vm.Stdout
+	if ins.Stderr {
+		w = vm.Stderr
+	}
+
+	fmt.Fprint(w, vm.Get(ins.Text).Text)
+
+	return nil
+}
+
+// String is the human-readable description of the instruction.
+func (ins *Write) String() string {
+	if ins.Stderr {
+		return fmt.Sprintf("io.WriteError(%s)", ins.Text)
+	}
+
+	return fmt.Sprintf("io.Write(%s)", ins.Text)
+}
@@ -0,0 +1,136 @@
+package vm_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadLine_Execute(t *testing.T) {
+	registers := []*vm.Value{nil}
+	m := &vm.VM{
+		Stack: []*vm.Scope{{Registers: registers}},
+		Stdin: strings.NewReader("foo\r\nbar\nbaz"),
+	}
+
+	var lines []string
+	eof := &vm.EOF{Result: 0}
+	for {
+		assert.NoError(t, eof.Execute(nil, m))
+		if registers[0].Bool {
+			break
+		}
+
+		assert.NoError(t, (&vm.ReadLine{Result: 0}).Execute(nil, m))
+		lines = append(lines, registers[0].Text)
+	}
+
+	assert.Equal(t, []string{"foo", "bar", "baz"}, lines)
+
+	assert.NoError(t, (&vm.ReadLine{Result: 0}).Execute(nil, m))
+	assert.Equal(t, vm.NewString(""), registers[0])
+}
+
+func TestReadAll_Execute(t *testing.T) {
+	for testName, test := range map[string]struct {
+		stdin    string
+		expected *vm.Value
+	}{
+		"empty": {
+			"",
+			vm.NewData([]byte{}),
+		},
+		"lines": {
+			"foo\nbar\n",
+			vm.NewData([]byte("foo\nbar\n")),
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{nil}
+			ins := &vm.ReadAll{Result: 0}
+			m := &vm.VM{
+				Stack: []*vm.Scope{{Registers: registers}},
+				Stdin: strings.NewReader(test.stdin),
+			}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expected, registers[ins.Result])
+		})
+	}
+}
+
+func TestWrite_Execute(t *testing.T) {
+	for testName, test := range map[string]struct {
+		stderr         bool
+		expectedStdout string
+		expectedStderr string
+	}{
+		"stdout": {false, "foo", ""},
+		"stderr": {true, "", "foo"},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			registers := []*vm.Value{vm.NewString("foo")}
+			ins := &vm.Write{Text: 0, Stderr: test.stderr}
+			stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+			m := &vm.VM{
+				Stack:  []*vm.Scope{{Registers: registers}},
+				Stdout: stdout,
+				Stderr: stderr,
+			}
+			assert.NoError(t, ins.Execute(nil, m))
+			assert.Equal(t, test.expectedStdout, stdout.String())
+			assert.Equal(t, test.expectedStderr, stderr.String())
+		})
+	}
+}
+
+func TestReadLine_Stream(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	registers := []*vm.Value{nil, nil}
+	m := &vm.VM{
+		Stack: []*vm.Scope{{Registers: registers}},
+		Stdin: r,
+	}
+
+	// Each line must be available as soon as it has been written, rather than
+	// when the input is closed.
+	lines := make(chan string)
+	go func() {
+		eof := &vm.EOF{Result: 0}
+		readLine := &vm.ReadLine{Result: 1}
+		for {
+			assert.NoError(t, eof.Execute(nil, m))
+			if registers[0].Bool {
+				close(lines)
+
+				return
+			}
+
+			assert.NoError(t, readLine.Execute(nil, m))
+			lines <- registers[1].Text
+		}
+	}()
+
+	for _, line := range []string{"one", "two"} {
+		go w.Write([]byte(line + "\n"))
+
+		select {
+		case actual := <-lines:
+			assert.Equal(t, line, actual)
+
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not read before the input was closed", line)
+		}
+	}
+
+	require.NoError(t, w.Close())
+	_, ok := <-lines
+	assert.False(t, ok)
+}
@@ -2,9 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
-	"github.com/elliotchance/ok/number"
 )
 
 // StringIndex returns a character from an index of a string.
@@ -14,10 +11,10 @@ type StringIndex struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *StringIndex) Execute(_ *int, vm *VM) error {
-	index := number.Int64(number.NewNumber(vm.Get(ins.Index).Value))
+	index := vm.Get(ins.Index).Int()
 
 	// TODO(elliot): This won't work with multibyte characters.
-	vm.Set(ins.Result, asttest.NewLiteralChar([]rune(vm.Get(ins.Str).Value)[index]))
+	vm.Set(ins.Result, NewChar([]rune(vm.Get(ins.Str).Text)[index]))
 
 	return nil
 }
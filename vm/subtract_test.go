@@ -3,8 +3,6 @@ package vm_test
 import (
 	"testing"
 
-	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
 	"github.com/elliotchance/ok/vm"
 
 	"github.com/stretchr/testify/assert"
@@ -18,21 +16,23 @@ func TestSubtract_Execute(t *testing.T) {
 		"maintain-precision": {"1.2200", "4.7", "-3.4800"},
 	} {
 		t.Run(testName, func(t *testing.T) {
-			registers := map[vm.Register]*ast.Literal{
-				"0": asttest.NewLiteralNumber(test.left),
-				"1": asttest.NewLiteralNumber(test.right),
+			registers := []*vm.Value{
+				vm.NewNumber(test.left),
+				vm.NewNumber(test.right),
+				nil,
+				nil,
 			}
-			ins := &vm.Subtract{Left: "0", Right: "1", Result: "2"}
+			ins := &vm.Subtract{Left: 0, Right: 1, Result: 2}
 			vm := &vm.VM{
-				Stack: []map[vm.Register]*ast.Literal{registers},
+				Stack: []*vm.Scope{{Registers: registers}},
 			}
 			assert.NoError(t, ins.Execute(nil, vm))
-			assert.Equal(t, test.expected, registers[ins.Result].Value)
+			assert.Equal(t, test.expected, registers[ins.Result].Number.String())
 		})
 	}
 }
 
 func TestSubtract_String(t *testing.T) {
-	ins := &vm.Subtract{Left: "1", Right: "2", Result: "3"}
+	ins := &vm.Subtract{Left: 1, Right: 2, Result: 3}
 	assert.Equal(t, "$3 = $1 - $2", ins.String())
 }
@@ -0,0 +1,48 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AssertionFailure is an assert in a test that did not pass.
+type AssertionFailure struct {
+	// Pos is the position of the assert, like "main.okt:3:5".
+	Pos string
+
+	// Message describes the failure, like "assert(1 == 2) failed".
+	Message string
+
+	// Details is optional. It may be several lines that explain the failure,
+	// such as the difference between values that were too long to be included
+	// in Message.
+	Details string
+}
+
+// String returns the position and message. Any details follow on the next
+// lines with each line indented.
+func (f *AssertionFailure) String() string {
+	s := fmt.Sprintf("%s: %s", f.Pos, f.Message)
+	if f.Details != "" {
+		s += "\n    " + strings.Replace(f.Details, "\n", "\n    ", -1)
+	}
+
+	return s
+}
+
+// TestResult is the outcome of running a single test. See VM.RunTests.
+type TestResult struct {
+	Name       string
+	Assertions int
+	Failures   []*AssertionFailure
+	Elapsed    time.Duration
+
+	// Output is everything the test printed.
+	Output string
+}
+
+// Passed is true when none of the asserts in the test failed.
+func (r *TestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
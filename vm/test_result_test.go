@@ -0,0 +1,311 @@
+package vm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elliotchance/ok/compiler"
+	"github.com/elliotchance/ok/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_RunTests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok-vm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "main.okt")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(`test "add one" {
+    assert(1 + 1 == 2)
+}
+
+test "add two" {
+    assert(1 + 2 == 4)
+    assert(2 + 2 == 4)
+    assert(3 + 2 == 6)
+}
+
+test "subtract" {
+    print("subtracting")
+    assert(2 - 1 == 1)
+}
+`), 0644))
+
+	pkg, errs := compiler.CompilePackage(dir, true)
+	require.Nil(t, errs)
+
+	all := []*vm.TestResult{
+		{Name: "add one", Assertions: 1},
+		{Name: "add two", Assertions: 3, Failures: []*vm.AssertionFailure{
+			{Pos: fileName + ":6:5", Message: "assert(3 == 4) failed"},
+			{Pos: fileName + ":8:5", Message: "assert(5 == 6) failed"},
+		}},
+		{Name: "subtract", Assertions: 1, Output: "subtracting\n"},
+	}
+
+	for testName, test := range map[string]struct {
+		filter   string
+		parallel int
+		expected []*vm.TestResult
+	}{
+		"all": {
+			expected: all,
+		},
+		"parallel": {
+			parallel: 3,
+			expected: all,
+		},
+		"filter": {
+			filter: "add*",
+			expected: []*vm.TestResult{
+				{Name: "add one", Assertions: 1},
+				{Name: "add two", Assertions: 3, Failures: []*vm.AssertionFailure{
+					{Pos: fileName + ":6:5", Message: "assert(3 == 4) failed"},
+					{Pos: fileName + ":8:5", Message: "assert(5 == 6) failed"},
+				}},
+			},
+		},
+		"no-match": {
+			filter: "multiply",
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+			m.Stdout = ioutil.Discard
+			m.TestFilter = test.filter
+			m.Parallel = test.parallel
+			require.NoError(t, m.RunTests())
+
+			for _, result := range m.TestResults {
+				result.Elapsed = 0
+			}
+			assert.Equal(t, test.expected, m.TestResults)
+		})
+	}
+}
+
+func TestVM_RunTests_Errors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok-vm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "main.okt")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(`func boom() {
+    raise Error("boom")
+}
+
+test "a" {
+    assert(1 == 1)
+}
+
+test "b" {
+    boom()
+    assert(1 == 1)
+}
+
+test "c" {
+    assert(2 == 2)
+}
+`), 0644))
+
+	pkg, errs := compiler.CompilePackage(dir, true)
+	require.Nil(t, errs)
+
+	// The error only fails the test that raised it. The tests after it are
+	// still run.
+	m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+	require.NoError(t, m.RunTests())
+
+	for _, result := range m.TestResults {
+		result.Elapsed = 0
+	}
+	assert.Equal(t, []*vm.TestResult{
+		{Name: "a", Assertions: 1},
+		{Name: "b", Failures: []*vm.AssertionFailure{
+			{
+				Pos:     fileName + ":2:5",
+				Message: "unhandled Error: boom",
+				Details: fileName + ":2:5 in boom\n" + fileName + ":10:5 in b",
+			},
+		}},
+		{Name: "c", Assertions: 1},
+	}, m.TestResults)
+	assert.Equal(t, 2, m.TestsPass)
+	assert.Equal(t, 1, m.TestsFailed)
+}
+
+func TestVM_RunTests_Cases(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ok-vm")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "main.okt")
+	require.NoError(t, ioutil.WriteFile(fileName, []byte(`test "add" for c in [[1, 2, 3], [2, 2, 5]] {
+    assert(c[0] + c[1] == c[2])
+}
+
+test "length" for want, s in {"a": 1, "bc": 2} {
+    print(s)
+    assert(len(s) == want)
+}
+`), 0644))
+
+	pkg, errs := compiler.CompilePackage(dir, true)
+	require.Nil(t, errs)
+
+	for testName, test := range map[string]struct {
+		filter   string
+		expected []*vm.TestResult
+	}{
+		"all": {
+			expected: []*vm.TestResult{
+				{Name: "add/0", Assertions: 1},
+				{Name: "add/1", Assertions: 1, Failures: []*vm.AssertionFailure{
+					{Pos: fileName + ":2:5", Message: "assert(4 == 5) failed"},
+				}},
+				{Name: "length/a", Assertions: 1, Output: "a\n"},
+				{Name: "length/bc", Assertions: 1, Output: "bc\n"},
+			},
+		},
+		"filter-test": {
+			filter: "length",
+			expected: []*vm.TestResult{
+				{Name: "length/a", Assertions: 1, Output: "a\n"},
+				{Name: "length/bc", Assertions: 1, Output: "bc\n"},
+			},
+		},
+		"filter-case": {
+			filter: "add/1",
+			expected: []*vm.TestResult{
+				{Name: "add/1", Assertions: 1, Failures: []*vm.AssertionFailure{
+					{Pos: fileName + ":2:5", Message: "assert(4 == 5) failed"},
+				}},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+			m.TestFilter = test.filter
+			require.NoError(t, m.RunTests())
+
+			for _, result := range m.TestResults {
+				result.Elapsed = 0
+			}
+			assert.Equal(t, test.expected, m.TestResults)
+			assert.Equal(t, len(test.expected), m.TestsFailed+m.TestsPass)
+		})
+	}
+}
+
+func TestVM_RunTests_AssertionFailures(t *testing.T) {
+	for testName, test := range map[string]struct {
+		src      string
+		expected []*vm.AssertionFailure
+	}{
+		"message": {
+			src: `a = 3
+    assert(a == 4, "a must be {a + 1}")`,
+			expected: []*vm.AssertionFailure{
+				{Message: "assert(3 == 4) failed: a must be 4"},
+			},
+		},
+		"long-values": {
+			src: `assert(12345678901234567890123456789012345678901 < 1)`,
+			expected: []*vm.AssertionFailure{
+				{
+					Message: "assert(left < right) failed",
+					Details: "left:  12345678901234567890123456789012345678901\nright: 1",
+				},
+			},
+		},
+		"string-diff": {
+			src: `assert("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk" == "a\nb\nc\nd\ne\nX\ng\nh\ni\nj\nk")`,
+			expected: []*vm.AssertionFailure{
+				{
+					Message: "assert(left == right) failed",
+					Details: "--- left\n+++ right\n@@ -3,7 +3,7 @@\n c\n d\n e\n-f\n+X\n g\n h\n i",
+				},
+			},
+		},
+		"array-diff": {
+			src: `assert([1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15] == [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 99, 15])`,
+			expected: []*vm.AssertionFailure{
+				{
+					Message: "assert(left == right) failed",
+					Details: "--- left\n+++ right\n@@ -12,6 +12,6 @@\n     11,\n     12,\n     13,\n-    14,\n+    99,\n     15,\n ]",
+				},
+			},
+		},
+		"map-diff": {
+			src: `assert({"a": "aaaaaaaaaaaaaaaa", "b": "bbbbbbbbbbbbbbbbb"} == {"a": "aaaaaaaaaaaaaaaa", "b": "c"})`,
+			expected: []*vm.AssertionFailure{
+				{
+					Message: "assert(left == right) failed",
+					Details: "--- left\n+++ right\n@@ -1,4 +1,4 @@\n {\n     \"a\": \"aaaaaaaaaaaaaaaa\",\n-    \"b\": \"bbbbbbbbbbbbbbbbb\",\n+    \"b\": \"c\",\n }",
+				},
+			},
+		},
+		"raise": {
+			src: `assert(fail(1) raise MyError)
+    assert(fail(1) raise Error)`,
+		},
+		"raise-nothing": {
+			src: `assert(fail(0) raise MyError, "why")`,
+			expected: []*vm.AssertionFailure{
+				{
+					Message: "assert(fail(0) raise MyError) failed: why",
+					Details: "nothing was raised",
+				},
+			},
+		},
+		"raise-other": {
+			src: `assert(fail(2) raise MyError)`,
+			expected: []*vm.AssertionFailure{
+				{
+					Message: "assert(fail(2) raise MyError) failed",
+					Details: "Error was raised",
+				},
+			},
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ok-vm")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.ok"), []byte(`func MyError() MyError {
+    Error = "my error"
+    Code = 1
+}
+
+func fail(n number) number {
+    if n == 1 {
+        raise MyError()
+    }
+    if n == 2 {
+        raise Error("other error")
+    }
+
+    return n
+}
+`), 0644))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.okt"),
+				[]byte("test \"foo\" {\n    "+test.src+"\n}\n"), 0644))
+
+			pkg, errs := compiler.CompilePackage(dir, true)
+			require.Nil(t, errs)
+
+			m := vm.NewVM(pkg.Funcs, pkg.Tests, pkg.Interfaces, "main")
+			require.NoError(t, m.RunTests())
+			require.Len(t, m.TestResults, 1)
+
+			for _, failure := range m.TestResults[0].Failures {
+				failure.Pos = ""
+			}
+			assert.Equal(t, test.expected, m.TestResults[0].Failures)
+		})
+	}
+}
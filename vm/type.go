@@ -2,8 +2,6 @@ package vm
 
 import (
 	"fmt"
-
-	"github.com/elliotchance/ok/ast/asttest"
 )
 
 // Type assigns the runtime type of a value to a string destination.
@@ -13,7 +11,7 @@ type Type struct {
 
 // Execute implements the Instruction interface for the VM.
 func (ins *Type) Execute(_ *int, vm *VM) error {
-	vm.Set(ins.Result, asttest.NewLiteralString(vm.Get(ins.Value).Kind))
+	vm.Set(ins.Result, NewString(vm.Get(ins.Value).Kind))
 
 	return nil
 }
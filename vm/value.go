@@ -0,0 +1,197 @@
+package vm
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/apd/v2"
+	"github.com/elliotchance/ok/ast"
+	"github.com/elliotchance/ok/compiler/kind"
+	"github.com/elliotchance/ok/number"
+)
+
+// Value is a runtime value. Unlike ast.Literal, which is only used for
+// constants in the instructions, values are already decoded so that they do
+// not have to be parsed each time they are used.
+//
+// Only the fields for the Kind are used:
+//
+//	number     Number
+//	bool       Bool
+//	char       Char
+//	string     Text
+//	data       Data
+//	[]T        Array
+//	{}T        Map and Keys
+//	func(...)  Text is the name of the function and Map is the parent scope.
+//	objects    Map contains the properties.
+//
+// Values for numbers, bools, chars, strings and data are never modified once
+// they are created, so they can be shared between registers.
+type Value struct {
+	Kind string
+
+	Number *apd.Decimal
+	Bool   bool
+	Char   rune
+	Text   string
+	Data   []byte
+	Array  []*Value
+	Map    map[string]*Value
+
+	// Keys contains the keys of a map in the order they were added. This is
+	// required for iteration.
+	Keys []string
+}
+
+var (
+	trueValue  = &Value{Kind: "bool", Bool: true}
+	falseValue = &Value{Kind: "bool", Bool: false}
+)
+
+// NewNumber creates a number value from its string representation.
+func NewNumber(s string) *Value {
+	return NewDecimal(number.NewNumber(s))
+}
+
+// NewDecimal creates a number value.
+func NewDecimal(d *apd.Decimal) *Value {
+	return &Value{Kind: "number", Number: d}
+}
+
+// NewInt creates a number value from an int.
+func NewInt(i int) *Value {
+	return NewDecimal(apd.New(int64(i), 0))
+}
+
+// NewString creates a string value.
+func NewString(s string) *Value {
+	return &Value{Kind: "string", Text: s}
+}
+
+// NewBool creates a bool value.
+func NewBool(b bool) *Value {
+	if b {
+		return trueValue
+	}
+
+	return falseValue
+}
+
+// NewChar creates a char value.
+func NewChar(c rune) *Value {
+	return &Value{Kind: "char", Char: c}
+}
+
+// NewData creates a data value.
+func NewData(data []byte) *Value {
+	return &Value{Kind: "data", Data: data}
+}
+
+// NewValue converts a literal into a value. A new value is always returned so
+// it is safe to modify.
+func NewValue(literal *ast.Literal) *Value {
+	if literal == nil {
+		return nil
+	}
+
+	v := &Value{Kind: literal.Kind}
+
+	switch {
+	case literal.Kind == "number":
+		v.Number = number.NewNumber(literal.Value)
+
+	case literal.Kind == "bool":
+		v.Bool = literal.Value == "true"
+
+	case literal.Kind == "char":
+		for _, c := range literal.Value {
+			v.Char = c
+			break
+		}
+
+	case literal.Kind == "string":
+		v.Text = literal.Value
+
+	case literal.Kind == "data":
+		v.Data = []byte(literal.Value)
+
+	case kind.IsArray(literal.Kind):
+		v.Array = make([]*Value, len(literal.Array))
+		for i, element := range literal.Array {
+			v.Array[i] = NewValue(element)
+		}
+
+	case kind.IsMap(literal.Kind):
+		v.Map = newValueMap(literal.Map)
+
+		// Array holds the keys of a map literal. It may not be provided.
+		for _, key := range literal.Array {
+			v.Keys = append(v.Keys, key.Value)
+		}
+
+		if len(v.Keys) == 0 {
+			for key := range v.Map {
+				v.Keys = append(v.Keys, key)
+			}
+			sort.Strings(v.Keys)
+		}
+
+	default:
+		// Functions and objects.
+		v.Text = literal.Value
+		v.Map = newValueMap(literal.Map)
+	}
+
+	return v
+}
+
+func newValueMap(m map[string]*ast.Literal) map[string]*Value {
+	if m == nil {
+		return nil
+	}
+
+	values := make(map[string]*Value, len(m))
+	for key, literal := range m {
+		values[key] = NewValue(literal)
+	}
+
+	return values
+}
+
+// constant returns the value for a literal in an instruction. Values that
+// cannot be modified are only decoded once.
+func (vm *VM) constant(literal *ast.Literal) *Value {
+	switch literal.Kind {
+	case "number", "bool", "char", "string", "data":
+		if v, ok := vm.constants[literal]; ok {
+			return v
+		}
+
+		if vm.constants == nil {
+			vm.constants = map[*ast.Literal]*Value{}
+		}
+
+		v := NewValue(literal)
+		vm.constants[literal] = v
+
+		return v
+	}
+
+	return NewValue(literal)
+}
+
+// String returns the value in the same format as print.
+func (v *Value) String() string {
+	return renderValue(v, false)
+}
+
+// Int returns the integer part of a number.
+func (v *Value) Int() int {
+	if v.Number.Exponent == 0 {
+		if i, err := v.Number.Int64(); err == nil {
+			return int(i)
+		}
+	}
+
+	return number.Int(v.Number)
+}
@@ -1,18 +1,26 @@
 package vm
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
-	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/elliotchance/ok/ast"
-	"github.com/elliotchance/ok/ast/asttest"
+	"github.com/elliotchance/ok/util"
 )
 
 // StateRegister is a reserved register for holding the map of the state.
-// Effectively the instance or "this" context.
-const StateRegister = "0"
+// Effectively the instance or "this" context. The upvalues of the function are
+// stored in the map.
+const StateRegister Register = 0
+
+// StateKey is the key of the parent scope in the map of the state.
+const StateKey = "0"
 
 // InternalDefinition is used by Lib to hold the definitions and compiled code
 // for internal functions.
@@ -27,37 +35,143 @@ var Packages map[string]bool
 var Constants map[string]*ast.Literal
 var Interfaces map[string]map[string]string
 
+// loadLib is called by the generated lib.go file to decode the bytecode of the
+// standard library.
+func loadLib(bytecode string) {
+	p, err := DecodeProgram(strings.NewReader(bytecode))
+	if err != nil {
+		panic(err)
+	}
+
+	Lib = map[string]*InternalDefinition{}
+	for name, fn := range p.Funcs {
+		Lib[name] = &InternalDefinition{
+			CompiledFunc: fn,
+			FuncDef:      p.FuncDefs[name],
+		}
+	}
+
+	Constants = p.Constants
+	Interfaces = p.Interfaces
+}
+
 // CompiledTest is a runnable test.
 type CompiledTest struct {
 	*CompiledFunc
 	TestName string
+
+	// Cases is optional. It returns the array or map of cases for a
+	// table-driven test. The test is run once for each case.
+	Cases *CompiledFunc
+
+	// FuzzTypes is the type of each argument for a fuzz test. See Fuzz.
+	FuzzTypes []string
 }
 
 // VM is an instance of a virtual machine to run ok instructions.
 type VM struct {
 	fns    map[string]*CompiledFunc
-	Return []Register
-	Stack  []map[Register]*ast.Literal
+	Return []*Value
+	Stack  []*Scope
 	tests  []*CompiledTest
 	pkg    string
+
+	// Stdin, Stdout and Stderr default to the streams of the process. They can
+	// be replaced before the VM is run.
+	Stdin  io.Reader
 	Stdout io.Writer
+	Stderr io.Writer
+
+	// stdin buffers Stdin so that it can be read in lines. stdinSource is the
+	// Stdin that it was created for.
+	stdin       *bufio.Reader
+	stdinSource io.Reader
+
+	// Args are the arguments passed to the program, not including the program
+	// name. They are returned by os.Args().
+	Args []string
+
+	// Env contains the environment variables used by os.Getenv, os.Setenv and
+	// os.Environ. It is copied from the process the first time it is needed,
+	// so changes made by one VM are not seen by any other VM.
+	Env map[string]string
+
+	// files are opened with FileOpen. lastFile is the most recent number used.
+	files    map[int]*openFile
+	lastFile int
 
 	// Stats when running tests.
 	TestsPass, TestsFailed int
 	TotalAssertions        int
-	CurrentTestName        string
-	CurrentTestPassed      bool
+
+	// TestResults contains a result for each test that has been run, in the
+	// order they were run.
+	TestResults []*TestResult
+
+	// Parallel is the maximum number of tests that RunTests will run at the
+	// same time. Values less than 1 are treated as 1.
+	Parallel int
+
+	// TestFilter is optional. When it is not empty only the tests with a name
+	// that matches the glob are run. See util.MatchesGlob.
+	TestFilter string
+
+	// FuzzCount is the number of random inputs for each fuzz test. If it is
+	// less than 1 then DefaultFuzzCount is used.
+	FuzzCount int
+
+	// FuzzSeed is used to generate the inputs for fuzz tests so that a failure
+	// can be reproduced. If it is zero when RunTests is called it will be set
+	// from the current time.
+	FuzzSeed int64
+
+	// FuzzCorpus is optional. It is the directory where the failing inputs of
+	// fuzz tests are saved. Each input that has been saved is also run before
+	// the random inputs.
+	FuzzCorpus string
+
+	// BenchResults contains a result for each benchmark that has been run by
+	// RunBenchmarks.
+	BenchResults []*BenchResult
+
+	// BenchFilter is the glob for the benchmarks that RunBenchmarks will run.
+	BenchFilter string
+
+	// BenchTime is how long each benchmark should run for. It defaults to one
+	// second.
+	BenchTime time.Duration
+
+	// instructions is the total number of instructions that have been
+	// executed.
+	instructions int64
 
 	// ErrType will be non-empty once an error is raised. It contains the type
 	// to match for a handler. ErrValue contains the actual error.
 	ErrType  string
-	ErrValue *ast.Literal
+	ErrValue *Value
 
 	// FinallyBlocks are stacked with stack.
 	FinallyBlocks [][]*FinallyBlock
 
 	// Interfaces describes all the interfaces types known by the VM.
 	Interfaces map[string]map[string]string
+
+	// Frames contains each function that is currently running. The last
+	// element is the function currently being executed.
+	Frames []*Frame
+
+	// Debugger is optional. See Debugger.
+	Debugger Debugger
+
+	// Coverage is optional. When it is not nil the position of each statement
+	// is added as it is run. See the cover package.
+	Coverage map[string]bool
+
+	// errStack is the stack trace from where the current error was raised.
+	errStack []Frame
+
+	// constants contains the decoded values of literals. See VM.constant.
+	constants map[*ast.Literal]*Value
 }
 
 // NewVM will create a new VM ready to run the provided instructions.
@@ -71,30 +185,125 @@ func NewVM(
 		fns:        fns,
 		tests:      tests,
 		pkg:        pkg,
+		Stdin:      os.Stdin,
 		Stdout:     os.Stdout,
+		Stderr:     os.Stderr,
 		Interfaces: interfaces,
 	}
 }
 
 // Run will run the program.
 func (vm *VM) Run() error {
-	_, err := vm.call("main", nil, map[string]*ast.Literal{}, "any")
+	_, err := vm.call("main", nil, map[string]*Value{}, "any")
+	if err != nil {
+		return err
+	}
 
-	vm.catchUnhandledError()
+	vm.Stack = vm.Stack[:len(vm.Stack)-1]
 
-	return err
+	return vm.unhandledError()
 }
 
-// Run will run the tests only.
+// Eval runs fn in the top level scope. Unlike Run, the scope is kept after fn
+// has finished so that its variables are available to the next call to Eval.
+// This is used by the REPL.
+//
+// An unhandled error is returned, rather than causing a panic, and the VM can
+// continue to be used.
+func (vm *VM) Eval(name string, fn *CompiledFunc) error {
+	if len(vm.Stack) == 0 {
+		vm.appendStack(fn, map[string]*Value{}, "any")
+	} else {
+		// Each input has its own registers but they all share the same state.
+		// This works because all of the variables are upvalues. See
+		// CompiledFunc.ShareVariables.
+		scope := vm.Stack[len(vm.Stack)-1]
+		state := scope.Registers[StateRegister]
+		*scope = *newScope(fn)
+		scope.Registers[StateRegister] = state
+	}
+
+	stackLen := len(vm.Stack)
+	finallyLen := len(vm.FinallyBlocks)
+
+	_, err := vm.runFunc(name, fn)
+
+	// A return at the top level has nowhere to go.
+	vm.Return = nil
+
+	if err != nil {
+		// The VM panicked part way through a call. The scopes have to be
+		// restored so the VM can continue to be used.
+		vm.Stack = vm.Stack[:stackLen]
+		vm.FinallyBlocks = vm.FinallyBlocks[:finallyLen]
+
+		return err
+	}
+
+	return vm.unhandledError()
+}
+
+// RunTests will run the tests only. Each test is run in its own VM so that
+// nothing can leak from one test into another. Up to Parallel tests are run at
+// the same time, but the results are always in the same order as the tests.
+//
+// A table-driven test is run once for each of its cases. Each case has its own
+// TestResult, like "add/0" for an array or "add/negative" for a map.
+//
+// The output of each test is buffered in its TestResult rather than being
+// written to Stdout. A test that stops because of an error, such as an
+// unhandled error, is recorded as a failure of that test. An error is only
+// returned if the tests could not be run.
 func (vm *VM) RunTests() error {
-	for _, t := range vm.tests {
-		vm.CurrentTestPassed = true
-		err := vm.runTest(t.TestName, t.Instructions, map[string]*ast.Literal{})
-		if err != nil {
-			return err
+	tests, err := vm.testCases()
+	if err != nil {
+		return err
+	}
+
+	if vm.FuzzSeed == 0 {
+		vm.FuzzSeed = time.Now().UnixNano()
+	}
+
+	parallel := vm.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	machines := make([]*VM, len(tests))
+	errs := make([]error, len(tests))
+	running := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, t := range tests {
+		machines[i] = vm.newTestVM(t.name)
+
+		wg.Add(1)
+		running <- struct{}{}
+		go func(m *VM, t *testCase, err *error) {
+			defer wg.Done()
+			if len(t.test.FuzzTypes) > 0 {
+				*err = m.runFuzz(t.test)
+			} else {
+				*err = m.runTest(t.test, t.arguments, map[string]*Value{})
+			}
+			<-running
+		}(machines[i], t, &errs[i])
+	}
+	wg.Wait()
+
+	for i, m := range machines {
+		result := m.TestResults[0]
+		result.Output = m.Stdout.(*bytes.Buffer).String()
+		vm.TestResults = append(vm.TestResults, result)
+		vm.TotalAssertions += m.TotalAssertions
+		vm.mergeCoverage(m)
+
+		// An error, such as an unhandled error raised by the test, fails the
+		// test rather than stopping the tests that follow it.
+		if errs[i] != nil {
+			result.Failures = append(result.Failures, errorFailure(errs[i]))
 		}
 
-		if vm.CurrentTestPassed {
+		if result.Passed() {
 			vm.TestsPass++
 		} else {
 			vm.TestsFailed++
@@ -104,53 +313,183 @@ func (vm *VM) RunTests() error {
 	return nil
 }
 
-func (vm *VM) appendStack(parentScope map[string]*ast.Literal, returnType string) {
-	registers := map[Register]*ast.Literal{}
-	vm.Stack = append(vm.Stack, registers)
+// testCase is a single run of a test. A table-driven test will have one
+// testCase for each of its cases.
+type testCase struct {
+	test      *CompiledTest
+	name      string
+	arguments []*Value
+}
+
+// testCases returns the tests that match TestFilter. The filter may match
+// either the name of a table-driven test or the name of one of its cases.
+func (vm *VM) testCases() ([]*testCase, error) {
+	var tests []*testCase
+	for _, t := range vm.tests {
+		if t.Cases == nil {
+			if vm.matchesTestFilter(t.TestName) {
+				tests = append(tests, &testCase{test: t, name: t.TestName})
+			}
+
+			continue
+		}
+
+		cases, err := vm.evalTestCases(t)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range cases {
+			if vm.matchesTestFilter(t.TestName) || vm.matchesTestFilter(c.name) {
+				tests = append(tests, c)
+			}
+		}
+	}
+
+	return tests, nil
+}
+
+func (vm *VM) matchesTestFilter(name string) bool {
+	return vm.TestFilter == "" || util.MatchesGlob(name, vm.TestFilter)
+}
+
+// evalTestCases runs the Cases function of a table-driven test. The value and
+// key (the index for an array) of each case become the arguments of the test.
+func (vm *VM) evalTestCases(test *CompiledTest) ([]*testCase, error) {
+	m := vm.newTestVM(test.TestName)
+	m.appendStack(test.Cases, map[string]*Value{}, "any")
+	returns, err := m.runFunc(test.TestName, test.Cases)
+	if err == nil {
+		err = m.unhandledError()
+	}
+	vm.mergeCoverage(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []*testCase
+	if value := returns[0]; value.Map != nil {
+		for _, key := range value.Keys {
+			cases = append(cases, &testCase{
+				test:      test,
+				name:      fmt.Sprintf("%s/%s", test.TestName, key),
+				arguments: []*Value{value.Map[key], NewString(key)},
+			})
+		}
+	} else {
+		for i, element := range value.Array {
+			cases = append(cases, &testCase{
+				test:      test,
+				name:      fmt.Sprintf("%s/%d", test.TestName, i),
+				arguments: []*Value{element, NewInt(i)},
+			})
+		}
+	}
+
+	return cases, nil
+}
+
+// newTestVM creates the VM that will run a single test.
+func (vm *VM) newTestVM(name string) *VM {
+	m := NewVM(vm.fns, nil, vm.Interfaces, vm.pkg)
+
+	// Tests cannot read input, and anything written to stderr is part of the
+	// output of the test.
+	output := new(bytes.Buffer)
+	m.Stdin = strings.NewReader("")
+	m.Stdout = output
+	m.Stderr = output
+	m.TestResults = []*TestResult{
+		{Name: name},
+	}
+	if vm.Coverage != nil {
+		m.Coverage = map[string]bool{}
+	}
+	m.FuzzCount = vm.FuzzCount
+	m.FuzzSeed = vm.FuzzSeed
+	m.FuzzCorpus = vm.FuzzCorpus
+
+	return m
+}
+
+func (vm *VM) mergeCoverage(m *VM) {
+	for pos := range m.Coverage {
+		vm.Coverage[pos] = true
+	}
+}
+
+func (vm *VM) appendStack(fn *CompiledFunc, parentScope map[string]*Value, returnType string) {
+	scope := newScope(fn)
+	vm.Stack = append(vm.Stack, scope)
 
 	// Setup a new state, even we don't use it.
-	vm.Set(StateRegister, &ast.Literal{
+	scope.Registers[StateRegister] = &Value{
 		Kind: returnType,
-		Map: map[string]*ast.Literal{
-			StateRegister: {
+		Map: map[string]*Value{
+			StateKey: {
 				Kind: "any",
 				Map:  parentScope,
 			},
 		},
-	})
+	}
 }
 
-func (vm *VM) call(name string, arguments []Register, parentScope map[string]*ast.Literal, returnType string) ([]Register, error) {
-	// TODO(elliot): Check function exists, especially main.
-
-	vm.appendStack(parentScope, returnType)
-
-	// Copy the registers of this context into the new call context.
+// call runs a function in a new scope. The scope is left on the stack so the
+// caller is responsible for removing it.
+func (vm *VM) call(name string, arguments []*Value, parentScope map[string]*Value, returnType string) ([]*Value, error) {
 	fn := vm.fns[name]
 
 	// TODO(elliot): It's probably not a good idea to fallback onto the standard
 	//  lib. Perhaps the compiler can append these functions so this isn't
 	//  necessary?
-	if fn == nil {
+	if fn == nil && Lib[name] != nil {
 		fn = Lib[name].CompiledFunc
 	}
 
+	// The compiler will not allow a call to a function that does not exist.
+	// However, a package without a main function can still be run, and
+	// bytecode may be corrupt.
+	if fn == nil {
+		message := fmt.Sprintf("no such function: %s", name)
+		if name == "main" && len(vm.Frames) == 0 {
+			message = fmt.Sprintf("no main function in package %s", vm.pkg)
+		}
+
+		return nil, &RuntimeError{
+			Message: message,
+			Stack:   vm.stackTrace(),
+		}
+	}
+
+	vm.appendStack(fn, parentScope, returnType)
+
+	// The arguments are always the first registers.
+	for i, arg := range arguments {
+		vm.Set(Register(i+1), arg)
+	}
+
+	return vm.runFunc(name, fn)
+}
+
+// runFunc runs the instructions, and any finally blocks, for a function in the
+// current scope.
+func (vm *VM) runFunc(name string, fn *CompiledFunc) ([]*Value, error) {
 	// Setup the finally blocks. Copy so they all start disabled.
 	var finallyBlocks []*FinallyBlock
-	for _, ins := range fn.Finally {
-		finallyBlocks = append(finallyBlocks, &FinallyBlock{
+	for i, ins := range fn.Finally {
+		finallyBlock := &FinallyBlock{
 			Run:          false,
 			Instructions: ins,
-		})
+		}
+		if i < len(fn.FinallyPositions) {
+			finallyBlock.Positions = fn.FinallyPositions[i]
+		}
+		finallyBlocks = append(finallyBlocks, finallyBlock)
 	}
 	vm.FinallyBlocks = append(vm.FinallyBlocks, finallyBlocks)
 
-	// Copy the arguments in.
-	for i, arg := range arguments {
-		vm.Set(Register(fn.Arguments[i]), vm.get(arg, 2))
-	}
-
-	returns, err := vm.runInstructions(name, fn.Instructions, false)
+	returns, err := vm.runInstructions(name, fn, fn.Instructions,
+		fn.Positions, false)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +499,8 @@ func (vm *VM) call(name string, arguments []Register, parentScope map[string]*as
 			// Ignore returns here.
 			// TODO(elliot): The compiler must disallow return
 			//  statements within a finally block.
-			_, err := vm.runInstructions(name, fb.Instructions, true)
+			_, err := vm.runInstructions(name, fn, fb.Instructions,
+				fb.Positions, true)
 			if err != nil {
 				return nil, err
 			}
@@ -172,89 +512,73 @@ func (vm *VM) call(name string, arguments []Register, parentScope map[string]*as
 	return returns, nil
 }
 
-func (vm *VM) dumpMemory() {
-	// TODO(elliot): It would be nice to have both of these sorted.
-
-	fmt.Printf("Registers:\n")
-
-	for n, v := range vm.Stack[len(vm.Stack)-1] {
-		if n == StateRegister {
-			fmt.Printf("  %s: <State>\n", n)
-		} else {
-			fmt.Printf("  %s: %v\n", n, v)
-		}
-	}
-
-	fmt.Printf("\nState:\n")
-
-	for n, v := range vm.Stack[len(vm.Stack)-1][StateRegister].Map {
-		fmt.Printf("  %s: %v\n", n, v)
+func (vm *VM) runInstructions(funcName string, fn *CompiledFunc, ins []Instruction, positions []string, inFinally bool) (_ []*Value, err error) {
+	i := 0
+	frame := &Frame{
+		FuncName: funcName,
+		Func:     fn,
+		Scope:    vm.Stack[len(vm.Stack)-1],
 	}
-}
-
-func (vm *VM) recoverPanic(funcName string, ins []Instruction, i *int) func() {
-	return func() {
+	vm.Frames = append(vm.Frames, frame)
+	defer func() {
+		// The frame is still needed for the stack trace.
 		if r := recover(); r != nil {
-			// i+1 because the first instruction shown in "ok asm" is #1.
-			fmt.Printf("VM panicked in function %s at instruction #%d: %s\n\n",
-				funcName, *i+1, ins[*i].String())
-			vm.dumpMemory()
-
-			fmt.Println()
-			fmt.Println(r)
-			fmt.Println(string(debug.Stack()))
-			os.Exit(1)
+			err = vm.panicError(i, ins, r)
 		}
-	}
-}
 
-func (vm *VM) runInstructions(funcName string, ins []Instruction, inFinally bool) ([]Register, error) {
-	i := 0
-	defer vm.recoverPanic(funcName, ins, &i)()
+		vm.Frames = vm.Frames[:len(vm.Frames)-1]
+	}()
 
 	totalInstructions := len(ins)
 	for ; i < totalInstructions; i++ {
 		ins := ins[i]
 
-		// If we are in an error state, we keep moving forward until we find an
-		// appropriate error handler.
-		//
-		// TODO(elliot): This can not differentiate a handler in a lower scope
-		//  that should be ignored. It would be best for raise to provide a jump
-		//  to the first (or each) of the handlers, ideally.
-		if vm.ErrType != "" && !inFinally {
-			if on, ok := ins.(*On); ok {
-				switch on.Type {
-				case vm.ErrType,
-					// TODO(elliot): This is a stupid hack for now. This was
-					//  created before interfaces could determine this properly.
-					"Error":
-
-					// TODO(elliot): The err register might be better as a
-					//  fixed position register rather than a variable?
-					vm.Set("err", vm.ErrValue)
-
-					// We found the handler. Remove the error state and continue
-					// as normal. There is a jump at the end of the handler that
-					// will launch us out when it's done.
-					vm.ErrType = ""
-
-				case "":
-					// An empty type signals the end of the error handlers.
-					// Making it to here means none of the error handlers worked
-					// for us. We need to return now and let the parent scope
-					// try to handle it.
-					return nil, nil
-				}
+		// The debugger only needs to know when a new statement starts.
+		newStatement := false
+		if i < len(positions) && positions[i] != "" && positions[i] != frame.Pos {
+			frame.Pos = positions[i]
+			newStatement = true
+		}
 
-			}
+		if newStatement && vm.Coverage != nil {
+			vm.Coverage[frame.Pos] = true
+		}
 
-			continue
+		if newStatement && vm.Debugger != nil {
+			vm.Debugger.BeforeStatement(vm)
 		}
 
+		vm.instructions++
 		err := ins.Execute(&i, vm)
 		if err != nil {
-			return nil, err
+			return nil, vm.instructionError(err)
+		}
+
+		// Keep the stack trace from where the error was raised. It would
+		// otherwise be lost as the error moves up to a handler.
+		if vm.ErrType != "" && vm.errStack == nil {
+			vm.errStack = vm.stackTrace()
+		}
+
+		// Errors raised in a finally block are not handled here. They are
+		// passed up to the caller when the finally block is done.
+		if vm.ErrType != "" && !inFinally {
+			on := vm.handler(fn, i)
+			if on == nil {
+				// There is no handler in this function. The caller will try
+				// to handle it.
+				return nil, nil
+			}
+
+			vm.Set(on.Err, vm.ErrValue)
+			vm.ErrType = ""
+			vm.errStack = nil
+
+			// The "-1" is to correct for the "+1" that happens after every
+			// instruction.
+			i = on.To - 1
+
+			continue
 		}
 
 		if vm.Return != nil && !inFinally {
@@ -265,84 +589,74 @@ func (vm *VM) runInstructions(funcName string, ins []Instruction, inFinally bool
 	return nil, nil
 }
 
-func (vm *VM) catchUnhandledError() {
-	if vm.ErrType != "" {
-		// TODO(elliot): This needs to be handled much more gracefully.
-		panic(fmt.Sprintf("unhandled %s: %+v", vm.ErrType, vm.ErrValue.Map["Error"]))
-	}
-}
-
-func (vm *VM) runTest(testName string, instructions []Instruction, parentScope map[string]*ast.Literal) error {
-	vm.CurrentTestName = testName
-
-	vm.appendStack(parentScope, "any")
-	_, err := vm.runInstructions(testName, instructions, false)
-
-	vm.catchUnhandledError()
+// runTest must only be used on a VM from newTestVM.
+func (vm *VM) runTest(test *CompiledTest, arguments []*Value, parentScope map[string]*Value) error {
+	result := vm.TestResults[0]
+	startTime := time.Now()
+	err := vm.runTestFunc(test, arguments, parentScope)
+	result.Elapsed = time.Since(startTime)
 
 	return err
 }
 
-func (vm *VM) assert(pass bool, left, op, right, pos string) {
-	if !pass {
-		fmt.Printf("%s: %s: %s: assert(%s %s %s) failed\n",
-			vm.pkg, pos, vm.CurrentTestName, left, op, right)
-		vm.CurrentTestPassed = false
-	}
-	vm.TotalAssertions++
-}
+// runTestFunc runs the statements of the test once.
+func (vm *VM) runTestFunc(test *CompiledTest, arguments []*Value, parentScope map[string]*Value) error {
+	vm.appendStack(test.CompiledFunc, parentScope, "any")
 
-func isRegister(register Register) bool {
-	r := register[0]
+	// The arguments of a table-driven test are the value and key of the case.
+	// The key is only used when the test declares it.
+	for i := range test.Arguments {
+		vm.Set(Register(i+1), arguments[i])
+	}
 
-	return r >= '0' && r <= '9'
-}
+	_, err := vm.runInstructions(test.TestName, test.CompiledFunc,
+		test.Instructions, test.Positions, false)
+	vm.Stack = vm.Stack[:len(vm.Stack)-1]
+	if err == nil {
+		err = vm.unhandledError()
+	}
 
-// Set will set a register.
-func (vm *VM) Set(register Register, val *ast.Literal) {
-	vm.set(register, val, 1)
+	return err
 }
 
-func (vm *VM) set(register Register, val *ast.Literal, offset int) {
-	switch {
-	case register[0] == '^':
-		parentScope := vm.Stack[len(vm.Stack)-1][StateRegister].Map[StateRegister].Map
-		parentScope[string(register[1:])] = val
-
-	case isRegister(register):
-		vm.Stack[len(vm.Stack)-offset][register] = val
+// assert records the result of an assertion. failure is nil if the assertion
+// passed.
+func (vm *VM) assert(failure *AssertionFailure) {
+	vm.TotalAssertions++
 
-	default:
-		vm.Stack[len(vm.Stack)-offset][StateRegister].Map[string(register)] = val
-	}
-}
+	// An assert outside of a test (such as with "ok run") has no result to be
+	// added to.
+	if len(vm.TestResults) == 0 {
+		if failure != nil {
+			fmt.Fprintf(vm.Stdout, "%s: %s\n", vm.pkg, failure)
+		}
 
-// Get will get a register.
-func (vm *VM) get(register Register, offset int) *ast.Literal {
-	if register[0] == '^' {
-		parentScope := vm.Stack[len(vm.Stack)-1][StateRegister].Map[StateRegister].Map
-		return parentScope[string(register[1:])]
+		return
 	}
 
-	if isRegister(register) {
-		return vm.Stack[len(vm.Stack)-offset][register]
+	result := vm.TestResults[len(vm.TestResults)-1]
+	result.Assertions++
+	if failure != nil {
+		result.Failures = append(result.Failures, failure)
 	}
+}
 
-	return vm.Stack[len(vm.Stack)-offset][StateRegister].Map[string(register)]
+// Set will set a register.
+func (vm *VM) Set(register Register, val *Value) {
+	vm.Stack[len(vm.Stack)-1].Set(register, val)
 }
 
 // Get will get a register.
-func (vm *VM) Get(register Register) *ast.Literal {
-	return vm.get(register, 1)
+func (vm *VM) Get(register Register) *Value {
+	return vm.Stack[len(vm.Stack)-1].Get(register)
 }
 
 func (vm *VM) Raise(message string) {
 	vm.ErrType = "Error"
-	vm.ErrValue = &ast.Literal{
-		Kind:  "Error",
-		Array: []*ast.Literal{asttest.NewLiteralString("Error")},
-		Map: map[string]*ast.Literal{
-			"Error": asttest.NewLiteralString(message),
+	vm.ErrValue = &Value{
+		Kind: "Error",
+		Map: map[string]*Value{
+			"Error": NewString(message),
 		},
 	}
 }
@@ -1,6 +1,7 @@
 package vm_test
 
 import (
+	"io/ioutil"
 	"testing"
 
 	"github.com/elliotchance/ok/compiler"
@@ -27,8 +28,8 @@ func add(a, b number) number {
 }
 `, "a.ok")
 		require.Nil(t, p.Errors())
-		f, err := compiler.CompileFile(p.File, nil, nil)
-		require.NoError(t, err)
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
 
 		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
 		assert.NoError(t, m.Run())
@@ -49,10 +50,149 @@ func main() {
 }
 `, "a.ok")
 		require.Nil(t, p.Errors())
-		f, err := compiler.CompileFile(p.File, nil, nil)
-		require.NoError(t, err)
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
 
 		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
 		assert.NoError(t, m.Run())
 	})
+	t.Run("unhandled-error-has-stack-trace", func(t *testing.T) {
+		p := parser.ParseString(`
+func f2(x number) {
+    if x > 1 {
+        raise Error("boom")
+    }
+}
+
+func f1() {
+    try {
+        f2(0)
+        f2(3)
+    } on Error {
+        print("handled")
+    }
+    f2(2)
+}
+
+func main() {
+    f1()
+}
+`, "a.ok")
+		require.Nil(t, p.Errors())
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
+
+		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
+		m.Stdout = ioutil.Discard
+		err := m.Run()
+		require.IsType(t, &vm.RuntimeError{}, err)
+		assert.Equal(t, "unhandled Error: boom\n"+
+			"    a.ok:4:9 in f2\n"+
+			"    a.ok:15:5 in f1\n"+
+			"    a.ok:19:5 in main\n",
+			err.(*vm.RuntimeError).StackTrace())
+	})
+
+	t.Run("panic-has-stack-trace", func(t *testing.T) {
+		p := parser.ParseString(`
+func f1() {
+    a = [1]
+    print(a[5])
+}
+
+func main() {
+    f1()
+}
+`, "a.ok")
+		require.Nil(t, p.Errors())
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
+
+		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
+		err := m.Run()
+		require.IsType(t, &vm.RuntimeError{}, err)
+		assert.Equal(t, []vm.Frame{
+			{FuncName: "f1", Pos: "a.ok:4:5"},
+			{FuncName: "main", Pos: "a.ok:8:5"},
+		}, err.(*vm.RuntimeError).Stack)
+		assert.Contains(t, err.Error(), "index out of range")
+		assert.Empty(t, m.Frames)
+	})
+
+	t.Run("no-main", func(t *testing.T) {
+		p := parser.ParseString(`
+func f1() {}
+`, "a.ok")
+		require.Nil(t, p.Errors())
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
+
+		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
+		err := m.Run()
+		require.IsType(t, &vm.RuntimeError{}, err)
+		assert.EqualError(t, err, "no main function in package pkg")
+	})
+
+	t.Run("missing-function-has-stack-trace", func(t *testing.T) {
+		p := parser.ParseString(`
+func f1() {}
+
+func main() {
+    f1()
+}
+`, "a.ok")
+		require.Nil(t, p.Errors())
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
+
+		// This can only happen with corrupt bytecode.
+		delete(f.Funcs, "f1")
+
+		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
+		err := m.Run()
+		require.IsType(t, &vm.RuntimeError{}, err)
+		assert.Equal(t, "no such function: f1\n"+
+			"    a.ok:5:5 in main\n",
+			err.(*vm.RuntimeError).StackTrace())
+	})
+
+	t.Run("division-by-zero-has-stack-trace", func(t *testing.T) {
+		p := parser.ParseString(`
+func f1(a number) number {
+    return 1 / a
+}
+
+func main() {
+    print(f1(0))
+}
+`, "a.ok")
+		require.Nil(t, p.Errors())
+		f, errs := compiler.CompileFile(p.File, nil, nil)
+		require.Nil(t, errs)
+
+		m := vm.NewVM(f.Funcs, f.Tests, f.Interfaces, "pkg")
+		err := m.Run()
+		require.IsType(t, &vm.RuntimeError{}, err)
+		assert.Equal(t, "division by zero\n"+
+			"    a.ok:3:5 in f1\n"+
+			"    a.ok:7:5 in main\n",
+			err.(*vm.RuntimeError).StackTrace())
+		assert.Empty(t, m.Frames)
+	})
+}
+
+func TestRuntimeError_StackTrace(t *testing.T) {
+	err := &vm.RuntimeError{
+		Message: "unhandled Error: boom",
+		Stack: []vm.Frame{
+			{FuncName: "f2", Pos: "main.ok:12:5"},
+			{FuncName: "Error"},
+			{FuncName: "main", Pos: "main.ok:30:9"},
+		},
+	}
+	assert.Equal(t, "unhandled Error: boom", err.Error())
+	assert.Equal(t, "unhandled Error: boom\n"+
+		"    main.ok:12:5 in f2\n"+
+		"    in Error\n"+
+		"    main.ok:30:9 in main\n", err.StackTrace())
 }